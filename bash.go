@@ -3,25 +3,90 @@ package mcptools
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/shaharia-lab/goai"
 )
 
 const BashToolName = "bash"
 
+// bashWaitDelay bounds how long output pipes held open by background children of a cancelled
+// command are waited for before they are closed
+const bashWaitDelay = time.Second
+
+// DefaultBashMaxOutputBytes is the output cap of the Bash tool when BashConfig.MaxOutputBytes is unset
+const DefaultBashMaxOutputBytes = 1 << 20
+
+// maxBashRetries is the largest number of retries a bash tool call may request
+const maxBashRetries = 10
+
+// defaultBashRetryDelay is the wait before the first retry of a failed command when a call
+// sets no retry_delay_ms
+const defaultBashRetryDelay = time.Second
+
+// DefaultBashShell is the shell commands run in when neither BashConfig.Shell nor the call names one
+const DefaultBashShell = "bash"
+
+// DefaultBashAllowedShells are the shells a call may name when BashConfig.AllowedShells is nil
+var DefaultBashAllowedShells = []string{"sh", "bash", "zsh", "dash"}
+
 // Bash represents a wrapper around the system's bash command-line tool
 type Bash struct {
 	logger      goai.Logger
 	cmdExecutor CommandExecutor
+	config      BashConfig
+}
+
+// BashConfig holds the configuration of the Bash tool
+type BashConfig struct {
+	// WorkingDirRoot, when set, restricts working_dir to this directory and its descendants.
+	// Relative working directories are resolved against it, and commands without a
+	// working_dir run in it.
+	WorkingDirRoot string
+	// BaseEnv is added to the inherited environment of every command. Variables passed in a
+	// call's env override it.
+	BaseEnv map[string]string
+	// BlockedCommands rejects command lines running a matching command. Entries match a
+	// command's binary or its full text, case-insensitively, and may use "*" as a wildcard,
+	// e.g. "rm" or "git push *".
+	BlockedCommands []string
+	// AllowedCommands, when not empty, rejects command lines running any command that matches
+	// none of its entries. Entries use the same syntax as BlockedCommands.
+	AllowedCommands []string
+	// MaxOutputBytes caps the output returned by a command or pipeline; output beyond it is
+	// discarded and replaced by a truncation marker. Zero means DefaultBashMaxOutputBytes and a
+	// negative value disables the cap.
+	MaxOutputBytes int
+	// RedactionRules scrub secrets from everything the tool logs. Nil means DefaultRedactionRules.
+	RedactionRules []RedactionRule
+	// Shell is the shell, a name looked up on PATH or a path, that commands are run in with
+	// "-c", such as "sh" on images without bash. Empty means DefaultBashShell.
+	Shell string
+	// AllowedShells are the shells a call may name instead of Shell, matched exactly, so that a
+	// call cannot run its command with an interpreter such as python3. Nil means
+	// DefaultBashAllowedShells.
+	AllowedShells []string
 }
 
 // NewBash creates a new instance of the Bash wrapper
 func NewBash(logger goai.Logger) *Bash {
+	return NewBashWithConfig(logger, BashConfig{})
+}
+
+// NewBashWithConfig creates a new instance of the Bash wrapper with the provided configuration
+func NewBashWithConfig(logger goai.Logger, config BashConfig) *Bash {
 	return &Bash{
-		logger:      logger,
+		logger:      newRedactingLogger(logger, config.RedactionRules),
 		cmdExecutor: &RealCommandExecutor{},
+		config:      config,
 	}
 }
 
@@ -43,37 +108,530 @@ func (b *Bash) BashAllInOneTool() goai.Tool {
                         "type": "string"
                     },
                     "description": "Additional arguments for the command"
+                },
+                "shell": {
+                    "type": "string",
+                    "description": "Shell the command runs in, one of the allowed shells such as \"sh\" or \"zsh\". Omit for the configured shell"
+                },
+                "stdin": {
+                    "type": "string",
+                    "description": "Data written to the stdin of the command, or of the first pipeline stage"
+                },
+                "auto_answer": {
+                    "type": "string",
+                    "description": "Answer (e.g. \"yes\") fed repeatedly on stdin to interactive prompts, after any stdin. Omit to disable"
+                },
+                "pipeline": {
+                    "type": "array",
+                    "items": {
+                        "type": "object",
+                        "properties": {
+                            "program": {"type": "string", "description": "Program to run"},
+                            "args": {"type": "array", "items": {"type": "string"}, "description": "Program arguments"}
+                        },
+                        "required": ["program"]
+                    },
+                    "description": "Stages run without a shell, each stage's stdout piped to the next stage's stdin. Use instead of command"
+                },
+                "working_dir": {
+                    "type": "string",
+                    "description": "Directory the command or pipeline runs in"
+                },
+                "env": {
+                    "type": "object",
+                    "additionalProperties": {"type": "string"},
+                    "description": "Environment variables set for the command or pipeline, overriding inherited ones"
+                },
+                "timeout_seconds": {
+                    "type": "integer",
+                    "description": "Maximum number of seconds the command or pipeline may run. Omit or 0 for no timeout"
+                },
+                "retries": {
+                    "type": "integer",
+                    "description": "Number of times a failing command or pipeline is run again (default 0, at most 10)"
+                },
+                "retry_delay_ms": {
+                    "type": "integer",
+                    "description": "Milliseconds waited before the first retry, doubling before each further one (default 1000)"
+                },
+                "output_format": {
+                    "type": "string",
+                    "enum": ["text", "json"],
+                    "description": "Result of a command: text for the combined output (default) or json for separate stdout and stderr with exit_code and duration_ms. Pipelines always report JSON"
                 }
-            },
-            "required": ["command"]
+            }
         }`),
 		Handler: func(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
 			var input struct {
-				Command string   `json:"command"`
-				Args    []string `json:"args"`
+				Command        string            `json:"command"`
+				Args           []string          `json:"args"`
+				Shell          string            `json:"shell"`
+				Stdin          string            `json:"stdin"`
+				AutoAnswer     string            `json:"auto_answer"`
+				Pipeline       []pipelineStage   `json:"pipeline"`
+				WorkingDir     string            `json:"working_dir"`
+				Env            map[string]string `json:"env"`
+				TimeoutSeconds int               `json:"timeout_seconds"`
+				OutputFormat   string            `json:"output_format"`
+				Retries        int               `json:"retries"`
+				RetryDelayMS   int               `json:"retry_delay_ms"`
 			}
 
-			b.logger.WithFields(map[string]interface{}{"tool": BashToolName}).Info("Received input", "input", string(params.Arguments))
+			// The values of env, such as deploy credentials, are never logged
+			b.logger.WithFields(map[string]interface{}{"tool": BashToolName}).Info("Received input", "input", maskArguments(params.Arguments, "env"))
 
 			if err := json.Unmarshal(params.Arguments, &input); err != nil {
 				b.logger.WithFields(map[string]interface{}{"tool": BashToolName}).Error("Failed to parse input", "error", err)
 				return goai.CallToolResult{}, fmt.Errorf("failed to parse input: %w", err)
 			}
 
-			b.logger.Info("Executing bash command", "command", input.Command, "args", input.Args)
-			cmd := exec.Command("bash", append([]string{"-c", input.Command}, input.Args...)...)
-			output, err := b.cmdExecutor.ExecuteCommand(ctx, cmd)
+			dir, err := b.resolveWorkingDir(input.WorkingDir)
 			if err != nil {
-				b.logger.WithFields(map[string]interface{}{"tool": BashToolName}).Error("Failed to execute bash command", "error", err)
+				b.logger.WithFields(map[string]interface{}{"tool": BashToolName}).Error("Invalid working directory", "error", err)
 				return returnErrorOutput(err), nil
 			}
 
-			o := string(output)
-			b.logger.WithFields(map[string]interface{}{"tool": BashToolName, "output_length": len(o)}).Info("Bash command executed successfully")
-			return goai.CallToolResult{
-				Content: []goai.ToolResultContent{{Type: "text", Text: o}},
-				IsError: false,
-			}, nil
+			if input.OutputFormat != "" && input.OutputFormat != "text" && input.OutputFormat != "json" {
+				return returnErrorOutput(fmt.Errorf("unsupported output_format %q: must be text or json", input.OutputFormat)), nil
+			}
+			if input.TimeoutSeconds < 0 {
+				return returnErrorOutput(fmt.Errorf("timeout_seconds must not be negative")), nil
+			}
+			if input.TimeoutSeconds > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, time.Duration(input.TimeoutSeconds)*time.Second)
+				defer cancel()
+			}
+
+			env := b.commandEnv(input.Env)
+
+			commands := invokedCommands(input.Command)
+			if len(input.Pipeline) > 0 {
+				commands = pipelineCommands(input.Pipeline)
+			}
+			if err := b.checkCommandPolicy(commands); err != nil {
+				b.logger.WithFields(map[string]interface{}{"tool": BashToolName}).Error("Command rejected by policy", "error", err)
+				return returnErrorOutput(err), nil
+			}
+
+			if input.Retries < 0 || input.Retries > maxBashRetries {
+				return returnErrorOutput(fmt.Errorf("retries must be between 0 and %d", maxBashRetries)), nil
+			}
+			if input.RetryDelayMS < 0 {
+				return returnErrorOutput(fmt.Errorf("retry_delay_ms must not be negative")), nil
+			}
+			delay := defaultBashRetryDelay
+			if input.RetryDelayMS > 0 {
+				delay = time.Duration(input.RetryDelayMS) * time.Millisecond
+			}
+
+			if len(input.Pipeline) > 0 {
+				return b.withRetries(ctx, input.Retries, delay, func() (goai.CallToolResult, error) {
+					return b.executePipeline(ctx, input.Pipeline, commandStdin(input.Stdin, input.AutoAnswer), dir, env)
+				})
+			}
+			if input.Command == "" {
+				return returnErrorOutput(fmt.Errorf("command or pipeline is required")), nil
+			}
+
+			shell, err := b.resolveShell(input.Shell)
+			if err != nil {
+				b.logger.WithFields(map[string]interface{}{"tool": BashToolName}).Error("Invalid shell", "error", err)
+				return returnErrorOutput(err), nil
+			}
+
+			return b.withRetries(ctx, input.Retries, delay, func() (goai.CallToolResult, error) {
+				b.logger.Info("Executing bash command", "command", input.Command, "args", input.Args, "shell", shell)
+				cmd := exec.CommandContext(ctx, shell, append([]string{"-c", input.Command}, input.Args...)...)
+				cmd.WaitDelay = bashWaitDelay
+				cmd.Dir = dir
+				cmd.Env = env
+				if stdin := commandStdin(input.Stdin, input.AutoAnswer); stdin != nil {
+					cmd.Stdin = stdin
+				}
+				if input.OutputFormat == "json" {
+					return b.executeStructured(ctx, cmd)
+				}
+				return b.executeText(ctx, cmd)
+			})
 		},
 	}
 }
+
+// executeText runs cmd and reports its combined output as text
+func (b *Bash) executeText(ctx context.Context, cmd *exec.Cmd) (goai.CallToolResult, error) {
+	captured := &cappedBuffer{limit: b.maxOutputBytes()}
+	cmd.Stdout = captured
+	cmd.Stderr = captured
+
+	start := time.Now()
+	output, err := b.cmdExecutor.ExecuteCommand(ctx, cmd)
+	o := captured.String()
+	if output != nil {
+		o = truncateOutput(string(output), b.maxOutputBytes())
+	}
+	if timedOut(ctx) {
+		elapsed := time.Since(start).Round(time.Millisecond)
+		b.logger.WithFields(map[string]interface{}{"tool": BashToolName}).Error("Bash command timed out", "elapsed", elapsed)
+		return timeoutOutput(elapsed, o), nil
+	}
+	if err != nil {
+		b.logger.WithFields(map[string]interface{}{"tool": BashToolName}).Error("Failed to execute bash command", "error", err)
+		return failureOutput(err, o), nil
+	}
+
+	b.logger.WithFields(map[string]interface{}{"tool": BashToolName, "output_length": len(o)}).Info("Bash command executed successfully")
+	return goai.CallToolResult{
+		Content: []goai.ToolResultContent{{Type: "text", Text: o}},
+		IsError: false,
+	}, nil
+}
+
+// withRetries runs attempt again while it reports an error, up to retries more times, waiting
+// delay before the first retry and twice as long before each further one. It returns the
+// result of the last attempt; when retries were requested, a text block reporting the number
+// of attempts made is appended to it. A done ctx, e.g. once timeout_seconds, which bounds all
+// attempts together, expires, ends the retries immediately.
+func (b *Bash) withRetries(ctx context.Context, retries int, delay time.Duration, attempt func() (goai.CallToolResult, error)) (goai.CallToolResult, error) {
+	attempts := 1
+	result, err := attempt()
+	for err == nil && result.IsError && attempts <= retries && ctx.Err() == nil {
+		b.logger.WithFields(map[string]interface{}{"tool": BashToolName}).Warn("Retrying failed bash command", "attempt", attempts, "delay", delay)
+		if !sleepContext(ctx, delay) {
+			break
+		}
+		delay *= 2
+
+		attempts++
+		result, err = attempt()
+	}
+
+	if err == nil && retries > 0 {
+		result.Content = append(result.Content, goai.ToolResultContent{Type: "text", Text: fmt.Sprintf("attempts: %d", attempts)})
+	}
+	return result, err
+}
+
+// sleepContext waits for d and reports whether it did, returning false as soon as ctx is done
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// bashCommandResult is the result of a command run with the json output_format. Stdout and
+// Stderr are capped separately; the truncated flags report whether output was discarded.
+type bashCommandResult struct {
+	Stdout          string `json:"stdout"`
+	Stderr          string `json:"stderr"`
+	ExitCode        int    `json:"exit_code"`
+	DurationMS      int64  `json:"duration_ms"`
+	StdoutTruncated bool   `json:"stdout_truncated"`
+	StderrTruncated bool   `json:"stderr_truncated"`
+	TimedOut        bool   `json:"timed_out,omitempty"`
+	Error           string `json:"error,omitempty"`
+}
+
+// executeStructured runs cmd with stdout and stderr captured apart and reports them with the
+// exit code and duration. A command that exits nonzero, times out or cannot be started is
+// reported as an error result, with an exit code of -1 when it did not exit by itself.
+func (b *Bash) executeStructured(ctx context.Context, cmd *exec.Cmd) (goai.CallToolResult, error) {
+	stdout := &cappedBuffer{limit: b.maxOutputBytes()}
+	stderr := &cappedBuffer{limit: b.maxOutputBytes()}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	start := time.Now()
+	output, err := b.cmdExecutor.ExecuteCommand(ctx, cmd)
+	if output != nil {
+		_, _ = stdout.Write(output)
+	}
+
+	result := bashCommandResult{
+		Stdout:          stdout.buf.String(),
+		Stderr:          stderr.buf.String(),
+		DurationMS:      time.Since(start).Milliseconds(),
+		StdoutTruncated: stdout.truncated(),
+		StderrTruncated: stderr.truncated(),
+	}
+	var exitErr *exec.ExitError
+	switch {
+	case timedOut(ctx):
+		result.ExitCode = -1
+		result.TimedOut = true
+		b.logger.WithFields(map[string]interface{}{"tool": BashToolName}).Error("Bash command timed out", "elapsed", time.Since(start).Round(time.Millisecond))
+	case errors.As(err, &exitErr):
+		result.ExitCode = exitErr.ExitCode()
+		b.logger.WithFields(map[string]interface{}{"tool": BashToolName}).Error("Failed to execute bash command", "error", err)
+	case err != nil:
+		result.ExitCode = -1
+		result.Error = fmt.Sprintf("command could not be started: %v", err)
+		b.logger.WithFields(map[string]interface{}{"tool": BashToolName}).Error("Failed to execute bash command", "error", err)
+	}
+
+	o, mErr := marshalResult(result)
+	if mErr != nil {
+		return returnErrorOutput(mErr), nil
+	}
+	if err == nil && !result.TimedOut {
+		b.logger.WithFields(map[string]interface{}{"tool": BashToolName, "output_length": len(result.Stdout) + len(result.Stderr)}).Info("Bash command executed successfully")
+	}
+	return goai.CallToolResult{
+		Content: []goai.ToolResultContent{{Type: "json", Text: o}},
+		IsError: err != nil || result.TimedOut,
+	}, nil
+}
+
+// executePipeline runs a structured pipeline and reports the final output and every stage's exit code
+func (b *Bash) executePipeline(ctx context.Context, stages []pipelineStage, stdin io.Reader, dir string, env []string) (goai.CallToolResult, error) {
+	b.logger.Info("Executing pipeline", "stages", len(stages))
+
+	start := time.Now()
+	result, err := runPipeline(ctx, stages, stdin, b.maxOutputBytes(), func(cmd *exec.Cmd) {
+		cmd.Dir = dir
+		cmd.Env = env
+	})
+	if timedOut(ctx) {
+		elapsed := time.Since(start).Round(time.Millisecond)
+		b.logger.WithFields(map[string]interface{}{"tool": BashToolName}).Error("Pipeline timed out", "elapsed", elapsed)
+		output := ""
+		if result != nil {
+			output = result.Output
+		}
+		return timeoutOutput(elapsed, output), nil
+	}
+	if err != nil {
+		b.logger.WithFields(map[string]interface{}{"tool": BashToolName}).Error("Failed to execute pipeline", "error", err)
+		return returnErrorOutput(err), nil
+	}
+
+	o, err := marshalResult(result)
+	if err != nil {
+		return returnErrorOutput(err), nil
+	}
+	b.logger.WithFields(map[string]interface{}{"tool": BashToolName, "output_length": len(result.Output)}).Info("Pipeline executed")
+	return goai.CallToolResult{
+		Content: []goai.ToolResultContent{{Type: "json", Text: o}},
+		IsError: result.failed(),
+	}, nil
+}
+
+// maxOutputBytes returns the configured output cap, or 0 when output is not capped
+func (b *Bash) maxOutputBytes() int {
+	switch {
+	case b.config.MaxOutputBytes < 0:
+		return 0
+	case b.config.MaxOutputBytes == 0:
+		return DefaultBashMaxOutputBytes
+	default:
+		return b.config.MaxOutputBytes
+	}
+}
+
+// resolveShell returns the path of the shell a command runs in: the shell named by the call,
+// else the configured one, else DefaultBashShell. A shell named by the call must be the
+// configured one or one of AllowedShells. The shell must exist, so that a missing one is
+// reported as such rather than as a failure to start the command.
+func (b *Bash) resolveShell(shell string) (string, error) {
+	if shell != "" && shell != b.config.Shell && !b.shellAllowed(shell) {
+		return "", fmt.Errorf("shell %q is not allowed", shell)
+	}
+	if shell == "" {
+		shell = b.config.Shell
+	}
+	if shell == "" {
+		shell = DefaultBashShell
+	}
+
+	path, err := exec.LookPath(shell)
+	if err != nil {
+		return "", fmt.Errorf("shell %s is not available: %w", shell, err)
+	}
+	return path, nil
+}
+
+// shellAllowed reports whether a call may run its command in shell
+func (b *Bash) shellAllowed(shell string) bool {
+	allowed := b.config.AllowedShells
+	if allowed == nil {
+		allowed = DefaultBashAllowedShells
+	}
+	for _, s := range allowed {
+		if s == shell {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveWorkingDir returns the directory a command runs in, or "" for the server's own
+// working directory. The directory must exist and, when WorkingDirRoot is configured,
+// must not resolve outside of it, following symlinks.
+func (b *Bash) resolveWorkingDir(workingDir string) (string, error) {
+	root := b.config.WorkingDirRoot
+	if workingDir == "" {
+		workingDir = root
+	}
+	if workingDir == "" {
+		return "", nil
+	}
+	if root != "" && !filepath.IsAbs(workingDir) {
+		workingDir = filepath.Join(root, workingDir)
+	}
+
+	info, err := os.Stat(workingDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("working_dir %s does not exist", workingDir)
+		}
+		return "", fmt.Errorf("invalid working_dir %s: %w", workingDir, err)
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("working_dir %s is not a directory", workingDir)
+	}
+
+	if root != "" {
+		resolvedRoot, err := filepath.EvalSymlinks(root)
+		if err != nil {
+			return "", fmt.Errorf("invalid working directory root %s: %w", root, err)
+		}
+		resolved, err := filepath.EvalSymlinks(workingDir)
+		if err != nil {
+			return "", fmt.Errorf("invalid working_dir %s: %w", workingDir, err)
+		}
+		if !isWithinRoot(resolvedRoot, resolved) {
+			return "", fmt.Errorf("working_dir %s is outside of the allowed root %s", workingDir, root)
+		}
+	}
+
+	return workingDir, nil
+}
+
+// commandEnv returns the environment of a command: the server's environment with BaseEnv
+// and then overrides applied on top. It returns nil, inheriting the environment unchanged,
+// when there is nothing to add.
+func (b *Bash) commandEnv(overrides map[string]string) []string {
+	if len(b.config.BaseEnv) == 0 && len(overrides) == 0 {
+		return nil
+	}
+
+	values := map[string]string{}
+	var keys []string
+	set := func(key, value string) {
+		if _, ok := values[key]; !ok {
+			keys = append(keys, key)
+		}
+		values[key] = value
+	}
+
+	for _, kv := range os.Environ() {
+		if key, value, ok := strings.Cut(kv, "="); ok {
+			set(key, value)
+		}
+	}
+	for _, vars := range []map[string]string{b.config.BaseEnv, overrides} {
+		names := make([]string, 0, len(vars))
+		for key := range vars {
+			names = append(names, key)
+		}
+		sort.Strings(names)
+		for _, key := range names {
+			set(key, vars[key])
+		}
+	}
+
+	env := make([]string, len(keys))
+	for i, key := range keys {
+		env[i] = key + "=" + values[key]
+	}
+	return env
+}
+
+// timedOut reports whether ctx ended because its deadline passed
+func timedOut(ctx context.Context) bool {
+	return errors.Is(ctx.Err(), context.DeadlineExceeded)
+}
+
+// timeoutOutput returns an error result reporting how long a timed out command ran,
+// followed by the output it produced before it was killed
+func timeoutOutput(elapsed time.Duration, output string) goai.CallToolResult {
+	return goai.CallToolResult{
+		Content: []goai.ToolResultContent{{Type: "text", Text: fmt.Sprintf("command timed out after %s\n%s", elapsed, output)}},
+		IsError: true,
+	}
+}
+
+// failureOutput returns an error result describing why a command failed, followed by the output
+// it produced. A command that ran and exited nonzero is reported with its exit code, which
+// bash sets to 127 when the binary is not found and 126 when it cannot be executed; a command
+// that could not be started at all is reported with the start error.
+func failureOutput(err error, output string) goai.CallToolResult {
+	var summary string
+	var exitErr *exec.ExitError
+	switch {
+	case !errors.As(err, &exitErr):
+		summary = fmt.Sprintf("command could not be started: %v", err)
+	case exitErr.ExitCode() == -1:
+		summary = fmt.Sprintf("command was terminated: %v", err)
+	case exitErr.ExitCode() == 127:
+		summary = "command not found (exit code 127)"
+	case exitErr.ExitCode() == 126:
+		summary = "command could not be executed (exit code 126)"
+	default:
+		summary = fmt.Sprintf("command exited with code %d", exitErr.ExitCode())
+	}
+
+	return goai.CallToolResult{
+		Content: []goai.ToolResultContent{{Type: "text", Text: summary + "\n" + output}},
+		IsError: true,
+	}
+}
+
+// commandStdin returns the stdin of a command: the stdin data followed by the auto answer
+// repeated endlessly, or nil, leaving stdin unconnected, when both are empty. exec copies a
+// non-file reader to the process from its own goroutine, so large inputs cannot deadlock
+// against the output being read.
+func commandStdin(stdin, autoAnswer string) io.Reader {
+	var readers []io.Reader
+	if stdin != "" {
+		readers = append(readers, strings.NewReader(stdin))
+	}
+	if autoAnswer != "" {
+		readers = append(readers, newRepeatReader(autoAnswer+"\n"))
+	}
+
+	switch len(readers) {
+	case 0:
+		return nil
+	case 1:
+		return readers[0]
+	default:
+		return io.MultiReader(readers...)
+	}
+}
+
+// repeatReader endlessly repeats a line, like the output of the yes command
+type repeatReader struct {
+	line   []byte
+	offset int
+}
+
+// newRepeatReader returns a reader that yields line over and over
+func newRepeatReader(line string) *repeatReader {
+	return &repeatReader{line: []byte(line)}
+}
+
+func (r *repeatReader) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		copied := copy(p[n:], r.line[r.offset:])
+		n += copied
+		r.offset = (r.offset + copied) % len(r.line)
+	}
+	return n, nil
+}
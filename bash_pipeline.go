@@ -0,0 +1,122 @@
+package mcptools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+)
+
+// pipelineStage is a single program of a structured pipeline
+type pipelineStage struct {
+	Program string   `json:"program"`
+	Args    []string `json:"args"`
+}
+
+// pipelineStageResult reports how a pipeline stage exited
+type pipelineStageResult struct {
+	Program  string `json:"program"`
+	ExitCode int    `json:"exit_code"`
+	Stderr   string `json:"stderr,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// pipelineResult is the output of the final stage and the result of every stage
+type pipelineResult struct {
+	Output string                `json:"output"`
+	Stages []pipelineStageResult `json:"stages"`
+}
+
+// failed reports whether any stage of the pipeline exited unsuccessfully
+func (r *pipelineResult) failed() bool {
+	for _, stage := range r.Stages {
+		if stage.ExitCode != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// runPipeline runs the stages without a shell, connecting the stdout of each stage to the
+// stdin of the next one with OS pipes. stdin, when not nil, feeds the first stage. The output
+// of the last stage and the stderr of every stage are capped at maxOutputBytes while they are
+// read, as by cappedBuffer; zero means unlimited. prepare,
+// when not nil, is called with every stage's command before it is started. When a stage cannot
+// be started, the stages already running are killed and the error is returned.
+func runPipeline(ctx context.Context, stages []pipelineStage, stdin io.Reader, maxOutputBytes int, prepare func(cmd *exec.Cmd)) (*pipelineResult, error) {
+	if len(stages) == 0 {
+		return nil, fmt.Errorf("pipeline must have at least one stage")
+	}
+
+	cmds := make([]*exec.Cmd, len(stages))
+	stderrs := make([]cappedBuffer, len(stages))
+	stdout := &cappedBuffer{limit: maxOutputBytes}
+
+	for i, stage := range stages {
+		if stage.Program == "" {
+			return nil, fmt.Errorf("pipeline stage %d has no program", i)
+		}
+
+		cmd := exec.CommandContext(ctx, stage.Program, stage.Args...)
+		if prepare != nil {
+			prepare(cmd)
+		}
+		stderrs[i].limit = maxOutputBytes
+		cmd.Stderr = &stderrs[i]
+		if i == 0 {
+			cmd.Stdin = stdin
+		} else {
+			pipe, err := cmds[i-1].StdoutPipe()
+			if err != nil {
+				return nil, fmt.Errorf("failed to connect pipeline stage %d: %w", i, err)
+			}
+			cmd.Stdin = pipe
+		}
+		cmds[i] = cmd
+	}
+	cmds[len(cmds)-1].Stdout = stdout
+
+	for i, cmd := range cmds {
+		if err := cmd.Start(); err != nil {
+			abortPipeline(cmds, i)
+			return nil, fmt.Errorf("failed to start pipeline stage %d (%s): %w", i, stages[i].Program, err)
+		}
+	}
+
+	result := &pipelineResult{Stages: make([]pipelineStageResult, len(stages))}
+	for i, cmd := range cmds {
+		result.Stages[i].Program = stages[i].Program
+		if err := cmd.Wait(); err != nil {
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) {
+				result.Stages[i].ExitCode = exitErr.ExitCode()
+			} else {
+				result.Stages[i].ExitCode = -1
+			}
+			result.Stages[i].Error = err.Error()
+		}
+		result.Stages[i].Stderr = stderrs[i].String()
+	}
+
+	result.Output = stdout.String()
+	return result, nil
+}
+
+// abortPipeline stops the stages before cmds[failed], which could not be started. The pipe
+// ends of the failed stage are closed and the started stages killed and waited for, since a
+// stage writing into a pipe that is never read, like yes, would otherwise never exit.
+func abortPipeline(cmds []*exec.Cmd, failed int) {
+	if failed > 0 {
+		if reader, ok := cmds[failed].Stdin.(io.Closer); ok {
+			_ = reader.Close()
+		}
+	}
+	if writer, ok := cmds[failed].Stdout.(io.Closer); ok {
+		_ = writer.Close()
+	}
+	for _, cmd := range cmds[:failed] {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	}
+}
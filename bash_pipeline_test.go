@@ -0,0 +1,84 @@
+package mcptools
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunPipeline_Stdin(t *testing.T) {
+	result, err := runPipeline(context.Background(), []pipelineStage{
+		{Program: "tr", Args: []string{"a-z", "A-Z"}},
+		{Program: "wc", Args: []string{"-c"}},
+	}, strings.NewReader("hello"), 0, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "5", strings.TrimSpace(result.Output))
+	assert.False(t, result.failed())
+}
+
+func TestRunPipeline_InvalidStages(t *testing.T) {
+	_, err := runPipeline(context.Background(), nil, nil, 0, nil)
+	assert.Error(t, err)
+
+	_, err = runPipeline(context.Background(), []pipelineStage{{Program: "echo"}, {}}, nil, 0, nil)
+	assert.EqualError(t, err, "pipeline stage 1 has no program")
+}
+
+func TestRunPipeline_StartFailure(t *testing.T) {
+	tests := []struct {
+		name     string
+		stages   []pipelineStage
+		expected string
+	}{
+		{
+			name:     "missing program",
+			stages:   []pipelineStage{{Program: "definitely-not-a-real-program"}},
+			expected: "failed to start pipeline stage 0 (definitely-not-a-real-program)",
+		},
+		{
+			name:     "missing program after a running stage",
+			stages:   []pipelineStage{{Program: "yes"}, {Program: "definitely-not-a-real-program"}},
+			expected: "failed to start pipeline stage 1 (definitely-not-a-real-program)",
+		},
+		{
+			name:     "missing program in the middle",
+			stages:   []pipelineStage{{Program: "yes"}, {Program: "definitely-not-a-real-program"}, {Program: "cat"}},
+			expected: "failed to start pipeline stage 1 (definitely-not-a-real-program)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			done := make(chan error, 1)
+			go func() {
+				_, err := runPipeline(context.Background(), tt.stages, nil, 0, nil)
+				done <- err
+			}()
+
+			select {
+			case err := <-done:
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expected)
+			case <-time.After(10 * time.Second):
+				t.Fatal("pipeline did not return after a stage failed to start")
+			}
+		})
+	}
+}
+
+func TestRunPipeline_MaxOutputBytes(t *testing.T) {
+	result, err := runPipeline(context.Background(), []pipelineStage{
+		{Program: "sh", Args: []string{"-c", "head -c 100000 /dev/zero | tr '\\0' a; head -c 100000 /dev/zero | tr '\\0' e >&2"}},
+		{Program: "sh", Args: []string{"-c", "cat; head -c 100000 /dev/zero | tr '\\0' f >&2"}},
+	}, nil, 10, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "aaaaaaaaaa\n... output truncated (100000 bytes total)", result.Output)
+	assert.Equal(t, "eeeeeeeeee\n... output truncated (100000 bytes total)", result.Stages[0].Stderr)
+	assert.Equal(t, "ffffffffff\n... output truncated (100000 bytes total)", result.Stages[1].Stderr)
+}
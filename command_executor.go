@@ -13,6 +13,16 @@ type CommandExecutor interface {
 // RealCommandExecutor implements CommandExecutor for real command execution
 type RealCommandExecutor struct{}
 
-func (e *RealCommandExecutor) ExecuteCommand(_ context.Context, cmd *exec.Cmd) ([]byte, error) {
+// ExecuteCommand runs cmd and returns its combined output. Callers build cmd with
+// exec.CommandContext using the same ctx, so cancelling ctx kills the process; a command
+// whose context is already done is not started. When the caller has set cmd.Stdout the
+// output is streamed there instead and nil is returned.
+func (e *RealCommandExecutor) ExecuteCommand(ctx context.Context, cmd *exec.Cmd) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if cmd.Stdout != nil {
+		return nil, cmd.Run()
+	}
 	return cmd.CombinedOutput()
 }
@@ -1,7 +1,18 @@
 package mcptools
 
-import "github.com/shaharia-lab/goai"
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
 
+	"github.com/shaharia-lab/goai"
+)
+
+// returnErrorOutput reports err as a failed tool call: the error text as content with IsError set,
+// so MCP clients see a tool error rather than successful output. Handlers return it with a nil
+// error; a non-nil handler error is reserved for requests that cannot be processed at all.
 func returnErrorOutput(err error) goai.CallToolResult {
 	return goai.CallToolResult{
 		Content: []goai.ToolResultContent{
@@ -13,3 +24,68 @@ func returnErrorOutput(err error) goai.CallToolResult {
 		IsError: true,
 	}
 }
+
+// marshalResult marshals v into the JSON text of a tool result. Values that cannot be marshaled,
+// such as a NaN or a channel, produce an error for the caller to report with returnErrorOutput
+// instead of failing the server.
+func marshalResult(v interface{}) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize result: %w", err)
+	}
+	return string(b), nil
+}
+
+// truncateOutput caps output at maxBytes and appends a marker noting the original size.
+// A non-positive maxBytes leaves the output untouched.
+func truncateOutput(output string, maxBytes int) string {
+	if maxBytes <= 0 || len(output) <= maxBytes {
+		return output
+	}
+	return fmt.Sprintf("%s\n... output truncated (%d bytes total)", output[:maxBytes], len(output))
+}
+
+// cappedBuffer is an io.Writer that keeps the first limit bytes written to it and only counts
+// the rest, so arbitrarily large output can be consumed in bounded memory. A non-positive
+// limit keeps everything.
+type cappedBuffer struct {
+	limit int
+	buf   bytes.Buffer
+	total int
+}
+
+func (c *cappedBuffer) Write(p []byte) (int, error) {
+	c.total += len(p)
+	keep := p
+	if c.limit > 0 {
+		room := c.limit - c.buf.Len()
+		if room < 0 {
+			room = 0
+		}
+		if len(keep) > room {
+			keep = keep[:room]
+		}
+	}
+	c.buf.Write(keep)
+	return len(p), nil
+}
+
+// truncated reports whether output beyond the limit was discarded
+func (c *cappedBuffer) truncated() bool {
+	return c.total > c.buf.Len()
+}
+
+// String returns the kept output, followed by the truncateOutput marker when output was discarded
+func (c *cappedBuffer) String() string {
+	if !c.truncated() {
+		return c.buf.String()
+	}
+	return fmt.Sprintf("%s\n... output truncated (%d bytes total)", c.buf.String(), c.total)
+}
+
+// isWithinRoot reports whether path is root or lies below it. Both paths must be absolute
+// and have their symlinks resolved, so that neither "../" segments nor symlinks escape root.
+func isWithinRoot(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
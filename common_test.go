@@ -0,0 +1,57 @@
+package mcptools
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newPermissiveLogger returns a MockLogger that accepts any logging call.
+func newPermissiveLogger() *MockLogger {
+	logger := new(MockLogger)
+	logger.On("WithFields", mock.Anything).Return(logger).Maybe()
+	logger.On("Debug", mock.Anything).Return().Maybe()
+	logger.On("Info", mock.Anything).Return().Maybe()
+	logger.On("Warn", mock.Anything).Return().Maybe()
+	logger.On("Error", mock.Anything).Return().Maybe()
+	return logger
+}
+
+func TestCappedBuffer(t *testing.T) {
+	buf := &cappedBuffer{limit: 5}
+	for _, chunk := range []string{"abc", "def", "ghi"} {
+		n, err := buf.Write([]byte(chunk))
+		require.NoError(t, err)
+		assert.Equal(t, len(chunk), n)
+	}
+	assert.Equal(t, "abcde\n... output truncated (9 bytes total)", buf.String())
+
+	unlimited := &cappedBuffer{}
+	_, _ = unlimited.Write([]byte("abcdef"))
+	assert.Equal(t, "abcdef", unlimited.String())
+}
+
+func TestMarshalResult(t *testing.T) {
+	text, err := marshalResult(map[string]interface{}{"name": "mcp", "count": 2})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name": "mcp", "count": 2}`, text)
+
+	_, err = marshalResult(map[string]interface{}{"updates": make(chan int)})
+	assert.EqualError(t, err, "failed to serialize result: json: unsupported type: chan int")
+
+	_, err = marshalResult(math.Inf(1))
+	assert.EqualError(t, err, "failed to serialize result: json: unsupported value: +Inf")
+}
+
+func TestReturnErrorOutput(t *testing.T) {
+	result := returnErrorOutput(errors.New("something broke"))
+
+	assert.True(t, result.IsError)
+	require.Len(t, result.Content, 1)
+	assert.Equal(t, "text", result.Content[0].Type)
+	assert.Equal(t, "something broke", result.Content[0].Text)
+}
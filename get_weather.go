@@ -9,53 +9,161 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 )
 
+// WeatherToolName is the name of the current weather tool
+const WeatherToolName = "get_weather"
+
+// Units is the unit system temperatures are reported in. The values match the units
+// parameter of the OpenWeatherMap API.
+type Units string
+
+const (
+	// UnitsMetric reports temperatures in degrees Celsius
+	UnitsMetric Units = "metric"
+	// UnitsImperial reports temperatures in degrees Fahrenheit
+	UnitsImperial Units = "imperial"
+	// UnitsStandard reports temperatures in Kelvin
+	UnitsStandard Units = "standard"
+)
+
+// parseUnits validates a units string, defaulting to UnitsImperial when it is empty
+func parseUnits(s string) (Units, error) {
+	switch units := Units(s); units {
+	case "":
+		return UnitsImperial, nil
+	case UnitsMetric, UnitsImperial, UnitsStandard:
+		return units, nil
+	default:
+		return "", fmt.Errorf("unsupported units %q: must be one of metric, imperial or standard", s)
+	}
+}
+
+// temperatureSymbol returns the symbol appended to temperatures in these units
+func (u Units) temperatureSymbol() string {
+	switch u {
+	case UnitsMetric:
+		return "°C"
+	case UnitsStandard:
+		return " K"
+	default:
+		return "°F"
+	}
+}
+
+// fromCelsius converts a temperature in degrees Celsius to these units
+func (u Units) fromCelsius(c float64) float64 {
+	switch u {
+	case UnitsImperial:
+		return c*9/5 + 32
+	case UnitsStandard:
+		return c + 273.15
+	default:
+		return c
+	}
+}
+
+// Weather is the current weather of a location
+type Weather struct {
+	Location string
+	// Temperature is in the units the weather was requested in
+	Temperature float64
+	Condition   string
+}
+
+// WeatherProvider looks up the current weather of a location
+type WeatherProvider interface {
+	Current(ctx context.Context, location string, units Units) (Weather, error)
+}
+
+// StaticWeatherProvider reports the same canned weather for every location. It needs no
+// network access, which makes it useful for tests and offline use.
+type StaticWeatherProvider struct{}
+
+// Current returns sunny weather at 72°F, converted to units, for any location
+func (StaticWeatherProvider) Current(ctx context.Context, location string, units Units) (Weather, error) {
+	return Weather{Location: location, Temperature: units.fromCelsius(fahrenheitToCelsius(72)), Condition: "Sunny"}, nil
+}
+
+// NewWeatherProvider returns an OpenWeatherMap provider using apiKey, or a StaticWeatherProvider
+// when no key is configured
+func NewWeatherProvider(apiKey string) WeatherProvider {
+	if apiKey == "" {
+		return StaticWeatherProvider{}
+	}
+	return NewOpenWeatherMapProvider(apiKey)
+}
+
 // GetWeather is a tool that provides the current weather for a specified location.
 // The tool expects an input schema that includes a "location" field, which
 // specifies the city and state (e.g., "San Francisco, CA"). It returns the
-// weather information as text content.
-var GetWeather = goai.Tool{
-	Name:        "get_weather",
-	Description: "Get the current weather for a given location.",
-	InputSchema: json.RawMessage(`{
+// weather information as text content. It is backed by a StaticWeatherProvider;
+// use GetWeatherTool to report real weather.
+var GetWeather = GetWeatherTool(StaticWeatherProvider{})
+
+// GetWeatherTool returns a tool that reports the current weather of a location as looked up by provider
+func GetWeatherTool(provider WeatherProvider) goai.Tool {
+	return goai.Tool{
+		Name:        WeatherToolName,
+		Description: "Get the current weather for a given location.",
+		InputSchema: json.RawMessage(`{
 				"type": "object",
 				"properties": {
 					"location": {
 						"type": "string",
 						"description": "The city and state, e.g. San Francisco, CA"
+					},
+					"units": {
+						"type": "string",
+						"enum": ["metric", "imperial", "standard"],
+						"description": "Temperature units: metric (Celsius), imperial (Fahrenheit) or standard (Kelvin). Defaults to imperial"
 					}
 				},
 				"required": ["location"]
 			}`),
-	Handler: func(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
-		_, span := goai.StartSpan(ctx, fmt.Sprintf("%s.Handler", params.Name))
-		span.SetAttributes(
-			attribute.String("tool_name", params.Name),
-			attribute.String("tool_argument", string(params.Arguments)),
-		)
-		defer span.End()
-
-		var err error
-		defer func() {
+		Handler: func(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
+			ctx, span := goai.StartSpan(ctx, fmt.Sprintf("%s.Handler", params.Name))
+			span.SetAttributes(
+				attribute.String("tool_name", params.Name),
+				attribute.String("tool_argument", string(params.Arguments)),
+			)
+			defer span.End()
+
+			var input struct {
+				Location string `json:"location"`
+				Units    string `json:"units"`
+			}
+			if err := json.Unmarshal(params.Arguments, &input); err != nil {
+				span.RecordError(err)
+				return goai.CallToolResult{}, err
+			}
+
+			if input.Location == "" {
+				return returnErrorOutput(fmt.Errorf("location is required")), nil
+			}
+
+			units, err := parseUnits(input.Units)
+			if err != nil {
+				return returnErrorOutput(err), nil
+			}
+
+			weather, err := provider.Current(ctx, input.Location, units)
 			if err != nil {
 				span.RecordError(err)
+				return returnErrorOutput(fmt.Errorf("failed to get weather: %w", err)), nil
 			}
-		}()
-
-		var input struct {
-			Location string `json:"location"`
-		}
-		if err := json.Unmarshal(params.Arguments, &input); err != nil {
-			return goai.CallToolResult{}, err
-		}
-
-		// Return result
-		return goai.CallToolResult{
-			Content: []goai.ToolResultContent{
-				{
-					Type: "text",
-					Text: fmt.Sprintf("Weather in %s: Sunny, 72°F", input.Location),
+
+			return goai.CallToolResult{
+				Content: []goai.ToolResultContent{
+					{
+						Type: "text",
+						Text: fmt.Sprintf("Weather in %s: %s, %.0f%s", weather.Location, weather.Condition, weather.Temperature, units.temperatureSymbol()),
+					},
 				},
-			},
-		}, nil
-	},
+			}, nil
+		},
+	}
+}
+
+// fahrenheitToCelsius converts a temperature from degrees Fahrenheit to degrees Celsius
+func fahrenheitToCelsius(f float64) float64 {
+	return (f - 32) * 5 / 9
 }
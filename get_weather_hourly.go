@@ -0,0 +1,167 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/shaharia-lab/goai"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// HourlyForecastToolName is the name of the hourly forecast tool
+const HourlyForecastToolName = "get_hourly_forecast"
+
+// defaultForecastHours is the number of hours forecast when the caller does not ask for a number
+const defaultForecastHours = 12
+
+// HourlyConditions are the forecast conditions for a single hour
+type HourlyConditions struct {
+	Time time.Time `json:"time"`
+	// Temperature is in degrees Celsius
+	Temperature float64 `json:"temperature"`
+	// PrecipitationProbability is a percentage from 0 to 100
+	PrecipitationProbability int    `json:"precipitation_probability"`
+	Condition                string `json:"condition"`
+}
+
+// HourlyForecast is the hourly forecast of a location
+type HourlyForecast struct {
+	Location string
+	// Timezone is the IANA time zone of the location, e.g. "Europe/Berlin"
+	Timezone string
+	Hours    []HourlyConditions
+}
+
+// HourlyForecastProvider is implemented by weather providers that support hourly forecasts
+type HourlyForecastProvider interface {
+	// MaxForecastHours returns how many hours ahead the provider can forecast
+	MaxForecastHours() int
+	// HourlyForecast returns the conditions for the next hours of a location, starting with the current hour
+	HourlyForecast(ctx context.Context, location string, hours int) (*HourlyForecast, error)
+}
+
+// hourlyForecastResult is the result returned by the hourly forecast tool
+type hourlyForecastResult struct {
+	Location string             `json:"location"`
+	Timezone string             `json:"timezone"`
+	Hours    int                `json:"hours"`
+	Note     string             `json:"note,omitempty"`
+	Forecast []HourlyConditions `json:"forecast"`
+}
+
+// GetHourlyForecastTool returns a tool that reports the hourly weather trend of a location for
+// the next hours, with times in the location's local time zone.
+func GetHourlyForecastTool(provider HourlyForecastProvider) goai.Tool {
+	return goai.Tool{
+		Name:        HourlyForecastToolName,
+		Description: "Get the hourly weather forecast (temperature, precipitation probability, condition) for the next hours of a location.",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"location": {
+					"type": "string",
+					"description": "The city and state, e.g. San Francisco, CA"
+				},
+				"hours": {
+					"type": "integer",
+					"description": "Number of hours to forecast (default 12, limited by the provider)"
+				}
+			},
+			"required": ["location"]
+		}`),
+		Handler: func(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
+			ctx, span := goai.StartSpan(ctx, fmt.Sprintf("%s.Handler", params.Name))
+			span.SetAttributes(
+				attribute.String("tool_name", params.Name),
+				attribute.String("tool_argument", string(params.Arguments)),
+			)
+			defer span.End()
+
+			var input struct {
+				Location string `json:"location"`
+				Hours    int    `json:"hours"`
+			}
+			if err := json.Unmarshal(params.Arguments, &input); err != nil {
+				span.RecordError(err)
+				return goai.CallToolResult{}, err
+			}
+
+			if input.Location == "" {
+				return returnErrorOutput(fmt.Errorf("location is required")), nil
+			}
+
+			hours, note := clampForecastHours(input.Hours, provider.MaxForecastHours())
+
+			forecast, err := provider.HourlyForecast(ctx, input.Location, hours)
+			if err != nil {
+				span.RecordError(err)
+				return returnErrorOutput(fmt.Errorf("failed to get hourly forecast: %w", err)), nil
+			}
+
+			result, err := newHourlyForecastResult(forecast, hours, note)
+			if err != nil {
+				span.RecordError(err)
+				return returnErrorOutput(err), nil
+			}
+
+			text, err := marshalResult(result)
+			if err != nil {
+				return returnErrorOutput(err), nil
+			}
+
+			return goai.CallToolResult{
+				Content: []goai.ToolResultContent{{
+					Type: "json",
+					Text: text,
+				}},
+			}, nil
+		},
+	}
+}
+
+// clampForecastHours validates the requested number of hours against the provider limit,
+// returning the hours to forecast and a note when the request had to be adjusted
+func clampForecastHours(requested, limit int) (int, string) {
+	hours := requested
+	if hours <= 0 {
+		hours = defaultForecastHours
+	}
+	if limit > 0 && hours > limit {
+		if requested > 0 {
+			return limit, fmt.Sprintf("requested %d hours but the provider forecasts at most %d; returning %d", requested, limit, limit)
+		}
+		return limit, ""
+	}
+	return hours, ""
+}
+
+// newHourlyForecastResult converts the forecast times to the location's time zone
+func newHourlyForecastResult(forecast *HourlyForecast, hours int, note string) (*hourlyForecastResult, error) {
+	loc := time.UTC
+	if forecast.Timezone != "" {
+		var err error
+		if loc, err = time.LoadLocation(forecast.Timezone); err != nil {
+			return nil, fmt.Errorf("invalid time zone %q for %s: %w", forecast.Timezone, forecast.Location, err)
+		}
+	}
+
+	entries := forecast.Hours
+	if len(entries) > hours {
+		entries = entries[:hours]
+	}
+
+	result := &hourlyForecastResult{
+		Location: forecast.Location,
+		Timezone: loc.String(),
+		Hours:    len(entries),
+		Note:     note,
+		Forecast: make([]HourlyConditions, 0, len(entries)),
+	}
+	for _, entry := range entries {
+		entry.Time = entry.Time.In(loc)
+		result.Forecast = append(result.Forecast, entry)
+	}
+	return result, nil
+}
@@ -0,0 +1,153 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/shaharia-lab/goai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockHourlyForecastProvider implements HourlyForecastProvider for testing
+type MockHourlyForecastProvider struct {
+	mock.Mock
+}
+
+func (m *MockHourlyForecastProvider) MaxForecastHours() int {
+	return m.Called().Int(0)
+}
+
+func (m *MockHourlyForecastProvider) HourlyForecast(ctx context.Context, location string, hours int) (*HourlyForecast, error) {
+	args := m.Called(ctx, location, hours)
+	forecast, _ := args.Get(0).(*HourlyForecast)
+	return forecast, args.Error(1)
+}
+
+// hourlyFixture returns n hourly entries starting at 2024-06-01 10:00 UTC
+func hourlyFixture(n int) []HourlyConditions {
+	start := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+	conditions := make([]HourlyConditions, n)
+	for i := range conditions {
+		conditions[i] = HourlyConditions{
+			Time:                     start.Add(time.Duration(i) * time.Hour),
+			Temperature:              20 + float64(i),
+			PrecipitationProbability: 10 * i,
+			Condition:                "Cloudy",
+		}
+	}
+	return conditions
+}
+
+func callHourlyForecastTool(t *testing.T, provider HourlyForecastProvider, input map[string]interface{}) goai.CallToolResult {
+	t.Helper()
+	args, err := json.Marshal(input)
+	require.NoError(t, err)
+
+	result, err := GetHourlyForecastTool(provider).Handler(context.Background(), goai.CallToolParams{Name: HourlyForecastToolName, Arguments: args})
+	require.NoError(t, err)
+	return result
+}
+
+func TestGetHourlyForecastTool(t *testing.T) {
+	provider := new(MockHourlyForecastProvider)
+	provider.On("MaxForecastHours").Return(48)
+	provider.On("HourlyForecast", mock.Anything, "Tokyo", 3).Return(&HourlyForecast{
+		Location: "Tokyo",
+		Timezone: "Asia/Tokyo",
+		Hours:    hourlyFixture(3),
+	}, nil)
+
+	result := callHourlyForecastTool(t, provider, map[string]interface{}{"location": "Tokyo", "hours": 3})
+	require.False(t, result.IsError, result.Content)
+
+	var forecast hourlyForecastResult
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &forecast))
+	assert.Equal(t, "Asia/Tokyo", forecast.Timezone)
+	assert.Equal(t, 3, forecast.Hours)
+	assert.Empty(t, forecast.Note)
+	require.Len(t, forecast.Forecast, 3)
+	assert.Equal(t, 21.0, forecast.Forecast[1].Temperature)
+	assert.Equal(t, 20, forecast.Forecast[2].PrecipitationProbability)
+	assert.Equal(t, "Cloudy", forecast.Forecast[0].Condition)
+
+	// 10:00 UTC is 19:00 in Tokyo
+	assert.Contains(t, result.Content[0].Text, `"time":"2024-06-01T19:00:00+09:00"`)
+	provider.AssertExpectations(t)
+}
+
+func TestGetHourlyForecastTool_UnserializableResult(t *testing.T) {
+	hours := hourlyFixture(2)
+	hours[1].Temperature = math.NaN()
+
+	provider := new(MockHourlyForecastProvider)
+	provider.On("MaxForecastHours").Return(48)
+	provider.On("HourlyForecast", mock.Anything, "Oslo", 2).Return(&HourlyForecast{
+		Location: "Oslo",
+		Timezone: "Europe/Oslo",
+		Hours:    hours,
+	}, nil)
+
+	result := callHourlyForecastTool(t, provider, map[string]interface{}{"location": "Oslo", "hours": 2})
+	assert.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].Text, "failed to serialize result: json: unsupported value: NaN")
+}
+
+func TestGetHourlyForecastTool_ClampsToProviderLimit(t *testing.T) {
+	provider := new(MockHourlyForecastProvider)
+	provider.On("MaxForecastHours").Return(24)
+	provider.On("HourlyForecast", mock.Anything, "Berlin", 24).Return(&HourlyForecast{
+		Location: "Berlin",
+		Timezone: "Europe/Berlin",
+		Hours:    hourlyFixture(24),
+	}, nil)
+
+	result := callHourlyForecastTool(t, provider, map[string]interface{}{"location": "Berlin", "hours": 100})
+	require.False(t, result.IsError, result.Content)
+
+	var forecast hourlyForecastResult
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &forecast))
+	assert.Equal(t, 24, forecast.Hours)
+	assert.Len(t, forecast.Forecast, 24)
+	assert.Equal(t, "requested 100 hours but the provider forecasts at most 24; returning 24", forecast.Note)
+	provider.AssertExpectations(t)
+}
+
+func TestClampForecastHours(t *testing.T) {
+	tests := []struct {
+		name      string
+		requested int
+		limit     int
+		expected  int
+		hasNote   bool
+	}{
+		{name: "default", requested: 0, limit: 48, expected: defaultForecastHours},
+		{name: "default above limit", requested: 0, limit: 6, expected: 6},
+		{name: "within limit", requested: 5, limit: 48, expected: 5},
+		{name: "above limit", requested: 72, limit: 48, expected: 48, hasNote: true},
+		{name: "no limit", requested: 72, limit: 0, expected: 72},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hours, note := clampForecastHours(tt.requested, tt.limit)
+			assert.Equal(t, tt.expected, hours)
+			assert.Equal(t, tt.hasNote, note != "")
+		})
+	}
+}
+
+func TestGetHourlyForecastTool_ProviderError(t *testing.T) {
+	provider := new(MockHourlyForecastProvider)
+	provider.On("MaxForecastHours").Return(48)
+	provider.On("HourlyForecast", mock.Anything, "Atlantis", defaultForecastHours).Return(nil, errors.New("unknown location"))
+
+	result := callHourlyForecastTool(t, provider, map[string]interface{}{"location": "Atlantis"})
+	assert.True(t, result.IsError)
+	assert.Equal(t, "failed to get hourly forecast: unknown location", result.Content[0].Text)
+}
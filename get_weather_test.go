@@ -3,11 +3,35 @@ package mcptools
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"testing"
 
 	"github.com/shaharia-lab/goai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
+// MockWeatherProvider implements WeatherProvider for testing
+type MockWeatherProvider struct {
+	mock.Mock
+}
+
+func (m *MockWeatherProvider) Current(ctx context.Context, location string, units Units) (Weather, error) {
+	args := m.Called(ctx, location, units)
+	return args.Get(0).(Weather), args.Error(1)
+}
+
+func callWeatherTool(t *testing.T, provider WeatherProvider, input map[string]interface{}) goai.CallToolResult {
+	t.Helper()
+	args, err := json.Marshal(input)
+	require.NoError(t, err)
+
+	result, err := GetWeatherTool(provider).Handler(context.Background(), goai.CallToolParams{Name: WeatherToolName, Arguments: args})
+	require.NoError(t, err)
+	return result
+}
+
 func TestGetWeather(t *testing.T) {
 	// Define the input parameters
 	input := map[string]interface{}{
@@ -36,3 +60,69 @@ func TestGetWeather(t *testing.T) {
 		t.Errorf("Unexpected result: got %v, want %v", result.Content, expectedText)
 	}
 }
+
+func TestGetWeatherTool(t *testing.T) {
+	provider := new(MockWeatherProvider)
+	provider.On("Current", mock.Anything, "Berlin", UnitsImperial).Return(Weather{Location: "Berlin", Temperature: 50, Condition: "Light rain"}, nil)
+
+	result := callWeatherTool(t, provider, map[string]interface{}{"location": "Berlin"})
+
+	assert.False(t, result.IsError)
+	assert.Equal(t, "Weather in Berlin: Light rain, 50°F", result.Content[0].Text)
+	provider.AssertExpectations(t)
+}
+
+func TestGetWeatherTool_ProviderError(t *testing.T) {
+	provider := new(MockWeatherProvider)
+	provider.On("Current", mock.Anything, "Atlantis", UnitsImperial).Return(Weather{}, errors.New("city not found"))
+
+	result := callWeatherTool(t, provider, map[string]interface{}{"location": "Atlantis"})
+
+	assert.True(t, result.IsError)
+	assert.Equal(t, "failed to get weather: city not found", result.Content[0].Text)
+}
+
+func TestGetWeatherTool_RequiresLocation(t *testing.T) {
+	result := callWeatherTool(t, StaticWeatherProvider{}, map[string]interface{}{})
+	assert.True(t, result.IsError)
+}
+
+func TestNewWeatherProvider(t *testing.T) {
+	assert.IsType(t, StaticWeatherProvider{}, NewWeatherProvider(""))
+	assert.IsType(t, &OpenWeatherMapProvider{}, NewWeatherProvider("key"))
+}
+
+func TestGetWeatherTool_Units(t *testing.T) {
+	tests := []struct {
+		units    string
+		expected string
+	}{
+		{units: "", expected: "Weather in Paris: Sunny, 72°F"},
+		{units: "imperial", expected: "Weather in Paris: Sunny, 72°F"},
+		{units: "metric", expected: "Weather in Paris: Sunny, 22°C"},
+		{units: "standard", expected: "Weather in Paris: Sunny, 295 K"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.units, func(t *testing.T) {
+			input := map[string]interface{}{"location": "Paris"}
+			if tt.units != "" {
+				input["units"] = tt.units
+			}
+
+			result := callWeatherTool(t, StaticWeatherProvider{}, input)
+			assert.False(t, result.IsError)
+			assert.Equal(t, tt.expected, result.Content[0].Text)
+		})
+	}
+}
+
+func TestGetWeatherTool_UnknownUnits(t *testing.T) {
+	provider := new(MockWeatherProvider)
+
+	result := callWeatherTool(t, provider, map[string]interface{}{"location": "Paris", "units": "kelvin"})
+
+	assert.True(t, result.IsError)
+	assert.Equal(t, `unsupported units "kelvin": must be one of metric, imperial or standard`, result.Content[0].Text)
+	provider.AssertNotCalled(t, "Current", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -3,20 +3,48 @@ package mcptools
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/shaharia-lab/goai"
 	"go.opentelemetry.io/otel/attribute"
 )
 
-const GitToolName = "git"
+const (
+	GitToolName            = "git"
+	GitFormatPatchToolName = "git_format_patch"
+	GitWorkspaceToolName   = "git_prepare_workspace"
+	GitChurnToolName       = "git_churn"
+	GitEOLToolName         = "git_eol"
+	GitCheckAttrToolName   = "git_check_attr"
+	GitStashToolName       = "git_stash"
+	GitChangelogToolName   = "git_changelog"
+	GitPickaxeToolName     = "git_pickaxe"
+	GitConflictsToolName   = "git_resolve_conflicts"
+	GitStatusToolName      = "git_status"
+	GitLogToolName         = "git_log"
+	GitCloneToolName       = "git_clone"
+	GitDiffToolName        = "git_diff"
+	GitCommitToolName      = "git_commit"
+	GitPushToolName        = "git_push"
+	GitPullToolName        = "git_pull"
+)
 
 // Git represents a wrapper around the system's git command-line tool,
 // providing a programmatic interface for executing git commands.
 type Git struct {
-	logger goai.Logger
-	config GitConfig
+	logger      goai.Logger
+	config      GitConfig
+	cmdExecutor CommandExecutor
 }
 
 // GitConfig holds the configuration for the Git tool
@@ -25,13 +53,69 @@ type GitConfig struct {
 	// For example, you might want to add:
 	DefaultRepoPath string
 	BlockedCommands []string
+	// MaxOutputBytes caps the size of output returned by the git tool and the operations that
+	// honor it; output beyond it is discarded and replaced by a marker noting the total size.
+	// Zero means unlimited.
+	MaxOutputBytes int
+	// AllowHardReset permits operations that run "git reset --hard".
+	AllowHardReset bool
+	// AllowClean permits operations that remove untracked files with "git clean".
+	AllowClean bool
+	// Timeout bounds each git invocation; the git process is killed when it expires.
+	// Zero means no timeout.
+	Timeout time.Duration
+	// RedactionRules scrub secrets from everything the tool logs. Nil means DefaultRedactionRules.
+	RedactionRules []RedactionRule
+	// AuthToken authenticates https clones of the clone tool when a call provides no
+	// auth_token. It is passed to git through a credential helper and never logged.
+	AuthToken string
+	// AuthTokenHosts are the hosts AuthToken is offered to, so that a clone from any other
+	// host never receives it. Nil means DefaultGitAuthTokenHost only.
+	AuthTokenHosts []string
+	// AuthorName and AuthorEmail are the identity of commits created by the git tool when a
+	// call provides none, instead of the identity configured on the host.
+	AuthorName  string
+	AuthorEmail string
+	// SignCommits signs the commits created by the git tool, with SigningKey when set and
+	// otherwise git's default key. SigningFormat selects openpgp (the default), ssh or x509.
+	// These settings reach git through its environment and are never logged.
+	SignCommits   bool
+	SigningKey    string
+	SigningFormat string
+	// AllowedRepoRoots, when set, restricts repo_path to these directories and their
+	// subdirectories, with symlinks resolved. Empty means any path is allowed.
+	AllowedRepoRoots []string
+	// AllowedCommands, when not empty, rejects git tool calls running a command that matches
+	// none of its entries, e.g. "status" or "log". Entries use the syntax of BlockedCommands
+	// and match the command alone. Leave out config, which can set the same programs as -c.
+	// BlockedCommands, AllowedCommands and BlockDangerousArgs apply to the commands every git
+	// tool runs, so AllowedCommands must also list the ones the enabled tools rely on, such as
+	// rev-parse for git_stash and git_clone.
+	AllowedCommands []string
+	// BlockDangerousArgs rejects git tool calls passing options that run arbitrary programs or
+	// reach outside of the repository, such as -c, --upload-pack or --exec; see
+	// dangerousGitOptions. It also limits git config to reads, since values like
+	// core.fsmonitor or alias.* make git run programs. AllowedDangerousArgs lists the options
+	// still permitted, e.g. "--exec", or "config" for configuration writes.
+	BlockDangerousArgs   bool
+	AllowedDangerousArgs []string
+	// MaxConcurrentRepos bounds how many repositories of a git tool call with repo_paths the
+	// command runs in at once. Zero means DefaultGitMaxConcurrentRepos.
+	MaxConcurrentRepos int
+	// BaseEnv is added to the inherited environment of every git invocation, on top of
+	// GIT_TERMINAL_PROMPT=0, which keeps git from waiting on credential prompts. Variables
+	// passed in a git tool call's env override it. With BlockDangerousArgs set, the env of a
+	// call may not set variables that run programs, such as GIT_SSH_COMMAND; see
+	// dangerousGitEnv.
+	BaseEnv map[string]string
 }
 
 // NewGit creates and returns a new instance of the Git wrapper with the provided configuration.
 func NewGit(logger goai.Logger, config GitConfig) *Git {
 	return &Git{
-		logger: logger,
-		config: config,
+		logger:      newRedactingLogger(logger, config.RedactionRules),
+		config:      config,
+		cmdExecutor: &RealCommandExecutor{},
 	}
 }
 
@@ -49,7 +133,14 @@ func (g *Git) GitAllInOneTool() goai.Tool {
 				},
 				"repo_path": {
 					"type": "string",
-					"description": "Path to Git repository"
+					"description": "Path to Git repository (defaults to the configured default repository path)"
+				},
+				"repo_paths": {
+					"type": "array",
+					"items": {
+						"type": "string"
+					},
+					"description": "Paths of several Git repositories to run the command in concurrently, instead of repo_path. The result maps each path to its output or error"
 				},
 				"args": {
 					"type": "array",
@@ -57,27 +148,50 @@ func (g *Git) GitAllInOneTool() goai.Tool {
 						"type": "string"
 					},
 					"description": "Arguments for the Git command"
+				},
+				"author_name": {
+					"type": "string",
+					"description": "Author and committer name of commits created by the command (defaults to the configured identity)"
+				},
+				"author_email": {
+					"type": "string",
+					"description": "Author and committer email of commits created by the command (defaults to the configured identity)"
+				},
+				"env": {
+					"type": "object",
+					"additionalProperties": {"type": "string"},
+					"description": "Environment variables set for the command, such as GIT_SSH_COMMAND or HTTPS_PROXY, overriding inherited ones"
+				},
+				"dry_run": {
+					"type": "boolean",
+					"description": "Return the git invocation that would run without running it"
 				}
 			},
-			"required": ["command", "repo_path"]
+			"required": ["command"]
 		}`),
 		Handler: func(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
 			ctx, span := goai.StartSpan(ctx, fmt.Sprintf("%s.Handler", params.Name))
 			span.SetAttributes(
 				attribute.String("tool_name", params.Name),
-				attribute.String("tool_argument", string(params.Arguments)),
+				attribute.String("tool_argument", maskArguments(params.Arguments, "env")),
 			)
 			defer span.End()
 
+			// The values of env, such as proxy credentials, are never logged
 			g.logger.WithFields(map[string]interface{}{
 				"tool_name": params.Name,
-				"arguments": string(params.Arguments),
+				"arguments": maskArguments(params.Arguments, "env"),
 			}).Info("Received input")
 
 			var input struct {
-				Command  string   `json:"command"`
-				RepoPath string   `json:"repo_path"`
-				Args     []string `json:"args"`
+				Command     string            `json:"command"`
+				RepoPath    string            `json:"repo_path"`
+				RepoPaths   []string          `json:"repo_paths"`
+				Args        []string          `json:"args"`
+				AuthorName  string            `json:"author_name"`
+				AuthorEmail string            `json:"author_email"`
+				Env         map[string]string `json:"env"`
+				DryRun      bool              `json:"dry_run"`
 			}
 
 			if err := json.Unmarshal(params.Arguments, &input); err != nil {
@@ -91,15 +205,42 @@ func (g *Git) GitAllInOneTool() goai.Tool {
 				return goai.CallToolResult{}, fmt.Errorf("failed to unmarshal input: %w", err)
 			}
 
-			args := append([]string{"-C", input.RepoPath, input.Command}, input.Args...)
+			if len(input.RepoPaths) > 0 && input.RepoPath != "" {
+				return returnErrorOutput(errors.New("repo_path and repo_paths cannot be combined")), nil
+			}
+			var err error
+			if len(input.RepoPaths) == 0 {
+				if input.RepoPath, err = g.resolveRepoPath(input.RepoPath); err != nil {
+					return returnErrorOutput(err), nil
+				}
+			}
+
+			// execGit enforces the command policies as well; checking them here rejects dry runs
+			// and every repository of repo_paths up front
+			if err := g.checkPolicy(input.Command, input.Args); err != nil {
+				return returnErrorOutput(err), nil
+			}
+			if err := g.checkEnvPolicy(input.Env); err != nil {
+				g.logger.WithFields(map[string]interface{}{
+					"tool":             GitToolName,
+					"command":          input.Command,
+					goai.ErrorLogField: err,
+				}).Warn("Rejected git command")
 
-			g.logger.WithFields(map[string]interface{}{
-				"command":   input.Command,
-				"repo_path": input.RepoPath,
-				"args":      args,
-			}).Debug("Executing git command")
+				return returnErrorOutput(err), nil
+			}
+
+			args := append([]string{input.Command}, input.Args...)
 
-			cmd := exec.CommandContext(ctx, "git", args...)
+			// The commit settings come last, so their GIT_CONFIG_* variables replace any in env
+			env := envList(input.Env)
+			if commitCommands[strings.ToLower(input.Command)] {
+				env = append(env, g.commitEnv(input.AuthorName, input.AuthorEmail)...)
+			}
+
+			if len(input.RepoPaths) > 0 {
+				return g.runAcrossRepos(ctx, input.RepoPaths, args, env, input.DryRun)
+			}
 
 			g.logger.WithFields(map[string]interface{}{
 				"command":   input.Command,
@@ -107,11 +248,34 @@ func (g *Git) GitAllInOneTool() goai.Tool {
 				"args":      args,
 			}).Debug("Executing git command")
 
-			output, err := cmd.CombinedOutput()
+			gitArgs := append([]string{"-C", input.RepoPath}, args...)
+			if input.DryRun {
+				preview := gitDryRun{DryRun: true, Args: append([]string{"git"}, gitArgs...)}
+				preview.Command = shellJoin(preview.Args)
+
+				g.logger.WithFields(map[string]interface{}{
+					"tool":    GitToolName,
+					"command": preview.Command,
+				}).Info("Git dry run")
+
+				text, err := marshalResult(preview)
+				if err != nil {
+					return returnErrorOutput(err), nil
+				}
+
+				return goai.CallToolResult{
+					Content: []goai.ToolResultContent{{
+						Type: "json",
+						Text: text,
+					}},
+				}, nil
+			}
+
+			output, err := g.runGitCapped(ctx, env, gitArgs...)
 			if err != nil {
 				g.logger.WithFields(map[string]interface{}{
 					goai.ErrorLogField: err,
-					"output":                    string(output),
+					"output":                    output,
 					"command":                   input.Command,
 				}).Error("Git command failed")
 
@@ -122,15 +286,293 @@ func (g *Git) GitAllInOneTool() goai.Tool {
 			g.logger.WithFields(map[string]interface{}{
 				"tool":    GitToolName,
 				"command": input.Command,
-				"output":  string(output),
+				"output":  output,
 			}).Debug("Git command completed successfully")
 
 			return goai.CallToolResult{
 				Content: []goai.ToolResultContent{{
 					Type: "text",
-					Text: string(output),
+					Text: output,
 				}},
 			}, nil
 		},
 	}
 }
+
+// gitDryRun is the invocation a git tool call would have run. The identity and signing settings
+// of commit commands, which reach git through its environment, are not part of it.
+type gitDryRun struct {
+	DryRun  bool     `json:"dry_run"`
+	Args    []string `json:"args"`
+	Command string   `json:"command"`
+}
+
+// shellJoin joins an argument vector into a command line, quoting the arguments a shell would
+// otherwise split or expand
+func shellJoin(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		if arg != "" && !strings.ContainsAny(arg, " \t\n\"'\\$`!*?[]{}()<>|&;#~") {
+			quoted[i] = arg
+			continue
+		}
+		quoted[i] = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+	}
+	return strings.Join(quoted, " ")
+}
+
+// resolveRepoPath returns repoPath, falling back to the configured DefaultRepoPath when it is
+// empty. When AllowedRepoRoots is configured the path must resolve to a directory within one
+// of the roots, and the resolved path is returned.
+func (g *Git) resolveRepoPath(repoPath string) (string, error) {
+	if repoPath == "" {
+		repoPath = g.config.DefaultRepoPath
+	}
+	if repoPath == "" {
+		return "", fmt.Errorf("repo_path is required: no repo_path was provided and no DefaultRepoPath is configured")
+	}
+	if len(g.config.AllowedRepoRoots) == 0 {
+		return repoPath, nil
+	}
+
+	absPath, err := filepath.Abs(repoPath)
+	if err != nil {
+		return "", fmt.Errorf("invalid repo_path %s: %w", repoPath, err)
+	}
+	resolved, err := filepath.EvalSymlinks(absPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("repo_path %s does not exist", repoPath)
+		}
+		return "", fmt.Errorf("invalid repo_path %s: %w", repoPath, err)
+	}
+
+	for _, root := range g.config.AllowedRepoRoots {
+		absRoot, err := filepath.Abs(root)
+		if err != nil {
+			continue
+		}
+		resolvedRoot, err := filepath.EvalSymlinks(absRoot)
+		if err != nil {
+			continue
+		}
+		if isWithinRoot(resolvedRoot, resolved) {
+			return resolved, nil
+		}
+	}
+	return "", fmt.Errorf("repo_path %s is outside of the allowed repository roots", repoPath)
+}
+
+// blockedBy reports the BlockedCommands entry matching a git invocation, if any. Entries are
+// matched case-insensitively against the command alone and against the command followed by
+// each of its arguments, so "push --force" blocks force pushes; "*" matches any characters.
+func (g *Git) blockedBy(command string, args []string) (string, bool) {
+	command = strings.ToLower(strings.TrimSpace(command))
+	candidates := []string{command}
+	for _, arg := range args {
+		candidates = append(candidates, command+" "+strings.ToLower(arg))
+	}
+
+	for _, pattern := range g.config.BlockedCommands {
+		re := blockedCommandPattern(pattern)
+		for _, candidate := range candidates {
+			if re.MatchString(candidate) {
+				return pattern, true
+			}
+		}
+	}
+	return "", false
+}
+
+// blockedCommandPattern compiles a BlockedCommands entry into an anchored, case-insensitive regexp
+func blockedCommandPattern(pattern string) *regexp.Regexp {
+	quoted := regexp.QuoteMeta(strings.ToLower(strings.TrimSpace(pattern)))
+	return regexp.MustCompile("^" + strings.ReplaceAll(quoted, `\*`, ".*") + "$")
+}
+
+// gitWaitDelay is how long a killed git process may keep its output pipes open, e.g. through
+// a remote helper child, before they are closed forcibly
+const gitWaitDelay = 5 * time.Second
+
+// commitCommands are the git commands that create commits and receive the commit identity and
+// signing configuration
+var commitCommands = map[string]bool{
+	"commit":      true,
+	"merge":       true,
+	"cherry-pick": true,
+	"revert":      true,
+}
+
+// commitEnv returns the environment applying the commit identity and signing configuration to
+// a git invocation. name and email override the configured identity. The settings are passed
+// as GIT_CONFIG_* variables rather than -c arguments, which keeps the signing key out of the
+// command line and the logged arguments.
+func (g *Git) commitEnv(name, email string) []string {
+	if name == "" {
+		name = g.config.AuthorName
+	}
+	if email == "" {
+		email = g.config.AuthorEmail
+	}
+
+	var settings [][2]string
+	if name != "" {
+		settings = append(settings, [2]string{"user.name", name})
+	}
+	if email != "" {
+		settings = append(settings, [2]string{"user.email", email})
+	}
+	if g.config.SignCommits {
+		settings = append(settings, [2]string{"commit.gpgsign", "true"})
+		if g.config.SigningKey != "" {
+			settings = append(settings, [2]string{"user.signingkey", g.config.SigningKey})
+		}
+		if g.config.SigningFormat != "" {
+			settings = append(settings, [2]string{"gpg.format", g.config.SigningFormat})
+		}
+	}
+	if len(settings) == 0 {
+		return nil
+	}
+
+	env := []string{"GIT_CONFIG_COUNT=" + strconv.Itoa(len(settings))}
+	for i, setting := range settings {
+		env = append(env,
+			fmt.Sprintf("GIT_CONFIG_KEY_%d=%s", i, setting[0]),
+			fmt.Sprintf("GIT_CONFIG_VALUE_%d=%s", i, setting[1]),
+		)
+	}
+	return env
+}
+
+// gitEnv returns the environment of a git invocation: the server's environment, then
+// GIT_TERMINAL_PROMPT=0, the configured BaseEnv and env. For a variable set more than once the
+// last value wins.
+func (g *Git) gitEnv(env []string) []string {
+	merged := append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	merged = append(merged, envList(g.config.BaseEnv)...)
+	return append(merged, env...)
+}
+
+// envList returns vars as KEY=value entries, sorted by key
+func envList(vars map[string]string) []string {
+	keys := envKeys(vars)
+	env := make([]string, len(keys))
+	for i, key := range keys {
+		env[i] = key + "=" + vars[key]
+	}
+	return env
+}
+
+// envKeys returns the names of env in sorted order
+func envKeys(env map[string]string) []string {
+	keys := make([]string, 0, len(env))
+	for key := range env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// runGit executes git with the given arguments against repoPath and returns the combined output.
+// When a Timeout is configured the process is killed once it expires.
+func (g *Git) runGit(ctx context.Context, repoPath string, args ...string) ([]byte, error) {
+	return g.runGitWithEnv(ctx, nil, append([]string{"-C", repoPath}, args...)...)
+}
+
+// runGitWithEnv executes git with the given arguments and the environment of gitEnv(env). It
+// honors the configured Timeout like runGit.
+func (g *Git) runGitWithEnv(ctx context.Context, env []string, args ...string) ([]byte, error) {
+	return g.execGit(ctx, env, nil, args...)
+}
+
+// runGitCapped is runGitWithEnv with the output capped at MaxOutputBytes. The output beyond
+// the cap is counted but never held in memory, and is replaced by the truncateOutput marker.
+func (g *Git) runGitCapped(ctx context.Context, env []string, args ...string) (string, error) {
+	if g.config.MaxOutputBytes <= 0 {
+		output, err := g.runGitWithEnv(ctx, env, args...)
+		return string(output), err
+	}
+
+	captured := &cappedBuffer{limit: g.config.MaxOutputBytes}
+	output, err := g.execGit(ctx, env, captured, args...)
+	if output != nil {
+		return truncateOutput(string(output), g.config.MaxOutputBytes), err
+	}
+	return captured.String(), err
+}
+
+// runGitBounded is runGit keeping only the first MaxOutputBytes of the output, without a
+// marker, for callers that parse it. It reports whether output beyond the cap was discarded.
+func (g *Git) runGitBounded(ctx context.Context, repoPath string, args ...string) ([]byte, bool, error) {
+	limit := g.config.MaxOutputBytes
+	if limit <= 0 {
+		output, err := g.runGit(ctx, repoPath, args...)
+		return output, false, err
+	}
+
+	captured := &cappedBuffer{limit: limit}
+	output, err := g.execGit(ctx, nil, captured, append([]string{"-C", repoPath}, args...)...)
+	if output != nil {
+		if len(output) > limit {
+			return output[:limit], true, err
+		}
+		return output, false, err
+	}
+	return captured.buf.Bytes(), captured.truncated(), err
+}
+
+// execGit executes git, writing the combined output to w when it is not nil and returning
+// it otherwise. Every git invocation of the tools goes through it, so it enforces
+// BlockedCommands, AllowedCommands and BlockDangerousArgs on the command and its arguments.
+func (g *Git) execGit(ctx context.Context, env []string, w io.Writer, args ...string) ([]byte, error) {
+	if command, commandArgs := splitGitCommand(args); command != "" {
+		if err := g.checkPolicy(command, commandArgs); err != nil {
+			return nil, err
+		}
+	}
+
+	if g.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, g.config.Timeout)
+		defer cancel()
+	}
+
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.WaitDelay = gitWaitDelay
+	cmd.Env = g.gitEnv(env)
+	if w != nil {
+		cmd.Stdout = w
+		cmd.Stderr = w
+	}
+	output, err := g.cmdExecutor.ExecuteCommand(ctx, cmd)
+	if err != nil && g.config.Timeout > 0 && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return output, fmt.Errorf("git command timed out after %s", g.config.Timeout)
+	}
+	return output, err
+}
+
+// splitGitCommand splits the arguments of a git invocation into the command and its arguments,
+// skipping the global options before the command: -C <path>, -c <name>=<value> and options
+// without a value
+func splitGitCommand(args []string) (string, []string) {
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i]; {
+		case arg == "-C" || arg == "-c":
+			i++
+		case strings.HasPrefix(arg, "-"):
+		default:
+			return arg, args[i+1:]
+		}
+	}
+	return "", nil
+}
+
+// gitOutputError returns err of a failed git invocation with the output git explained it in,
+// if any
+func gitOutputError(err error, output []byte) error {
+	if message := strings.TrimSpace(string(output)); message != "" {
+		return fmt.Errorf("%w: %s", err, message)
+	}
+	return err
+}
@@ -0,0 +1,134 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/shaharia-lab/goai"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// defaultCheckAttrAttributes are the attributes that control how git processes file content
+var defaultCheckAttrAttributes = []string{"filter", "diff", "merge", "text", "eol"}
+
+// GitCheckAttrTool returns a goai.Tool that reports the .gitattributes attributes applied to paths,
+// such as smudge/clean filters and text/eol handling.
+func (g *Git) GitCheckAttrTool() goai.Tool {
+	return goai.Tool{
+		Name:        GitCheckAttrToolName,
+		Description: "Reports the .gitattributes attributes (filter, diff, merge, text, eol) applied to the given paths",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"repo_path": {
+					"type": "string",
+					"description": "Path to Git repository"
+				},
+				"paths": {
+					"type": "array",
+					"items": {"type": "string"},
+					"description": "Paths to check"
+				},
+				"attributes": {
+					"type": "array",
+					"items": {"type": "string"},
+					"description": "Attributes to report (defaults to filter, diff, merge, text, eol)"
+				}
+			},
+			"required": ["repo_path", "paths"]
+		}`),
+		Handler: func(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
+			ctx, span := goai.StartSpan(ctx, fmt.Sprintf("%s.Handler", params.Name))
+			span.SetAttributes(
+				attribute.String("tool_name", params.Name),
+				attribute.String("tool_argument", string(params.Arguments)),
+			)
+			defer span.End()
+
+			g.logger.WithFields(map[string]interface{}{
+				"tool_name": params.Name,
+				"arguments": string(params.Arguments),
+			}).Info("Received input")
+
+			var input struct {
+				RepoPath   string   `json:"repo_path"`
+				Paths      []string `json:"paths"`
+				Attributes []string `json:"attributes"`
+			}
+
+			if err := json.Unmarshal(params.Arguments, &input); err != nil {
+				span.RecordError(err)
+				return goai.CallToolResult{}, fmt.Errorf("failed to unmarshal input: %w", err)
+			}
+
+			repoPath, err := g.resolveRepoPath(input.RepoPath)
+			if err != nil {
+				return returnErrorOutput(err), nil
+			}
+			input.RepoPath = repoPath
+
+			if len(input.Paths) == 0 {
+				return returnErrorOutput(fmt.Errorf("at least one path is required")), nil
+			}
+			if len(input.Attributes) == 0 {
+				input.Attributes = defaultCheckAttrAttributes
+			}
+			for _, attr := range input.Attributes {
+				if attr == "" || strings.HasPrefix(attr, "-") {
+					return returnErrorOutput(fmt.Errorf("invalid attribute name: %q", attr)), nil
+				}
+			}
+
+			args := append([]string{"check-attr", "-z"}, input.Attributes...)
+			args = append(append(args, "--"), input.Paths...)
+
+			output, err := g.runGit(ctx, input.RepoPath, args...)
+			if err != nil {
+				g.logger.WithFields(map[string]interface{}{
+					goai.ErrorLogField: err,
+					"output":           string(output),
+				}).Error("Git check-attr failed")
+
+				span.RecordError(err)
+				return returnErrorOutput(gitOutputError(err, output)), nil
+			}
+
+			attributes := parseCheckAttrOutput(string(output))
+
+			g.logger.WithFields(map[string]interface{}{
+				"tool":  GitCheckAttrToolName,
+				"paths": len(attributes),
+			}).Info("Git check-attr completed successfully")
+
+			text, err := marshalResult(attributes)
+			if err != nil {
+				return returnErrorOutput(err), nil
+			}
+
+			return goai.CallToolResult{
+				Content: []goai.ToolResultContent{{
+					Type: "json",
+					Text: text,
+				}},
+			}, nil
+		},
+	}
+}
+
+// parseCheckAttrOutput parses "git check-attr -z" output, a sequence of NUL-terminated
+// path, attribute and value triples, into a map of path to attribute values. Values are
+// "set", "unset", "unspecified" or the attribute's string value.
+func parseCheckAttrOutput(output string) map[string]map[string]string {
+	attributes := map[string]map[string]string{}
+	fields := strings.Split(output, "\x00")
+	for i := 0; i+2 < len(fields); i += 3 {
+		path, attr, value := fields[i], fields[i+1], fields[i+2]
+		if attributes[path] == nil {
+			attributes[path] = map[string]string{}
+		}
+		attributes[path][attr] = value
+	}
+	return attributes
+}
@@ -0,0 +1,61 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shaharia-lab/goai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCheckAttrOutput(t *testing.T) {
+	output := "assets/logo.psd\x00filter\x00lfs\x00" +
+		"assets/logo.psd\x00diff\x00lfs\x00" +
+		"assets/logo.psd\x00text\x00unset\x00" +
+		"main.go\x00filter\x00unspecified\x00" +
+		"main.go\x00text\x00set\x00"
+
+	attributes := parseCheckAttrOutput(output)
+
+	assert.Equal(t, map[string]map[string]string{
+		"assets/logo.psd": {"filter": "lfs", "diff": "lfs", "text": "unset"},
+		"main.go":         {"filter": "unspecified", "text": "set"},
+	}, attributes)
+}
+
+func TestGit_GitCheckAttrTool(t *testing.T) {
+	repoPath := initTestRepo(t)
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, ".gitattributes"), []byte("*.psd filter=lfs diff=lfs -text\n*.txt text eol=lf\n"), 0644))
+
+	git := NewGit(newPermissiveLogger(), GitConfig{})
+	tool := git.GitCheckAttrTool()
+
+	args, err := json.Marshal(map[string]interface{}{"repo_path": repoPath, "paths": []string{"logo.psd", "test.txt"}})
+	require.NoError(t, err)
+
+	result, err := tool.Handler(context.Background(), goai.CallToolParams{Name: GitCheckAttrToolName, Arguments: args})
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+
+	var attributes map[string]map[string]string
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &attributes))
+	assert.Equal(t, map[string]string{"filter": "lfs", "diff": "lfs", "merge": "unspecified", "text": "unset", "eol": "unspecified"}, attributes["logo.psd"])
+	assert.Equal(t, "set", attributes["test.txt"]["text"])
+	assert.Equal(t, "lf", attributes["test.txt"]["eol"])
+}
+
+func TestGit_GitCheckAttrTool_RejectsOptionLikeAttribute(t *testing.T) {
+	git := NewGit(newPermissiveLogger(), GitConfig{})
+	tool := git.GitCheckAttrTool()
+
+	args, err := json.Marshal(map[string]interface{}{"repo_path": t.TempDir(), "paths": []string{"a"}, "attributes": []string{"--all"}})
+	require.NoError(t, err)
+
+	result, err := tool.Handler(context.Background(), goai.CallToolParams{Name: GitCheckAttrToolName, Arguments: args})
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+}
@@ -0,0 +1,332 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/shaharia-lab/goai"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// conventionalCommitPattern matches "type(scope)!: description" Conventional Commit subjects
+var conventionalCommitPattern = regexp.MustCompile(`^([a-zA-Z]+)(?:\(([^)]*)\))?(!)?: (.+)$`)
+
+// changelogSectionTitles maps Conventional Commit types to changelog section titles.
+// Other types use the type itself as the title.
+var changelogSectionTitles = map[string]string{
+	"feat":     "Features",
+	"fix":      "Bug Fixes",
+	"perf":     "Performance",
+	"refactor": "Refactoring",
+	"docs":     "Documentation",
+	"test":     "Tests",
+	"chore":    "Chores",
+	"ci":       "CI",
+	"build":    "Build",
+}
+
+// changelogOtherType groups commits whose subject is not a Conventional Commit
+const changelogOtherType = "other"
+
+// changelogEntry is a single commit of a changelog
+type changelogEntry struct {
+	SHA         string `json:"sha"`
+	Subject     string `json:"subject"`
+	Type        string `json:"type,omitempty"`
+	Scope       string `json:"scope,omitempty"`
+	Description string `json:"description"`
+	Breaking    bool   `json:"breaking,omitempty"`
+}
+
+// changelogGroup holds the entries of one Conventional Commit type
+type changelogGroup struct {
+	Type    string           `json:"type"`
+	Title   string           `json:"title"`
+	Entries []changelogEntry `json:"entries"`
+}
+
+// changelog is the changelog between two refs, in both structured and markdown form
+type changelog struct {
+	From     string           `json:"from,omitempty"`
+	To       string           `json:"to"`
+	Commits  int              `json:"commits"`
+	Groups   []changelogGroup `json:"groups,omitempty"`
+	Entries  []changelogEntry `json:"entries,omitempty"`
+	Markdown string           `json:"markdown"`
+}
+
+// GitChangelogTool returns a goai.Tool that generates a changelog from the commit subjects
+// between two tags, optionally grouped by Conventional Commit type.
+func (g *Git) GitChangelogTool() goai.Tool {
+	return goai.Tool{
+		Name:        GitChangelogToolName,
+		Description: "Generates a markdown and JSON changelog from the commits between two tags (defaults to the latest two)",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"repo_path": {
+					"type": "string",
+					"description": "Path to Git repository"
+				},
+				"from": {
+					"type": "string",
+					"description": "Older tag or ref (defaults to the tag before 'to')"
+				},
+				"to": {
+					"type": "string",
+					"description": "Newer tag or ref (defaults to the latest tag)"
+				},
+				"group_by_type": {
+					"type": "boolean",
+					"description": "Group commits by Conventional Commit type (feat, fix, chore, ...)"
+				}
+			},
+			"required": ["repo_path"]
+		}`),
+		Handler: func(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
+			ctx, span := goai.StartSpan(ctx, fmt.Sprintf("%s.Handler", params.Name))
+			span.SetAttributes(
+				attribute.String("tool_name", params.Name),
+				attribute.String("tool_argument", string(params.Arguments)),
+			)
+			defer span.End()
+
+			g.logger.WithFields(map[string]interface{}{
+				"tool_name": params.Name,
+				"arguments": string(params.Arguments),
+			}).Info("Received input")
+
+			var input struct {
+				RepoPath    string `json:"repo_path"`
+				From        string `json:"from"`
+				To          string `json:"to"`
+				GroupByType bool   `json:"group_by_type"`
+			}
+
+			if err := json.Unmarshal(params.Arguments, &input); err != nil {
+				span.RecordError(err)
+				return goai.CallToolResult{}, fmt.Errorf("failed to unmarshal input: %w", err)
+			}
+
+			repoPath, err := g.resolveRepoPath(input.RepoPath)
+			if err != nil {
+				return returnErrorOutput(err), nil
+			}
+			input.RepoPath = repoPath
+
+			cl, err := g.buildChangelog(ctx, input.RepoPath, input.From, input.To, input.GroupByType)
+			if err != nil {
+				g.logger.WithFields(map[string]interface{}{
+					goai.ErrorLogField: err,
+				}).Error("Git changelog failed")
+
+				span.RecordError(err)
+				return returnErrorOutput(err), nil
+			}
+
+			g.logger.WithFields(map[string]interface{}{
+				"tool":    GitChangelogToolName,
+				"from":    cl.From,
+				"to":      cl.To,
+				"commits": cl.Commits,
+			}).Info("Git changelog generated successfully")
+
+			text, err := marshalResult(cl)
+			if err != nil {
+				return returnErrorOutput(err), nil
+			}
+
+			return goai.CallToolResult{
+				Content: []goai.ToolResultContent{{
+					Type: "json",
+					Text: text,
+				}},
+			}, nil
+		},
+	}
+}
+
+// buildChangelog resolves the refs and collects the changelog of the commits in from..to.
+// Refs that would be parsed as options are rejected.
+func (g *Git) buildChangelog(ctx context.Context, repoPath, from, to string, groupByType bool) (*changelog, error) {
+	if strings.HasPrefix(from, "-") {
+		return nil, fmt.Errorf("invalid from: %q", from)
+	}
+	if strings.HasPrefix(to, "-") {
+		return nil, fmt.Errorf("invalid to: %q", to)
+	}
+
+	from, to, err := g.resolveChangelogRefs(ctx, repoPath, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	revision := to
+	if from != "" {
+		revision = from + ".." + to
+	}
+
+	output, err := g.runGit(ctx, repoPath, "log", "--no-merges", "--format=%H%x00%s", revision, "--")
+	if err != nil {
+		return nil, gitOutputError(err, output)
+	}
+
+	entries := parseChangelogEntries(string(output))
+	cl := &changelog{From: from, To: to, Commits: len(entries)}
+	if groupByType {
+		cl.Groups = groupChangelogEntries(entries)
+	} else {
+		cl.Entries = entries
+	}
+	cl.Markdown = renderChangelogMarkdown(cl)
+	return cl, nil
+}
+
+// resolveChangelogRefs fills in omitted refs: to defaults to the latest tag and from to the
+// tag preceding to. from stays empty when to is the first tag, covering its whole history.
+func (g *Git) resolveChangelogRefs(ctx context.Context, repoPath, from, to string) (string, string, error) {
+	if to == "" {
+		output, err := g.runGit(ctx, repoPath, "tag", "--sort=-version:refname", "--sort=-creatordate")
+		if err != nil {
+			return "", "", gitOutputError(err, output)
+		}
+		tags := strings.Fields(string(output))
+		if len(tags) == 0 {
+			return "", "", fmt.Errorf("repository has no tags; provide from and to")
+		}
+		to = tags[0]
+		if from == "" && len(tags) > 1 {
+			from = tags[1]
+		}
+		return from, to, nil
+	}
+
+	if from == "" {
+		// A failing describe means there is no earlier tag
+		if output, err := g.runGit(ctx, repoPath, "describe", "--tags", "--abbrev=0", to+"^"); err == nil {
+			from = strings.TrimSpace(string(output))
+		}
+	}
+	return from, to, nil
+}
+
+// parseChangelogEntries parses "%H%x00%s" log lines into changelog entries
+func parseChangelogEntries(output string) []changelogEntry {
+	entries := []changelogEntry{}
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		sha, subject, ok := strings.Cut(line, "\x00")
+		if !ok {
+			continue
+		}
+
+		entry := changelogEntry{SHA: sha, Subject: subject, Description: subject}
+		if m := conventionalCommitPattern.FindStringSubmatch(subject); m != nil {
+			entry.Type = strings.ToLower(m[1])
+			entry.Scope = m[2]
+			entry.Breaking = m[3] == "!"
+			entry.Description = m[4]
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// groupChangelogEntries groups entries by type: features and fixes first, then the other
+// types alphabetically and finally the non-conventional commits
+func groupChangelogEntries(entries []changelogEntry) []changelogGroup {
+	byType := map[string][]changelogEntry{}
+	for _, entry := range entries {
+		t := entry.Type
+		if t == "" {
+			t = changelogOtherType
+		}
+		byType[t] = append(byType[t], entry)
+	}
+
+	rank := func(t string) int {
+		switch t {
+		case "feat":
+			return 0
+		case "fix":
+			return 1
+		case changelogOtherType:
+			return 3
+		}
+		return 2
+	}
+
+	types := make([]string, 0, len(byType))
+	for t := range byType {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		if rank(types[i]) != rank(types[j]) {
+			return rank(types[i]) < rank(types[j])
+		}
+		return types[i] < types[j]
+	})
+
+	groups := make([]changelogGroup, 0, len(types))
+	for _, t := range types {
+		groups = append(groups, changelogGroup{Type: t, Title: changelogSectionTitle(t), Entries: byType[t]})
+	}
+	return groups
+}
+
+// changelogSectionTitle returns the markdown section title for a commit type
+func changelogSectionTitle(t string) string {
+	if title, ok := changelogSectionTitles[t]; ok {
+		return title
+	}
+	if t == changelogOtherType {
+		return "Other Changes"
+	}
+	return t
+}
+
+// renderChangelogMarkdown renders a changelog as a markdown document
+func renderChangelogMarkdown(cl *changelog) string {
+	var b strings.Builder
+	if cl.From != "" {
+		fmt.Fprintf(&b, "## %s (changes since %s)\n", cl.To, cl.From)
+	} else {
+		fmt.Fprintf(&b, "## %s\n", cl.To)
+	}
+
+	writeEntries := func(entries []changelogEntry) {
+		for _, entry := range entries {
+			line := entry.Description
+			if entry.Scope != "" {
+				line = fmt.Sprintf("**%s:** %s", entry.Scope, line)
+			}
+			if entry.Breaking {
+				line = "**BREAKING** " + line
+			}
+			fmt.Fprintf(&b, "- %s (%s)\n", line, shortSHA(entry.SHA))
+		}
+	}
+
+	if cl.Groups == nil {
+		b.WriteString("\n")
+		writeEntries(cl.Entries)
+		return b.String()
+	}
+
+	for _, group := range cl.Groups {
+		fmt.Fprintf(&b, "\n### %s\n\n", group.Title)
+		writeEntries(group.Entries)
+	}
+	return b.String()
+}
+
+// shortSHA abbreviates a commit SHA to 7 characters
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
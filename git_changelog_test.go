@@ -0,0 +1,110 @@
+package mcptools
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// initTestRepoWithTags returns a test repository with tags v1.0.0 and v1.1.0 and an
+// untagged commit after v1.1.0
+func initTestRepoWithTags(t *testing.T) string {
+	t.Helper()
+	repoPath := initTestRepo(t)
+	runTestGit(t, repoPath, "tag", "v1.0.0")
+
+	for _, subject := range []string{"feat(api): add search endpoint", "fix: handle nil config", "chore: bump dependencies", "Update README", "feat!: drop legacy flags"} {
+		runTestGit(t, repoPath, "commit", "--allow-empty", "-m", subject)
+	}
+	runTestGit(t, repoPath, "tag", "v1.1.0")
+	runTestGit(t, repoPath, "commit", "--allow-empty", "-m", "feat: unreleased work")
+
+	return repoPath
+}
+
+func TestGit_GitChangelogTool_LatestTwoTagsGrouped(t *testing.T) {
+	repoPath := initTestRepoWithTags(t)
+
+	result := callGitCommandTool(t, NewGit(newPermissiveLogger(), GitConfig{}).GitChangelogTool(), map[string]interface{}{"repo_path": repoPath, "group_by_type": true})
+	require.False(t, result.IsError, result.Content)
+
+	var cl changelog
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &cl))
+	assert.Equal(t, "v1.0.0", cl.From)
+	assert.Equal(t, "v1.1.0", cl.To)
+	assert.Equal(t, 5, cl.Commits)
+
+	titles := make([]string, len(cl.Groups))
+	for i, group := range cl.Groups {
+		titles[i] = group.Title
+	}
+	assert.Equal(t, []string{"Features", "Bug Fixes", "Chores", "Other Changes"}, titles)
+
+	features := cl.Groups[0].Entries
+	require.Len(t, features, 2)
+	assert.Equal(t, "drop legacy flags", features[0].Description)
+	assert.True(t, features[0].Breaking)
+	assert.Equal(t, "api", features[1].Scope)
+
+	assert.Contains(t, cl.Markdown, "## v1.1.0 (changes since v1.0.0)")
+	assert.Contains(t, cl.Markdown, "### Features\n\n- **BREAKING** drop legacy flags (")
+	assert.Contains(t, cl.Markdown, "- **api:** add search endpoint (")
+	assert.Contains(t, cl.Markdown, "### Other Changes\n\n- Update README (")
+	assert.NotContains(t, cl.Markdown, "unreleased work")
+}
+
+func TestGit_GitChangelogTool_ExplicitRefsUngrouped(t *testing.T) {
+	repoPath := initTestRepoWithTags(t)
+
+	result := callGitCommandTool(t, NewGit(newPermissiveLogger(), GitConfig{}).GitChangelogTool(), map[string]interface{}{"repo_path": repoPath, "from": "v1.1.0", "to": "HEAD"})
+	require.False(t, result.IsError, result.Content)
+
+	var cl changelog
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &cl))
+	assert.Nil(t, cl.Groups)
+	require.Len(t, cl.Entries, 1)
+	assert.Equal(t, "feat: unreleased work", cl.Entries[0].Subject)
+	assert.Equal(t, "feat", cl.Entries[0].Type)
+}
+
+func TestGit_GitChangelogTool_ToWithoutFrom(t *testing.T) {
+	repoPath := initTestRepoWithTags(t)
+
+	result := callGitCommandTool(t, NewGit(newPermissiveLogger(), GitConfig{}).GitChangelogTool(), map[string]interface{}{"repo_path": repoPath, "to": "v1.1.0"})
+	require.False(t, result.IsError, result.Content)
+
+	var cl changelog
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &cl))
+	assert.Equal(t, "v1.0.0", cl.From)
+	assert.Equal(t, 5, cl.Commits)
+}
+
+func TestGit_GitChangelogTool_NoTags(t *testing.T) {
+	repoPath := initTestRepo(t)
+
+	result := callGitCommandTool(t, NewGit(newPermissiveLogger(), GitConfig{}).GitChangelogTool(), map[string]interface{}{"repo_path": repoPath})
+	assert.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].Text, "repository has no tags")
+}
+
+func TestParseChangelogEntries(t *testing.T) {
+	entries := parseChangelogEntries("aaa\x00feat(ui)!: new layout\nbbb\x00Merge fixes\n")
+
+	require.Len(t, entries, 2)
+	assert.Equal(t, changelogEntry{SHA: "aaa", Subject: "feat(ui)!: new layout", Type: "feat", Scope: "ui", Description: "new layout", Breaking: true}, entries[0])
+	assert.Equal(t, changelogEntry{SHA: "bbb", Subject: "Merge fixes", Description: "Merge fixes"}, entries[1])
+}
+
+func TestGit_GitChangelogTool_OptionRefs(t *testing.T) {
+	repoPath := initTestRepoWithTags(t)
+
+	result := callGitCommandTool(t, NewGit(newPermissiveLogger(), GitConfig{}).GitChangelogTool(), map[string]interface{}{"repo_path": repoPath, "from": "v1.0.0", "to": "--output=/tmp/changelog"})
+	assert.True(t, result.IsError)
+	assert.Equal(t, `invalid to: "--output=/tmp/changelog"`, result.Content[0].Text)
+
+	result = callGitCommandTool(t, NewGit(newPermissiveLogger(), GitConfig{}).GitChangelogTool(), map[string]interface{}{"repo_path": repoPath, "from": "--all", "to": "v1.1.0"})
+	assert.True(t, result.IsError)
+	assert.Equal(t, `invalid from: "--all"`, result.Content[0].Text)
+}
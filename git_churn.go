@@ -0,0 +1,200 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/shaharia-lab/goai"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// churnCommitMarker prefixes the per-commit author line emitted by churnLogFormat
+const churnCommitMarker = "\x00"
+
+// churnLogFormat prints the author of each commit on a line of its own before its numstat
+const churnLogFormat = "--format=%x00%aN <%aE>"
+
+// churnStats summarizes code churn over a revision range
+type churnStats struct {
+	Commits      int           `json:"commits"`
+	FilesChanged int           `json:"files_changed"`
+	Additions    int           `json:"additions"`
+	Deletions    int           `json:"deletions"`
+	Churn        int           `json:"churn"`
+	BinaryFiles  int           `json:"binary_files"`
+	Authors      []authorChurn `json:"authors,omitempty"`
+}
+
+// authorChurn is the churn attributed to a single author
+type authorChurn struct {
+	Author    string `json:"author"`
+	Commits   int    `json:"commits"`
+	Additions int    `json:"additions"`
+	Deletions int    `json:"deletions"`
+}
+
+// GitChurnTool returns a goai.Tool that computes files changed, additions and deletions
+// over a revision range, optionally broken down per author.
+func (g *Git) GitChurnTool() goai.Tool {
+	return goai.Tool{
+		Name:        GitChurnToolName,
+		Description: "Computes code churn metrics (files changed, additions, deletions) over a revision range",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"repo_path": {
+					"type": "string",
+					"description": "Path to Git repository"
+				},
+				"revision_range": {
+					"type": "string",
+					"description": "Revision range to analyze, e.g. v1.0.0..HEAD (defaults to HEAD)"
+				},
+				"by_author": {
+					"type": "boolean",
+					"description": "Include a per-author breakdown"
+				}
+			},
+			"required": ["repo_path"]
+		}`),
+		Handler: func(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
+			ctx, span := goai.StartSpan(ctx, fmt.Sprintf("%s.Handler", params.Name))
+			span.SetAttributes(
+				attribute.String("tool_name", params.Name),
+				attribute.String("tool_argument", string(params.Arguments)),
+			)
+			defer span.End()
+
+			g.logger.WithFields(map[string]interface{}{
+				"tool_name": params.Name,
+				"arguments": string(params.Arguments),
+			}).Info("Received input")
+
+			var input struct {
+				RepoPath      string `json:"repo_path"`
+				RevisionRange string `json:"revision_range"`
+				ByAuthor      bool   `json:"by_author"`
+			}
+
+			if err := json.Unmarshal(params.Arguments, &input); err != nil {
+				span.RecordError(err)
+				return goai.CallToolResult{}, fmt.Errorf("failed to unmarshal input: %w", err)
+			}
+
+			repoPath, err := g.resolveRepoPath(input.RepoPath)
+			if err != nil {
+				return returnErrorOutput(err), nil
+			}
+			input.RepoPath = repoPath
+
+			if input.RevisionRange == "" {
+				input.RevisionRange = "HEAD"
+			}
+			if strings.HasPrefix(input.RevisionRange, "-") {
+				return returnErrorOutput(fmt.Errorf("invalid revision_range: %q", input.RevisionRange)), nil
+			}
+
+			args := []string{"log", "--numstat", churnLogFormat, input.RevisionRange, "--"}
+			output, err := g.runGit(ctx, input.RepoPath, args...)
+			if err != nil {
+				g.logger.WithFields(map[string]interface{}{
+					goai.ErrorLogField: err,
+					"output":           string(output),
+				}).Error("Git churn log failed")
+
+				span.RecordError(err)
+				return returnErrorOutput(gitOutputError(err, output)), nil
+			}
+
+			stats := parseNumstat(string(output))
+			if !input.ByAuthor {
+				stats.Authors = nil
+			}
+
+			g.logger.WithFields(map[string]interface{}{
+				"tool":          GitChurnToolName,
+				"commits":       stats.Commits,
+				"files_changed": stats.FilesChanged,
+			}).Info("Git churn computed successfully")
+
+			text, err := marshalResult(stats)
+			if err != nil {
+				return returnErrorOutput(err), nil
+			}
+
+			return goai.CallToolResult{
+				Content: []goai.ToolResultContent{{
+					Type: "json",
+					Text: text,
+				}},
+			}, nil
+		},
+	}
+}
+
+// parseNumstat aggregates "git log --numstat" output in which each commit starts with a
+// churnCommitMarker-prefixed author line. Binary files are reported by git as "-\t-\tpath"
+// and are counted separately without contributing to additions or deletions.
+func parseNumstat(output string) churnStats {
+	stats := churnStats{}
+	byAuthor := map[string]*authorChurn{}
+	files := map[string]bool{}
+	binaries := map[string]bool{}
+	var current *authorChurn
+
+	for _, line := range strings.Split(output, "\n") {
+		if strings.HasPrefix(line, churnCommitMarker) {
+			author := strings.TrimPrefix(line, churnCommitMarker)
+			current = byAuthor[author]
+			if current == nil {
+				current = &authorChurn{Author: author}
+				byAuthor[author] = current
+			}
+			current.Commits++
+			stats.Commits++
+			continue
+		}
+
+		fields := strings.SplitN(line, "\t", 3)
+		if len(fields) != 3 {
+			continue
+		}
+
+		path := fields[2]
+		files[path] = true
+		if fields[0] == "-" && fields[1] == "-" {
+			binaries[path] = true
+			continue
+		}
+
+		added, errAdded := strconv.Atoi(fields[0])
+		deleted, errDeleted := strconv.Atoi(fields[1])
+		if errAdded != nil || errDeleted != nil {
+			continue
+		}
+
+		stats.Additions += added
+		stats.Deletions += deleted
+		if current != nil {
+			current.Additions += added
+			current.Deletions += deleted
+		}
+	}
+
+	stats.FilesChanged = len(files)
+	stats.BinaryFiles = len(binaries)
+	stats.Churn = stats.Additions + stats.Deletions
+
+	for _, author := range byAuthor {
+		stats.Authors = append(stats.Authors, *author)
+	}
+	sort.Slice(stats.Authors, func(i, j int) bool {
+		return stats.Authors[i].Author < stats.Authors[j].Author
+	})
+
+	return stats
+}
@@ -0,0 +1,82 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shaharia-lab/goai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNumstat(t *testing.T) {
+	output := "\x00Alice <alice@example.com>\n" +
+		"\n" +
+		"10\t2\tmain.go\n" +
+		"-\t-\tlogo.png\n" +
+		"\x00Bob <bob@example.com>\n" +
+		"\n" +
+		"3\t4\tmain.go\n" +
+		"1\t0\tREADME.md\n" +
+		"\x00Alice <alice@example.com>\n" +
+		"\n" +
+		"0\t5\tutil.go\n"
+
+	stats := parseNumstat(output)
+
+	assert.Equal(t, 3, stats.Commits)
+	assert.Equal(t, 4, stats.FilesChanged)
+	assert.Equal(t, 14, stats.Additions)
+	assert.Equal(t, 11, stats.Deletions)
+	assert.Equal(t, 25, stats.Churn)
+	assert.Equal(t, 1, stats.BinaryFiles)
+	assert.Equal(t, []authorChurn{
+		{Author: "Alice <alice@example.com>", Commits: 2, Additions: 10, Deletions: 7},
+		{Author: "Bob <bob@example.com>", Commits: 1, Additions: 4, Deletions: 4},
+	}, stats.Authors)
+}
+
+func TestGit_GitChurnTool(t *testing.T) {
+	repoPath := initTestRepo(t)
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "test.txt"), []byte("line one\nline two\n"), 0644))
+	runTestGit(t, repoPath, "commit", "-am", "Expand test file")
+
+	git := NewGit(newPermissiveLogger(), GitConfig{})
+	tool := git.GitChurnTool()
+
+	args, err := json.Marshal(map[string]interface{}{"repo_path": repoPath, "revision_range": "HEAD~1..HEAD", "by_author": true})
+	require.NoError(t, err)
+
+	result, err := tool.Handler(context.Background(), goai.CallToolParams{Name: GitChurnToolName, Arguments: args})
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+
+	var stats churnStats
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &stats))
+	assert.Equal(t, 1, stats.Commits)
+	assert.Equal(t, 1, stats.FilesChanged)
+	assert.Equal(t, 2, stats.Additions)
+	assert.Equal(t, 1, stats.Deletions)
+	require.Len(t, stats.Authors, 1)
+	assert.Equal(t, "Test User <test@example.com>", stats.Authors[0].Author)
+}
+
+func TestGit_GitChurnTool_OptionRevisionRange(t *testing.T) {
+	repoPath := initTestRepo(t)
+	target := filepath.Join(t.TempDir(), "written")
+
+	git := NewGit(newPermissiveLogger(), GitConfig{})
+	tool := git.GitChurnTool()
+
+	args, err := json.Marshal(map[string]interface{}{"repo_path": repoPath, "revision_range": "--output=" + target})
+	require.NoError(t, err)
+
+	result, err := tool.Handler(context.Background(), goai.CallToolParams{Name: GitChurnToolName, Arguments: args})
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Equal(t, `invalid revision_range: "--output=`+target+`"`, result.Content[0].Text)
+	assert.NoFileExists(t, target)
+}
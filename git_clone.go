@@ -0,0 +1,234 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/shaharia-lab/goai"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// cloneTokenEnv is the environment variable the clone credential helper reads the token from
+const cloneTokenEnv = "MCP_GIT_AUTH_TOKEN"
+
+// cloneCredentialHelper answers git credential requests with the token from cloneTokenEnv, so
+// the token never appears in the clone URL, the command line or the tool logs
+const cloneCredentialHelper = `!f() { test "$1" = get && echo username=x-access-token && echo "password=$` + cloneTokenEnv + `"; }; f`
+
+// DefaultGitAuthTokenHost is the only host the configured AuthToken is offered to when
+// GitConfig.AuthTokenHosts is not set
+const DefaultGitAuthTokenHost = "github.com"
+
+// cloneResult is the checkout produced by the clone tool
+type cloneResult struct {
+	Path   string `json:"path"`
+	Branch string `json:"branch"`
+}
+
+// GitCloneTool returns a goai.Tool that clones a repository into target_path. Credentials for
+// https remotes come from the auth_token field or the configured AuthToken and are handed to git
+// through a credential helper scoped to the host of the URL, never through the URL. The
+// configured AuthToken is only offered to AuthTokenHosts.
+func (g *Git) GitCloneTool() goai.Tool {
+	return goai.Tool{
+		Name:        GitCloneToolName,
+		Description: "Clones a Git repository into a target path, optionally a single branch and a shallow history, and returns the checkout path",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"url": {
+					"type": "string",
+					"description": "URL of the repository to clone. Do not embed credentials, use auth_token instead"
+				},
+				"target_path": {
+					"type": "string",
+					"description": "Directory to clone into. Its parent directory must exist"
+				},
+				"branch": {
+					"type": "string",
+					"description": "Branch or tag to check out instead of the remote HEAD"
+				},
+				"depth": {
+					"type": "integer",
+					"description": "Create a shallow clone with this many commits of history"
+				},
+				"auth_token": {
+					"type": "string",
+					"description": "Token used to authenticate https clones (defaults to the configured token)"
+				}
+			},
+			"required": ["url", "target_path"]
+		}`),
+		Handler: func(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
+			ctx, span := goai.StartSpan(ctx, fmt.Sprintf("%s.Handler", params.Name))
+			span.SetAttributes(
+				attribute.String("tool_name", params.Name),
+				attribute.String("tool_argument", maskArguments(params.Arguments, "auth_token")),
+			)
+			defer span.End()
+
+			// The auth_token is never logged
+			g.logger.WithFields(map[string]interface{}{
+				"tool_name": params.Name,
+				"arguments": maskArguments(params.Arguments, "auth_token"),
+			}).Info("Received input")
+
+			var input struct {
+				URL        string `json:"url"`
+				TargetPath string `json:"target_path"`
+				Branch     string `json:"branch"`
+				Depth      int    `json:"depth"`
+				AuthToken  string `json:"auth_token"`
+			}
+
+			if err := json.Unmarshal(params.Arguments, &input); err != nil {
+				span.RecordError(err)
+				return goai.CallToolResult{}, fmt.Errorf("failed to unmarshal input: %w", err)
+			}
+
+			if blocked, ok := g.blockedBy("clone", nil); ok {
+				g.logger.WithFields(map[string]interface{}{
+					"tool":    GitCloneToolName,
+					"command": "clone",
+					"pattern": blocked,
+				}).Warn("Blocked git command")
+
+				return returnErrorOutput(fmt.Errorf("command %q is blocked by policy", "clone")), nil
+			}
+			if input.URL == "" {
+				return returnErrorOutput(fmt.Errorf("url is required")), nil
+			}
+			if strings.HasPrefix(input.URL, "-") {
+				return returnErrorOutput(fmt.Errorf("invalid url: %q", input.URL)), nil
+			}
+			if strings.HasPrefix(input.Branch, "-") {
+				return returnErrorOutput(fmt.Errorf("invalid branch: %q", input.Branch)), nil
+			}
+			if input.Depth < 0 {
+				return returnErrorOutput(fmt.Errorf("depth must not be negative")), nil
+			}
+
+			target, err := g.resolveCloneTarget(input.TargetPath)
+			if err != nil {
+				return returnErrorOutput(err), nil
+			}
+
+			token := input.AuthToken
+			if token == "" && g.authTokenAllowed(input.URL) {
+				token = g.config.AuthToken
+			}
+
+			args, env := cloneArgs(input.URL, target, input.Branch, input.Depth, token)
+			output, err := g.runGitWithEnv(ctx, env, args...)
+			if err != nil {
+				err = gitOutputError(err, output)
+				g.logger.WithFields(map[string]interface{}{
+					goai.ErrorLogField: err,
+					"target_path":      target,
+				}).Error("Git clone failed")
+
+				span.RecordError(err)
+				return returnErrorOutput(err), nil
+			}
+
+			result := cloneResult{Path: target}
+			// An empty remote has no branch to report, which does not fail the clone
+			if branch, err := g.runGit(ctx, target, "rev-parse", "--abbrev-ref", "HEAD"); err == nil {
+				result.Branch = strings.TrimSpace(string(branch))
+			}
+
+			g.logger.WithFields(map[string]interface{}{
+				"tool":        GitCloneToolName,
+				"target_path": target,
+				"branch":      result.Branch,
+			}).Info("Git clone completed successfully")
+
+			text, err := marshalResult(result)
+			if err != nil {
+				return returnErrorOutput(err), nil
+			}
+
+			return goai.CallToolResult{
+				Content: []goai.ToolResultContent{{
+					Type: "json",
+					Text: text,
+				}},
+			}, nil
+		},
+	}
+}
+
+// resolveCloneTarget returns the absolute clone destination for targetPath. The parent
+// directory must exist and, when AllowedRepoRoots is configured, lie within one of the roots.
+func (g *Git) resolveCloneTarget(targetPath string) (string, error) {
+	if targetPath == "" {
+		return "", fmt.Errorf("target_path is required")
+	}
+	absPath, err := filepath.Abs(targetPath)
+	if err != nil {
+		return "", fmt.Errorf("invalid target_path %s: %w", targetPath, err)
+	}
+
+	parent, err := g.resolveRepoPath(filepath.Dir(absPath))
+	if err != nil {
+		return "", fmt.Errorf("invalid target_path %s: %w", targetPath, err)
+	}
+	return filepath.Join(parent, filepath.Base(absPath)), nil
+}
+
+// authTokenAllowed reports whether the configured AuthToken may be offered to the https remote
+// rawURL, i.e. whether its host is one of AuthTokenHosts
+func (g *Git) authTokenAllowed(rawURL string) bool {
+	host, ok := httpsHost(rawURL)
+	if !ok {
+		return false
+	}
+	hosts := g.config.AuthTokenHosts
+	if hosts == nil {
+		hosts = []string{DefaultGitAuthTokenHost}
+	}
+	for _, allowed := range hosts {
+		if strings.EqualFold(host, allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+// httpsHost returns the host, including any port, of an https URL
+func httpsHost(rawURL string) (string, bool) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return "", false
+	}
+	return u.Host, true
+}
+
+// cloneArgs builds the git clone arguments and the extra environment for a clone. A token is
+// only offered to https remotes, through the environment read by cloneCredentialHelper. The
+// helper is scoped to the host of the URL, so a redirect to another host gets no token.
+func cloneArgs(rawURL, target, branch string, depth int, token string) ([]string, []string) {
+	env := []string{"GIT_TERMINAL_PROMPT=0"}
+
+	var args []string
+	if host, ok := httpsHost(rawURL); ok && token != "" {
+		// The empty helper drops any configured helpers so only the token is offered
+		args = append(args, "-c", "credential.helper=",
+			"-c", "credential.https://"+host+".helper="+cloneCredentialHelper)
+		env = append(env, cloneTokenEnv+"="+token)
+	}
+
+	args = append(args, "clone")
+	if branch != "" {
+		args = append(args, "--branch="+branch)
+	}
+	if depth > 0 {
+		args = append(args, "--depth="+strconv.Itoa(depth))
+	}
+	return append(args, "--", rawURL, target), env
+}
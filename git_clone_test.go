@@ -0,0 +1,240 @@
+package mcptools
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloneArgs(t *testing.T) {
+	tests := []struct {
+		name         string
+		url          string
+		branch       string
+		depth        int
+		token        string
+		expectedArgs []string
+		expectedEnv  []string
+	}{
+		{
+			name:         "plain clone",
+			url:          "https://example.com/repo.git",
+			expectedArgs: []string{"clone", "--", "https://example.com/repo.git", "/work/repo"},
+			expectedEnv:  []string{"GIT_TERMINAL_PROMPT=0"},
+		},
+		{
+			name:         "shallow single branch with token",
+			url:          "https://example.com/repo.git",
+			branch:       "release",
+			depth:        1,
+			token:        "s3cr3t",
+			expectedArgs: []string{"-c", "credential.helper=", "-c", "credential.https://example.com.helper=" + cloneCredentialHelper, "clone", "--branch=release", "--depth=1", "--", "https://example.com/repo.git", "/work/repo"},
+			expectedEnv:  []string{"GIT_TERMINAL_PROMPT=0", "MCP_GIT_AUTH_TOKEN=s3cr3t"},
+		},
+		{
+			name:         "token is not offered to ssh remotes",
+			url:          "git@example.com:repo.git",
+			token:        "s3cr3t",
+			expectedArgs: []string{"clone", "--", "git@example.com:repo.git", "/work/repo"},
+			expectedEnv:  []string{"GIT_TERMINAL_PROMPT=0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args, env := cloneArgs(tt.url, "/work/repo", tt.branch, tt.depth, tt.token)
+			assert.Equal(t, tt.expectedArgs, args)
+			assert.Equal(t, tt.expectedEnv, env)
+		})
+	}
+}
+
+func TestGit_GitCloneTool(t *testing.T) {
+	source := initTestRepo(t)
+	runTestGit(t, source, "checkout", "-q", "-b", "release")
+	runTestGit(t, source, "commit", "--allow-empty", "-m", "Release commit")
+	runTestGit(t, source, "checkout", "-q", "main")
+
+	target := filepath.Join(t.TempDir(), "clone")
+	result := callGitCommandTool(t, NewGit(newPermissiveLogger(), GitConfig{}).GitCloneTool(), map[string]interface{}{
+		"url":         "file://" + source,
+		"target_path": target,
+		"branch":      "release",
+		"depth":       1,
+	})
+	require.False(t, result.IsError, result.Content)
+
+	var cloned cloneResult
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &cloned))
+	resolvedTarget, err := filepath.EvalSymlinks(target)
+	require.NoError(t, err)
+	assert.Equal(t, cloneResult{Path: resolvedTarget, Branch: "release"}, cloned)
+	assert.Equal(t, "Release commit\n", runTestGit(t, target, "log", "--format=%s"))
+}
+
+func TestGit_GitCloneTool_TokenIsNeverExposed(t *testing.T) {
+	const token = "ghp_s3cr3tTokenValue"
+
+	tests := []struct {
+		name   string
+		config GitConfig
+		input  map[string]interface{}
+	}{
+		{
+			name:  "token from input",
+			input: map[string]interface{}{"auth_token": token},
+		},
+		{
+			name:   "token from input without redaction rules",
+			config: GitConfig{RedactionRules: []RedactionRule{}},
+			input:  map[string]interface{}{"auth_token": token},
+		},
+		{
+			name:   "token from config",
+			config: GitConfig{AuthToken: token},
+			input:  map[string]interface{}{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var executed *exec.Cmd
+			mockExecutor := new(MockCommandExecutor)
+			mockExecutor.On("ExecuteCommand", mock.Anything, mock.MatchedBy(func(cmd *exec.Cmd) bool {
+				if executed == nil {
+					executed = cmd
+				}
+				return true
+			})).Return([]byte("main\n"), nil)
+
+			logger := newPermissiveLogger()
+			git := NewGit(logger, tt.config)
+			git.cmdExecutor = mockExecutor
+
+			tt.input["url"] = "https://github.com/example/private.git"
+			tt.input["target_path"] = filepath.Join(t.TempDir(), "private")
+			result := callGitCommandTool(t, git.GitCloneTool(), tt.input)
+			require.False(t, result.IsError, result.Content)
+
+			require.NotNil(t, executed)
+			assert.NotContains(t, strings.Join(executed.Args, " "), token)
+			assert.Contains(t, executed.Env, "MCP_GIT_AUTH_TOKEN="+token)
+			for _, call := range logger.Calls {
+				assert.NotContains(t, fmt.Sprint(call.Arguments...), token)
+			}
+		})
+	}
+}
+
+func TestGit_GitCloneTool_AuthTokenHosts(t *testing.T) {
+	const token = "ghp_s3cr3tTokenValue"
+
+	tests := []struct {
+		name        string
+		hosts       []string
+		url         string
+		expectToken bool
+	}{
+		{name: "default host", url: "https://github.com/example/private.git", expectToken: true},
+		{name: "foreign host", url: "https://attacker.example.com/repo.git"},
+		{name: "foreign host with github.com in the path", url: "https://attacker.example.com/github.com/repo.git"},
+		{name: "configured host", hosts: []string{"git.example.com"}, url: "https://git.example.com/repo.git", expectToken: true},
+		{name: "default host not configured", hosts: []string{"git.example.com"}, url: "https://github.com/example/private.git"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var executed *exec.Cmd
+			mockExecutor := new(MockCommandExecutor)
+			mockExecutor.On("ExecuteCommand", mock.Anything, mock.MatchedBy(func(cmd *exec.Cmd) bool {
+				if executed == nil {
+					executed = cmd
+				}
+				return true
+			})).Return([]byte("main\n"), nil)
+
+			git := NewGit(newPermissiveLogger(), GitConfig{AuthToken: token, AuthTokenHosts: tt.hosts})
+			git.cmdExecutor = mockExecutor
+
+			result := callGitCommandTool(t, git.GitCloneTool(), map[string]interface{}{
+				"url":         tt.url,
+				"target_path": filepath.Join(t.TempDir(), "repo"),
+			})
+			require.False(t, result.IsError, result.Content)
+
+			require.NotNil(t, executed)
+			if tt.expectToken {
+				assert.Contains(t, executed.Env, "MCP_GIT_AUTH_TOKEN="+token)
+				return
+			}
+			for _, v := range executed.Env {
+				assert.NotContains(t, v, token)
+			}
+			assert.NotContains(t, strings.Join(executed.Args, " "), "credential")
+		})
+	}
+}
+
+func TestGit_GitCloneTool_InvalidInput(t *testing.T) {
+	root := t.TempDir()
+	outside := t.TempDir()
+
+	tests := []struct {
+		name     string
+		input    map[string]interface{}
+		expected string
+	}{
+		{
+			name:     "missing url",
+			input:    map[string]interface{}{"target_path": filepath.Join(root, "repo")},
+			expected: "url is required",
+		},
+		{
+			name:     "url looks like an option",
+			input:    map[string]interface{}{"url": "--upload-pack=touch /tmp/pwned", "target_path": filepath.Join(root, "repo")},
+			expected: `invalid url: "--upload-pack=touch /tmp/pwned"`,
+		},
+		{
+			name:     "missing target path",
+			input:    map[string]interface{}{"url": "https://example.com/repo.git"},
+			expected: "target_path is required",
+		},
+		{
+			name:     "target outside of the allowed roots",
+			input:    map[string]interface{}{"url": "https://example.com/repo.git", "target_path": filepath.Join(outside, "repo")},
+			expected: "is outside of the allowed repository roots",
+		},
+		{
+			name:     "target escaping the allowed roots",
+			input:    map[string]interface{}{"url": "https://example.com/repo.git", "target_path": filepath.Join(root, "..", "repo")},
+			expected: "is outside of the allowed repository roots",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			git := NewGit(newPermissiveLogger(), GitConfig{AllowedRepoRoots: []string{root}})
+			git.cmdExecutor = new(MockCommandExecutor)
+
+			result := callGitCommandTool(t, git.GitCloneTool(), tt.input)
+			assert.True(t, result.IsError)
+			assert.Contains(t, result.Content[0].Text, tt.expected)
+		})
+	}
+}
+
+func TestGit_GitCloneTool_Blocked(t *testing.T) {
+	git := NewGit(newPermissiveLogger(), GitConfig{BlockedCommands: []string{"clone"}})
+	git.cmdExecutor = new(MockCommandExecutor)
+
+	result := callGitCommandTool(t, git.GitCloneTool(), map[string]interface{}{"url": "https://example.com/repo.git", "target_path": filepath.Join(t.TempDir(), "repo")})
+	assert.True(t, result.IsError)
+	assert.Equal(t, `command "clone" is blocked by policy`, result.Content[0].Text)
+}
@@ -0,0 +1,357 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/shaharia-lab/goai"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// gitToolCall is the input of the git tool that the commit, push and pull tools translate
+// their own input into
+type gitToolCall struct {
+	Command     string   `json:"command"`
+	RepoPath    string   `json:"repo_path,omitempty"`
+	Args        []string `json:"args,omitempty"`
+	AuthorName  string   `json:"author_name,omitempty"`
+	AuthorEmail string   `json:"author_email,omitempty"`
+	DryRun      bool     `json:"dry_run,omitempty"`
+}
+
+// GitCommitTool returns a goai.Tool that commits the staged changes of a repository, or all
+// changes to tracked files, with the given message. It runs through the git tool, so the
+// configured policies, commit identity and signing apply.
+func (g *Git) GitCommitTool() goai.Tool {
+	return goai.Tool{
+		Name:        GitCommitToolName,
+		Description: "Commits the staged changes of a repository, or all changes to tracked files, with a message",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"repo_path": {
+					"type": "string",
+					"description": "Path to Git repository (defaults to the configured default repository path)"
+				},
+				"message": {
+					"type": "string",
+					"description": "Commit message"
+				},
+				"all": {
+					"type": "boolean",
+					"description": "Stage all modified and deleted tracked files before committing (git commit -a)"
+				},
+				"paths": {
+					"type": "array",
+					"items": {
+						"type": "string"
+					},
+					"description": "Commit only the changes to these tracked paths, staged or not"
+				},
+				"amend": {
+					"type": "boolean",
+					"description": "Replace the last commit instead of creating a new one"
+				},
+				"allow_empty": {
+					"type": "boolean",
+					"description": "Create the commit even when it has no changes"
+				},
+				"author_name": {
+					"type": "string",
+					"description": "Author and committer name (defaults to the configured identity)"
+				},
+				"author_email": {
+					"type": "string",
+					"description": "Author and committer email (defaults to the configured identity)"
+				},
+				"dry_run": {
+					"type": "boolean",
+					"description": "Return the git invocation that would run without running it"
+				}
+			},
+			"required": ["message"]
+		}`),
+		Handler: func(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
+			ctx, span := goai.StartSpan(ctx, fmt.Sprintf("%s.Handler", params.Name))
+			span.SetAttributes(
+				attribute.String("tool_name", params.Name),
+				attribute.String("tool_argument", string(params.Arguments)),
+			)
+			defer span.End()
+
+			g.logger.WithFields(map[string]interface{}{
+				"tool_name": params.Name,
+				"arguments": string(params.Arguments),
+			}).Info("Received input")
+
+			var input struct {
+				RepoPath    string   `json:"repo_path"`
+				Message     string   `json:"message"`
+				All         bool     `json:"all"`
+				Paths       []string `json:"paths"`
+				Amend       bool     `json:"amend"`
+				AllowEmpty  bool     `json:"allow_empty"`
+				AuthorName  string   `json:"author_name"`
+				AuthorEmail string   `json:"author_email"`
+				DryRun      bool     `json:"dry_run"`
+			}
+
+			if err := json.Unmarshal(params.Arguments, &input); err != nil {
+				span.RecordError(err)
+				return goai.CallToolResult{}, fmt.Errorf("failed to unmarshal input: %w", err)
+			}
+
+			if strings.TrimSpace(input.Message) == "" {
+				return returnErrorOutput(errors.New("message is required")), nil
+			}
+			if input.All && len(input.Paths) > 0 {
+				return returnErrorOutput(errors.New("all and paths cannot be combined")), nil
+			}
+
+			return g.runGitTool(ctx, gitToolCall{
+				Command:     "commit",
+				RepoPath:    input.RepoPath,
+				Args:        commitArgs(input.Message, input.All, input.Amend, input.AllowEmpty, input.Paths),
+				AuthorName:  input.AuthorName,
+				AuthorEmail: input.AuthorEmail,
+				DryRun:      input.DryRun,
+			})
+		},
+	}
+}
+
+// GitPushTool returns a goai.Tool that pushes a branch to a remote. It runs through the git
+// tool, so the configured policies apply, including BlockedCommands patterns like "push --force".
+func (g *Git) GitPushTool() goai.Tool {
+	return goai.Tool{
+		Name:        GitPushToolName,
+		Description: "Pushes a branch of a repository to a remote, optionally setting it as the upstream",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"repo_path": {
+					"type": "string",
+					"description": "Path to Git repository (defaults to the configured default repository path)"
+				},
+				"remote": {
+					"type": "string",
+					"description": "Name of the remote to push to, e.g. origin"
+				},
+				"branch": {
+					"type": "string",
+					"description": "Name of the branch to push, e.g. main; refspecs are not accepted"
+				},
+				"set_upstream": {
+					"type": "boolean",
+					"description": "Set the pushed branch as the upstream of the local branch"
+				},
+				"force_with_lease": {
+					"type": "boolean",
+					"description": "Overwrite the remote branch, unless it has commits that were not fetched"
+				},
+				"dry_run": {
+					"type": "boolean",
+					"description": "Return the git invocation that would run without running it"
+				}
+			},
+			"required": ["remote", "branch"]
+		}`),
+		Handler: func(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
+			ctx, span := goai.StartSpan(ctx, fmt.Sprintf("%s.Handler", params.Name))
+			span.SetAttributes(
+				attribute.String("tool_name", params.Name),
+				attribute.String("tool_argument", string(params.Arguments)),
+			)
+			defer span.End()
+
+			g.logger.WithFields(map[string]interface{}{
+				"tool_name": params.Name,
+				"arguments": string(params.Arguments),
+			}).Info("Received input")
+
+			var input struct {
+				RepoPath       string `json:"repo_path"`
+				Remote         string `json:"remote"`
+				Branch         string `json:"branch"`
+				SetUpstream    bool   `json:"set_upstream"`
+				ForceWithLease bool   `json:"force_with_lease"`
+				DryRun         bool   `json:"dry_run"`
+			}
+
+			if err := json.Unmarshal(params.Arguments, &input); err != nil {
+				span.RecordError(err)
+				return goai.CallToolResult{}, fmt.Errorf("failed to unmarshal input: %w", err)
+			}
+
+			if err := validateRemoteBranch(input.Remote, input.Branch, true); err != nil {
+				return returnErrorOutput(err), nil
+			}
+
+			var args []string
+			if input.SetUpstream {
+				args = append(args, "--set-upstream")
+			}
+			if input.ForceWithLease {
+				args = append(args, "--force-with-lease")
+			}
+
+			return g.runGitTool(ctx, gitToolCall{
+				Command:  "push",
+				RepoPath: input.RepoPath,
+				Args:     append(args, input.Remote, input.Branch),
+				DryRun:   input.DryRun,
+			})
+		},
+	}
+}
+
+// GitPullTool returns a goai.Tool that pulls the upstream of the current branch, or a branch of
+// a remote, by merging or rebasing. It runs through the git tool, so the configured policies
+// and commit identity apply.
+func (g *Git) GitPullTool() goai.Tool {
+	return goai.Tool{
+		Name:        GitPullToolName,
+		Description: "Pulls the upstream of the current branch, or a branch of a remote, by merging, rebasing or fast-forwarding only",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"repo_path": {
+					"type": "string",
+					"description": "Path to Git repository (defaults to the configured default repository path)"
+				},
+				"remote": {
+					"type": "string",
+					"description": "Name of the remote to pull from (defaults to the upstream of the current branch)"
+				},
+				"branch": {
+					"type": "string",
+					"description": "Name of the branch of the remote to pull; requires remote"
+				},
+				"rebase": {
+					"type": "boolean",
+					"description": "Rebase the current branch onto the pulled commits instead of merging them"
+				},
+				"ff_only": {
+					"type": "boolean",
+					"description": "Only update the current branch when it can be fast-forwarded"
+				},
+				"dry_run": {
+					"type": "boolean",
+					"description": "Return the git invocation that would run without running it"
+				}
+			}
+		}`),
+		Handler: func(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
+			ctx, span := goai.StartSpan(ctx, fmt.Sprintf("%s.Handler", params.Name))
+			span.SetAttributes(
+				attribute.String("tool_name", params.Name),
+				attribute.String("tool_argument", string(params.Arguments)),
+			)
+			defer span.End()
+
+			g.logger.WithFields(map[string]interface{}{
+				"tool_name": params.Name,
+				"arguments": string(params.Arguments),
+			}).Info("Received input")
+
+			var input struct {
+				RepoPath string `json:"repo_path"`
+				Remote   string `json:"remote"`
+				Branch   string `json:"branch"`
+				Rebase   bool   `json:"rebase"`
+				FFOnly   bool   `json:"ff_only"`
+				DryRun   bool   `json:"dry_run"`
+			}
+
+			if err := json.Unmarshal(params.Arguments, &input); err != nil {
+				span.RecordError(err)
+				return goai.CallToolResult{}, fmt.Errorf("failed to unmarshal input: %w", err)
+			}
+
+			if err := validateRemoteBranch(input.Remote, input.Branch, false); err != nil {
+				return returnErrorOutput(err), nil
+			}
+			if input.Branch != "" && input.Remote == "" {
+				return returnErrorOutput(errors.New("branch requires remote")), nil
+			}
+			if input.Rebase && input.FFOnly {
+				return returnErrorOutput(errors.New("rebase and ff_only cannot be combined")), nil
+			}
+
+			var args []string
+			switch {
+			case input.Rebase:
+				args = append(args, "--rebase")
+			case input.FFOnly:
+				args = append(args, "--ff-only")
+			}
+			// Merges are never left waiting for an editor to confirm their message
+			args = append(args, "--no-edit")
+			if input.Remote != "" {
+				args = append(args, input.Remote)
+			}
+			if input.Branch != "" {
+				args = append(args, input.Branch)
+			}
+
+			return g.runGitTool(ctx, gitToolCall{
+				Command:  "pull",
+				RepoPath: input.RepoPath,
+				Args:     args,
+				DryRun:   input.DryRun,
+			})
+		},
+	}
+}
+
+// commitArgs returns the arguments of git commit for the commit tool. The message is passed as
+// --message=<message>, so that a message looking like an option is never parsed as one.
+func commitArgs(message string, all, amend, allowEmpty bool, paths []string) []string {
+	args := []string{"--message=" + message}
+	if all {
+		args = append(args, "--all")
+	}
+	if amend {
+		args = append(args, "--amend")
+	}
+	if allowEmpty {
+		args = append(args, "--allow-empty")
+	}
+	if len(paths) > 0 {
+		args = append(append(args, "--"), paths...)
+	}
+	return args
+}
+
+// validateRemoteBranch returns an error when the remote or branch of a push or pull is missing
+// while required, would be parsed as an option, or is a refspec rather than a name. A leading
+// "+" forces the update, bypassing BlockedCommands patterns like "push --force", and a ":"
+// maps or deletes remote branches.
+func validateRemoteBranch(remote, branch string, required bool) error {
+	if required && remote == "" {
+		return errors.New("remote is required")
+	}
+	if required && branch == "" {
+		return errors.New("branch is required")
+	}
+	if strings.HasPrefix(remote, "-") || strings.ContainsAny(remote, "+:") {
+		return fmt.Errorf("invalid remote: %q", remote)
+	}
+	if strings.HasPrefix(branch, "-") || strings.ContainsAny(branch, "+:") {
+		return fmt.Errorf("invalid branch: %q", branch)
+	}
+	return nil
+}
+
+// runGitTool runs call through the handler of the git tool, so that the commit, push and pull
+// tools share its repository resolution, policies, commit identity, output cap and dry runs
+func (g *Git) runGitTool(ctx context.Context, call gitToolCall) (goai.CallToolResult, error) {
+	args, err := json.Marshal(call)
+	if err != nil {
+		return returnErrorOutput(fmt.Errorf("failed to serialize git tool input: %w", err)), nil
+	}
+	return g.GitAllInOneTool().Handler(ctx, goai.CallToolParams{Name: GitToolName, Arguments: args})
+}
@@ -0,0 +1,259 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/shaharia-lab/goai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func callGitCommandTool(t *testing.T, tool goai.Tool, input map[string]interface{}) goai.CallToolResult {
+	t.Helper()
+	args, err := json.Marshal(input)
+	require.NoError(t, err)
+
+	result, err := tool.Handler(context.Background(), goai.CallToolParams{Name: tool.Name, Arguments: args})
+	require.NoError(t, err)
+	return result
+}
+
+// initTestRepoWithRemote returns a test repository whose origin remote is a bare repository
+// holding its main branch, and the path of that bare repository
+func initTestRepoWithRemote(t *testing.T) (string, string) {
+	t.Helper()
+	repoPath := initTestRepo(t)
+	remotePath := t.TempDir()
+
+	runTestGit(t, remotePath, "init", "--bare", "-b", "main")
+	runTestGit(t, repoPath, "remote", "add", "origin", remotePath)
+	runTestGit(t, repoPath, "push", "-u", "origin", "main")
+
+	return repoPath, remotePath
+}
+
+func TestGit_GitCommitTool(t *testing.T) {
+	repoPath := initTestRepo(t)
+	git := NewGit(newPermissiveLogger(), GitConfig{BlockDangerousArgs: true})
+
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "test.txt"), []byte("changed\n"), 0644))
+
+	result := callGitCommandTool(t, git.GitCommitTool(), map[string]interface{}{
+		"repo_path":    repoPath,
+		"message":      "--exec is mentioned in this message",
+		"all":          true,
+		"author_name":  "Release Bot",
+		"author_email": "bot@example.com",
+	})
+	require.False(t, result.IsError, result.Content)
+	assert.Contains(t, result.Content[0].Text, "--exec is mentioned in this message")
+
+	assert.Equal(t, "--exec is mentioned in this message|Release Bot <bot@example.com>",
+		strings.TrimSpace(runTestGit(t, repoPath, "log", "-1", "--format=%s|%an <%ae>")))
+	assert.Empty(t, strings.TrimSpace(runTestGit(t, repoPath, "status", "--porcelain")))
+
+	result = callGitCommandTool(t, git.GitCommitTool(), map[string]interface{}{
+		"repo_path": repoPath,
+		"message":   "Nothing to commit",
+	})
+	assert.True(t, result.IsError, "a commit without changes fails")
+
+	result = callGitCommandTool(t, git.GitCommitTool(), map[string]interface{}{
+		"repo_path":   repoPath,
+		"message":     "Empty commit",
+		"allow_empty": true,
+	})
+	require.False(t, result.IsError, result.Content)
+	assert.Equal(t, "3", strings.TrimSpace(runTestGit(t, repoPath, "rev-list", "--count", "HEAD")))
+}
+
+func TestGit_GitCommitTool_DryRun(t *testing.T) {
+	git := NewGit(newPermissiveLogger(), GitConfig{})
+	git.cmdExecutor = new(MockCommandExecutor)
+
+	result := callGitCommandTool(t, git.GitCommitTool(), map[string]interface{}{
+		"repo_path": "/repos/app",
+		"message":   "Fix the build",
+		"paths":     []string{"go.mod", "go.sum"},
+		"amend":     true,
+		"dry_run":   true,
+	})
+	require.False(t, result.IsError, result.Content)
+
+	var preview gitDryRun
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &preview))
+	assert.Equal(t, []string{"git", "-C", "/repos/app", "commit", "--message=Fix the build", "--amend", "--", "go.mod", "go.sum"}, preview.Args)
+}
+
+func TestGit_GitPushTool(t *testing.T) {
+	repoPath, remotePath := initTestRepoWithRemote(t)
+	git := NewGit(newPermissiveLogger(), GitConfig{})
+
+	runTestGit(t, repoPath, "checkout", "-b", "feature")
+	runTestGit(t, repoPath, "commit", "--allow-empty", "-m", "Feature work")
+
+	result := callGitCommandTool(t, git.GitPushTool(), map[string]interface{}{
+		"repo_path":    repoPath,
+		"remote":       "origin",
+		"branch":       "feature",
+		"set_upstream": true,
+	})
+	require.False(t, result.IsError, result.Content)
+
+	assert.Equal(t, "Feature work", strings.TrimSpace(runTestGit(t, remotePath, "log", "-1", "--format=%s", "feature")))
+	assert.Equal(t, "origin/feature", strings.TrimSpace(runTestGit(t, repoPath, "rev-parse", "--abbrev-ref", "feature@{upstream}")))
+}
+
+func TestGit_GitPushTool_BlockedCommands(t *testing.T) {
+	mockExecutor := new(MockCommandExecutor)
+	git := NewGit(newPermissiveLogger(), GitConfig{BlockedCommands: []string{"push --force*"}})
+	git.cmdExecutor = mockExecutor
+
+	result := callGitCommandTool(t, git.GitPushTool(), map[string]interface{}{
+		"repo_path":        "/repos/app",
+		"remote":           "origin",
+		"branch":           "main",
+		"force_with_lease": true,
+	})
+	assert.True(t, result.IsError)
+	assert.Equal(t, `command "push" is blocked by policy`, result.Content[0].Text)
+	mockExecutor.AssertNotCalled(t, "ExecuteCommand")
+}
+
+func TestGit_GitPullTool(t *testing.T) {
+	repoPath, remotePath := initTestRepoWithRemote(t)
+	git := NewGit(newPermissiveLogger(), GitConfig{})
+
+	other := t.TempDir()
+	runTestGit(t, other, "clone", "-b", "main", remotePath, ".")
+	runTestGit(t, other, "-c", "user.name=Other", "-c", "user.email=other@example.com", "commit", "--allow-empty", "-m", "Upstream change")
+	runTestGit(t, other, "push", "origin", "main")
+
+	result := callGitCommandTool(t, git.GitPullTool(), map[string]interface{}{
+		"repo_path": repoPath,
+		"ff_only":   true,
+	})
+	require.False(t, result.IsError, result.Content)
+	assert.Equal(t, "Upstream change", strings.TrimSpace(runTestGit(t, repoPath, "log", "-1", "--format=%s")))
+}
+
+func TestGit_GitPullTool_DryRun(t *testing.T) {
+	git := NewGit(newPermissiveLogger(), GitConfig{})
+	git.cmdExecutor = new(MockCommandExecutor)
+
+	result := callGitCommandTool(t, git.GitPullTool(), map[string]interface{}{
+		"repo_path": "/repos/app",
+		"remote":    "upstream",
+		"branch":    "main",
+		"rebase":    true,
+		"dry_run":   true,
+	})
+	require.False(t, result.IsError, result.Content)
+
+	var preview gitDryRun
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &preview))
+	assert.Equal(t, "git -C /repos/app pull --rebase --no-edit upstream main", preview.Command)
+}
+
+func TestGit_GitCommandTools_InvalidInput(t *testing.T) {
+	git := NewGit(newPermissiveLogger(), GitConfig{})
+
+	tests := []struct {
+		name     string
+		tool     goai.Tool
+		input    map[string]interface{}
+		expected string
+	}{
+		{
+			name:     "commit without message",
+			tool:     git.GitCommitTool(),
+			input:    map[string]interface{}{"message": "  "},
+			expected: "message is required",
+		},
+		{
+			name:     "commit with all and paths",
+			tool:     git.GitCommitTool(),
+			input:    map[string]interface{}{"message": "m", "all": true, "paths": []string{"a"}},
+			expected: "all and paths cannot be combined",
+		},
+		{
+			name:     "push without remote",
+			tool:     git.GitPushTool(),
+			input:    map[string]interface{}{"branch": "main"},
+			expected: "remote is required",
+		},
+		{
+			name:     "push without branch",
+			tool:     git.GitPushTool(),
+			input:    map[string]interface{}{"remote": "origin"},
+			expected: "branch is required",
+		},
+		{
+			name:     "push branch looking like an option",
+			tool:     git.GitPushTool(),
+			input:    map[string]interface{}{"remote": "origin", "branch": "--mirror"},
+			expected: `invalid branch: "--mirror"`,
+		},
+		{
+			name:     "push forcing refspec",
+			tool:     git.GitPushTool(),
+			input:    map[string]interface{}{"remote": "origin", "branch": "+main"},
+			expected: `invalid branch: "+main"`,
+		},
+		{
+			name:     "push mapping refspec",
+			tool:     git.GitPushTool(),
+			input:    map[string]interface{}{"remote": "origin", "branch": "+src:dst"},
+			expected: `invalid branch: "+src:dst"`,
+		},
+		{
+			name:     "push deleting refspec",
+			tool:     git.GitPushTool(),
+			input:    map[string]interface{}{"remote": "origin", "branch": ":main"},
+			expected: `invalid branch: ":main"`,
+		},
+		{
+			name:     "push remote url",
+			tool:     git.GitPushTool(),
+			input:    map[string]interface{}{"remote": "ssh://attacker.example.com/repo", "branch": "main"},
+			expected: `invalid remote: "ssh://attacker.example.com/repo"`,
+		},
+		{
+			name:     "pull forcing refspec",
+			tool:     git.GitPullTool(),
+			input:    map[string]interface{}{"remote": "origin", "branch": "+main:main"},
+			expected: `invalid branch: "+main:main"`,
+		},
+		{
+			name:     "pull remote looking like an option",
+			tool:     git.GitPullTool(),
+			input:    map[string]interface{}{"remote": "--upload-pack=touch pwned"},
+			expected: `invalid remote: "--upload-pack=touch pwned"`,
+		},
+		{
+			name:     "pull branch without remote",
+			tool:     git.GitPullTool(),
+			input:    map[string]interface{}{"branch": "main"},
+			expected: "branch requires remote",
+		},
+		{
+			name:     "pull with rebase and ff_only",
+			tool:     git.GitPullTool(),
+			input:    map[string]interface{}{"rebase": true, "ff_only": true},
+			expected: "rebase and ff_only cannot be combined",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := callGitCommandTool(t, tt.tool, tt.input)
+			assert.True(t, result.IsError)
+			assert.Equal(t, tt.expected, result.Content[0].Text)
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/shaharia-lab/goai"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// conflictOperationRefs maps the pseudo-refs git writes while an operation is stopped on
+// conflicts to the name of that operation
+var conflictOperationRefs = []struct {
+	ref       string
+	operation string
+}{
+	{ref: "MERGE_HEAD", operation: "merge"},
+	{ref: "REBASE_HEAD", operation: "rebase"},
+	{ref: "CHERRY_PICK_HEAD", operation: "cherry-pick"},
+	{ref: "REVERT_HEAD", operation: "revert"},
+}
+
+// conflictFailure is a file the strategy could not be applied to
+type conflictFailure struct {
+	Path  string `json:"path"`
+	Error string `json:"error"`
+}
+
+// conflictResolution reports the outcome of resolving conflicts by strategy
+type conflictResolution struct {
+	Operation string            `json:"operation"`
+	Strategy  string            `json:"strategy"`
+	Resolved  []string          `json:"resolved"`
+	Failed    []conflictFailure `json:"failed,omitempty"`
+	Skipped   []string          `json:"skipped,omitempty"`
+	Remaining []string          `json:"remaining"`
+}
+
+// GitResolveConflictsTool returns a goai.Tool that resolves conflicted files of an in-progress
+// merge, rebase, cherry-pick or revert by taking one side wholesale, then stages them.
+func (g *Git) GitResolveConflictsTool() goai.Tool {
+	return goai.Tool{
+		Name:        GitConflictsToolName,
+		Description: "Resolves conflicted files of an in-progress merge or rebase by taking 'ours' or 'theirs' and staging them, reporting the conflicts that remain",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"repo_path": {
+					"type": "string",
+					"description": "Path to Git repository"
+				},
+				"strategy": {
+					"type": "string",
+					"enum": ["ours", "theirs"],
+					"description": "Side to keep. During a rebase 'ours' is the branch being rebased onto and 'theirs' the commit being replayed"
+				},
+				"paths": {
+					"type": "array",
+					"items": {"type": "string"},
+					"description": "Conflicted files to resolve (defaults to all conflicted files)"
+				}
+			},
+			"required": ["repo_path", "strategy"]
+		}`),
+		Handler: func(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
+			ctx, span := goai.StartSpan(ctx, fmt.Sprintf("%s.Handler", params.Name))
+			span.SetAttributes(
+				attribute.String("tool_name", params.Name),
+				attribute.String("tool_argument", string(params.Arguments)),
+			)
+			defer span.End()
+
+			g.logger.WithFields(map[string]interface{}{
+				"tool_name": params.Name,
+				"arguments": string(params.Arguments),
+			}).Info("Received input")
+
+			var input struct {
+				RepoPath string   `json:"repo_path"`
+				Strategy string   `json:"strategy"`
+				Paths    []string `json:"paths"`
+			}
+
+			if err := json.Unmarshal(params.Arguments, &input); err != nil {
+				span.RecordError(err)
+				return goai.CallToolResult{}, fmt.Errorf("failed to unmarshal input: %w", err)
+			}
+
+			repoPath, err := g.resolveRepoPath(input.RepoPath)
+			if err != nil {
+				return returnErrorOutput(err), nil
+			}
+			input.RepoPath = repoPath
+
+			if input.Strategy != "ours" && input.Strategy != "theirs" {
+				return returnErrorOutput(fmt.Errorf("strategy must be 'ours' or 'theirs', got %q", input.Strategy)), nil
+			}
+
+			resolution, err := g.resolveConflicts(ctx, input.RepoPath, input.Strategy, input.Paths)
+			if err != nil {
+				g.logger.WithFields(map[string]interface{}{
+					goai.ErrorLogField: err,
+				}).Error("Git conflict resolution failed")
+
+				span.RecordError(err)
+				return returnErrorOutput(err), nil
+			}
+
+			g.logger.WithFields(map[string]interface{}{
+				"tool":      GitConflictsToolName,
+				"strategy":  input.Strategy,
+				"resolved":  len(resolution.Resolved),
+				"remaining": len(resolution.Remaining),
+			}).Info("Git conflicts resolved")
+
+			text, err := marshalResult(resolution)
+			if err != nil {
+				return returnErrorOutput(err), nil
+			}
+
+			return goai.CallToolResult{
+				Content: []goai.ToolResultContent{{
+					Type: "json",
+					Text: text,
+				}},
+			}, nil
+		},
+	}
+}
+
+// resolveConflicts applies strategy to the requested conflicted paths, or to all of them when
+// paths is empty. Requested paths that are not conflicted are skipped.
+func (g *Git) resolveConflicts(ctx context.Context, repoPath, strategy string, paths []string) (*conflictResolution, error) {
+	operation, err := g.conflictOperation(ctx, repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	conflicted, err := g.conflictedFiles(ctx, repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	resolution := &conflictResolution{Operation: operation, Strategy: strategy, Resolved: []string{}}
+
+	targets := conflicted
+	if len(paths) > 0 {
+		isConflicted := map[string]bool{}
+		for _, path := range conflicted {
+			isConflicted[path] = true
+		}
+		targets = nil
+		for _, path := range paths {
+			if isConflicted[path] {
+				targets = append(targets, path)
+			} else {
+				resolution.Skipped = append(resolution.Skipped, path)
+			}
+		}
+	}
+
+	for _, path := range targets {
+		if output, err := g.runGit(ctx, repoPath, "checkout", "--"+strategy, "--", path); err != nil {
+			resolution.Failed = append(resolution.Failed, conflictFailure{Path: path, Error: strings.TrimSpace(string(output))})
+			continue
+		}
+		if output, err := g.runGit(ctx, repoPath, "add", "--", path); err != nil {
+			resolution.Failed = append(resolution.Failed, conflictFailure{Path: path, Error: strings.TrimSpace(string(output))})
+			continue
+		}
+		resolution.Resolved = append(resolution.Resolved, path)
+	}
+
+	if resolution.Remaining, err = g.conflictedFiles(ctx, repoPath); err != nil {
+		return nil, err
+	}
+	return resolution, nil
+}
+
+// conflictOperation returns the operation the repository is stopped in, or an error when no
+// merge, rebase, cherry-pick or revert is in progress
+func (g *Git) conflictOperation(ctx context.Context, repoPath string) (string, error) {
+	for _, candidate := range conflictOperationRefs {
+		if _, err := g.runGit(ctx, repoPath, "rev-parse", "-q", "--verify", candidate.ref); err == nil {
+			return candidate.operation, nil
+		}
+	}
+	return "", fmt.Errorf("repository %s is not in the middle of a merge, rebase, cherry-pick or revert", repoPath)
+}
+
+// conflictedFiles returns the paths with unresolved conflicts
+func (g *Git) conflictedFiles(ctx context.Context, repoPath string) ([]string, error) {
+	output, err := g.runGit(ctx, repoPath, "diff", "--name-only", "--diff-filter=U", "-z")
+	if err != nil {
+		return nil, gitOutputError(err, output)
+	}
+
+	files := []string{}
+	for _, path := range strings.Split(string(output), "\x00") {
+		if path != "" {
+			files = append(files, path)
+		}
+	}
+	return files, nil
+}
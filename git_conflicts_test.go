@@ -0,0 +1,95 @@
+package mcptools
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// initConflictedRepo returns a test repository stopped in a merge with conflicts in a.txt, b.txt and c.txt
+func initConflictedRepo(t *testing.T) string {
+	t.Helper()
+	repoPath := initTestRepo(t)
+
+	writeFiles := func(content string) {
+		for _, name := range []string{"a.txt", "b.txt", "c.txt"} {
+			require.NoError(t, os.WriteFile(filepath.Join(repoPath, name), []byte(name+" "+content+"\n"), 0644))
+		}
+	}
+
+	writeFiles("base")
+	runTestGit(t, repoPath, "add", ".")
+	runTestGit(t, repoPath, "commit", "-m", "Add files")
+
+	runTestGit(t, repoPath, "checkout", "-b", "feature")
+	writeFiles("feature")
+	runTestGit(t, repoPath, "commit", "-am", "Feature changes")
+
+	runTestGit(t, repoPath, "checkout", "main")
+	writeFiles("main")
+	runTestGit(t, repoPath, "commit", "-am", "Main changes")
+
+	// The merge exits non-zero because of the conflicts
+	cmd := exec.Command("git", "merge", "feature")
+	cmd.Dir = repoPath
+	require.Error(t, cmd.Run())
+
+	return repoPath
+}
+
+func TestGit_GitResolveConflictsTool_TheirsSubset(t *testing.T) {
+	repoPath := initConflictedRepo(t)
+
+	result := callGitCommandTool(t, NewGit(newPermissiveLogger(), GitConfig{}).GitResolveConflictsTool(), map[string]interface{}{
+		"repo_path": repoPath,
+		"strategy":  "theirs",
+		"paths":     []string{"a.txt", "b.txt", "test.txt"},
+	})
+	require.False(t, result.IsError, result.Content)
+
+	var resolution conflictResolution
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &resolution))
+	assert.Equal(t, "merge", resolution.Operation)
+	assert.Equal(t, []string{"a.txt", "b.txt"}, resolution.Resolved)
+	assert.Equal(t, []string{"test.txt"}, resolution.Skipped)
+	assert.Equal(t, []string{"c.txt"}, resolution.Remaining)
+	assert.Empty(t, resolution.Failed)
+
+	content, err := os.ReadFile(filepath.Join(repoPath, "a.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "a.txt feature\n", string(content))
+}
+
+func TestGit_GitResolveConflictsTool_OursAll(t *testing.T) {
+	repoPath := initConflictedRepo(t)
+
+	result := callGitCommandTool(t, NewGit(newPermissiveLogger(), GitConfig{}).GitResolveConflictsTool(), map[string]interface{}{"repo_path": repoPath, "strategy": "ours"})
+	require.False(t, result.IsError, result.Content)
+
+	var resolution conflictResolution
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &resolution))
+	assert.Equal(t, []string{"a.txt", "b.txt", "c.txt"}, resolution.Resolved)
+	assert.Empty(t, resolution.Remaining)
+
+	content, err := os.ReadFile(filepath.Join(repoPath, "c.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "c.txt main\n", string(content))
+}
+
+func TestGit_GitResolveConflictsTool_NotInProgress(t *testing.T) {
+	repoPath := initTestRepo(t)
+
+	result := callGitCommandTool(t, NewGit(newPermissiveLogger(), GitConfig{}).GitResolveConflictsTool(), map[string]interface{}{"repo_path": repoPath, "strategy": "theirs"})
+	assert.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].Text, "is not in the middle of a merge")
+}
+
+func TestGit_GitResolveConflictsTool_InvalidStrategy(t *testing.T) {
+	result := callGitCommandTool(t, NewGit(newPermissiveLogger(), GitConfig{}).GitResolveConflictsTool(), map[string]interface{}{"repo_path": t.TempDir(), "strategy": "union"})
+	assert.True(t, result.IsError)
+}
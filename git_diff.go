@@ -0,0 +1,385 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/shaharia-lab/goai"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// gitDiff is the structured diff reported by the diff tool
+type gitDiff struct {
+	FilesChanged int           `json:"files_changed"`
+	Added        int           `json:"added"`
+	Removed      int           `json:"removed"`
+	Files        []gitDiffFile `json:"files"`
+	// Truncated is set when the patch exceeded MaxOutputBytes, so only the first files have hunks
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// gitDiffFile is a file changed by a diff. Added and Removed are zero for binary files.
+type gitDiffFile struct {
+	Path     string   `json:"path"`
+	OrigPath string   `json:"orig_path,omitempty"`
+	Status   string   `json:"status"`
+	Added    int      `json:"added"`
+	Removed  int      `json:"removed"`
+	Binary   bool     `json:"binary,omitempty"`
+	Hunks    []string `json:"hunks,omitempty"`
+}
+
+// diffQuery describes the changes compared by the diff tool
+type diffQuery struct {
+	Base   string
+	Head   string
+	Staged bool
+	Paths  []string
+}
+
+// GitDiffTool returns a goai.Tool that summarizes a diff as JSON: the change type and added and
+// removed line counts of every file, and optionally its hunks. The raw unified patch is still
+// available for when the full diff is needed. The patch, raw or split into hunks, is capped at
+// MaxOutputBytes.
+func (g *Git) GitDiffTool() goai.Tool {
+	return goai.Tool{
+		Name:        GitDiffToolName,
+		Description: "Summarizes a git diff as JSON with the change type and added/removed lines of every file, optionally with hunks or as the raw patch",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"repo_path": {
+					"type": "string",
+					"description": "Path to Git repository (defaults to the configured default repository path)"
+				},
+				"base": {
+					"type": "string",
+					"description": "Revision to compare from. Omit to compare the working tree with the index, or with staged the index with HEAD"
+				},
+				"head": {
+					"type": "string",
+					"description": "Revision to compare to. Omit to compare base with the working tree, or with staged with the index"
+				},
+				"staged": {
+					"type": "boolean",
+					"description": "Compare the index instead of the working tree (git diff --cached)"
+				},
+				"paths": {
+					"type": "array",
+					"items": {"type": "string"},
+					"description": "Limit the diff to these paths"
+				},
+				"include_hunks": {
+					"type": "boolean",
+					"description": "Include the hunks of every file in the summary"
+				},
+				"raw": {
+					"type": "boolean",
+					"description": "Return the raw unified patch as text instead of the summary"
+				}
+			}
+		}`),
+		Handler: func(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
+			ctx, span := goai.StartSpan(ctx, fmt.Sprintf("%s.Handler", params.Name))
+			span.SetAttributes(
+				attribute.String("tool_name", params.Name),
+				attribute.String("tool_argument", string(params.Arguments)),
+			)
+			defer span.End()
+
+			g.logger.WithFields(map[string]interface{}{
+				"tool_name": params.Name,
+				"arguments": string(params.Arguments),
+			}).Info("Received input")
+
+			var input struct {
+				RepoPath     string   `json:"repo_path"`
+				Base         string   `json:"base"`
+				Head         string   `json:"head"`
+				Staged       bool     `json:"staged"`
+				Paths        []string `json:"paths"`
+				IncludeHunks bool     `json:"include_hunks"`
+				Raw          bool     `json:"raw"`
+			}
+
+			if err := json.Unmarshal(params.Arguments, &input); err != nil {
+				span.RecordError(err)
+				return goai.CallToolResult{}, fmt.Errorf("failed to unmarshal input: %w", err)
+			}
+
+			repoPath, err := g.resolveRepoPath(input.RepoPath)
+			if err != nil {
+				return returnErrorOutput(err), nil
+			}
+			for _, rev := range []string{input.Base, input.Head} {
+				if strings.HasPrefix(rev, "-") {
+					return returnErrorOutput(fmt.Errorf("invalid revision: %q", rev)), nil
+				}
+			}
+			if input.Head != "" && input.Base == "" {
+				return returnErrorOutput(fmt.Errorf("base is required when head is set")), nil
+			}
+			if input.Head != "" && input.Staged {
+				return returnErrorOutput(fmt.Errorf("staged cannot be combined with head")), nil
+			}
+
+			query := diffQuery{Base: input.Base, Head: input.Head, Staged: input.Staged, Paths: input.Paths}
+			fail := func(err error, output []byte) error {
+				err = gitOutputError(err, output)
+				g.logger.WithFields(map[string]interface{}{
+					goai.ErrorLogField: err,
+					"repo_path":        repoPath,
+				}).Error("Git diff failed")
+
+				span.RecordError(err)
+				return err
+			}
+			run := func(format ...string) (string, error) {
+				output, err := g.runGit(ctx, repoPath, diffArgs(query, format...)...)
+				if err != nil {
+					return "", fail(err, output)
+				}
+				return string(output), nil
+			}
+
+			if input.Raw {
+				patch, err := g.runGitCapped(ctx, nil, append([]string{"-C", repoPath}, diffArgs(query)...)...)
+				if err != nil {
+					return returnErrorOutput(fail(err, []byte(patch))), nil
+				}
+
+				g.logger.WithFields(map[string]interface{}{
+					"tool":          GitDiffToolName,
+					"repo_path":     repoPath,
+					"output_length": len(patch),
+				}).Info("Git diff completed successfully")
+
+				return goai.CallToolResult{
+					Content: []goai.ToolResultContent{{
+						Type: "text",
+						Text: patch,
+					}},
+				}, nil
+			}
+
+			nameStatus, err := run("--name-status", "-z")
+			if err != nil {
+				return returnErrorOutput(err), nil
+			}
+			numstat, err := run("--numstat", "-z")
+			if err != nil {
+				return returnErrorOutput(err), nil
+			}
+
+			diff, err := parseGitDiff(nameStatus, numstat)
+			if err != nil {
+				span.RecordError(err)
+				return returnErrorOutput(err), nil
+			}
+
+			if input.IncludeHunks {
+				patch, truncated, err := g.runGitBounded(ctx, repoPath, diffArgs(query)...)
+				if err != nil {
+					return returnErrorOutput(fail(err, patch)), nil
+				}
+
+				// Every format lists the files of a diff in the same order, so the sections of
+				// the patch line up with the files. A patch cut at MaxOutputBytes ends with a
+				// partial section, which is dropped along with the files after it.
+				sections := parsePatchHunks(string(patch))
+				if truncated {
+					sections = sections[:max(len(sections)-1, 0)]
+					diff.Truncated = true
+				}
+				if len(sections) == len(diff.Files) || truncated && len(sections) < len(diff.Files) {
+					for i := range sections {
+						diff.Files[i].Hunks = sections[i]
+					}
+				}
+			}
+
+			m, err := marshalResult(diff)
+			if err != nil {
+				return returnErrorOutput(err), nil
+			}
+			g.logger.WithFields(map[string]interface{}{
+				"tool":          GitDiffToolName,
+				"repo_path":     repoPath,
+				"files_changed": diff.FilesChanged,
+				"output_length": len(m),
+			}).Info("Git diff completed successfully")
+
+			return goai.CallToolResult{
+				Content: []goai.ToolResultContent{{
+					Type: "json",
+					Text: m,
+				}},
+			}, nil
+		},
+	}
+}
+
+// diffArgs builds the git diff arguments of a query with the given output format, or a
+// unified patch when there is none. Renames are detected, and paths always follow "--".
+func diffArgs(q diffQuery, format ...string) []string {
+	args := append([]string{"diff", "--no-color", "--no-ext-diff", "-M"}, format...)
+	if q.Staged {
+		args = append(args, "--cached")
+	}
+	if q.Base != "" {
+		args = append(args, q.Base)
+	}
+	if q.Head != "" {
+		args = append(args, q.Head)
+	}
+	args = append(args, "--")
+	return append(args, q.Paths...)
+}
+
+// parseGitDiff combines the NUL-separated output of "git diff --name-status -z" and
+// "git diff --numstat -z" for the same diff into its summary
+func parseGitDiff(nameStatus, numstat string) (*gitDiff, error) {
+	diff := &gitDiff{Files: []gitDiffFile{}}
+
+	entries := strings.Split(nameStatus, "\x00")
+	for i := 0; i < len(entries); i++ {
+		code := entries[i]
+		if code == "" {
+			continue
+		}
+
+		// Renames and copies, such as R086, are followed by the original and the new path,
+		// every other change by its path
+		file := gitDiffFile{Status: diffStatusName(code[0])}
+		if code[0] == 'R' || code[0] == 'C' {
+			if i+2 >= len(entries) {
+				return nil, fmt.Errorf("unexpected git diff entry: %q", code)
+			}
+			file.OrigPath, file.Path = entries[i+1], entries[i+2]
+			i += 2
+		} else {
+			if i+1 >= len(entries) {
+				return nil, fmt.Errorf("unexpected git diff entry: %q", code)
+			}
+			file.Path = entries[i+1]
+			i++
+		}
+		diff.Files = append(diff.Files, file)
+	}
+
+	counts, err := parseDiffNumstat(numstat)
+	if err != nil {
+		return nil, err
+	}
+	for i := range diff.Files {
+		file := &diff.Files[i]
+		count, ok := counts[file.Path]
+		if !ok {
+			continue
+		}
+		file.Added, file.Removed, file.Binary = count.added, count.removed, count.binary
+		diff.Added += count.added
+		diff.Removed += count.removed
+	}
+	diff.FilesChanged = len(diff.Files)
+
+	return diff, nil
+}
+
+// diffLineCount is the numstat entry of a file
+type diffLineCount struct {
+	added   int
+	removed int
+	binary  bool
+}
+
+// parseDiffNumstat parses the NUL-separated output of "git diff --numstat -z" by path. Renamed
+// files are listed as "added\tremoved\t" followed by the original and the new path, other files
+// as "added\tremoved\tpath"; binary files have "-" counts.
+func parseDiffNumstat(output string) (map[string]diffLineCount, error) {
+	counts := map[string]diffLineCount{}
+
+	entries := strings.Split(output, "\x00")
+	for i := 0; i < len(entries); i++ {
+		entry := entries[i]
+		if entry == "" {
+			continue
+		}
+
+		fields := strings.SplitN(entry, "\t", 3)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("unexpected git diff numstat entry: %q", entry)
+		}
+
+		path := fields[2]
+		if path == "" {
+			if i+2 >= len(entries) {
+				return nil, fmt.Errorf("unexpected git diff numstat entry: %q", entry)
+			}
+			path = entries[i+2]
+			i += 2
+		}
+
+		if fields[0] == "-" && fields[1] == "-" {
+			counts[path] = diffLineCount{binary: true}
+			continue
+		}
+		added, errAdded := strconv.Atoi(fields[0])
+		removed, errRemoved := strconv.Atoi(fields[1])
+		if errAdded != nil || errRemoved != nil {
+			return nil, fmt.Errorf("unexpected git diff numstat entry: %q", entry)
+		}
+		counts[path] = diffLineCount{added: added, removed: removed}
+	}
+
+	return counts, nil
+}
+
+// parsePatchHunks splits a unified patch into the hunks of each file section, in order. Every
+// hunk is its "@@" header followed by its lines; sections without hunks, such as those of
+// binary files or mode changes, have none.
+func parsePatchHunks(patch string) [][]string {
+	var sections [][]string
+	var hunk *strings.Builder
+
+	flush := func() {
+		if hunk != nil {
+			last := len(sections) - 1
+			sections[last] = append(sections[last], hunk.String())
+			hunk = nil
+		}
+	}
+
+	for _, line := range strings.SplitAfter(patch, "\n") {
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			flush()
+			sections = append(sections, nil)
+		case len(sections) == 0:
+			continue
+		case strings.HasPrefix(line, "@@"):
+			flush()
+			hunk = &strings.Builder{}
+			hunk.WriteString(line)
+		case hunk != nil:
+			hunk.WriteString(line)
+		}
+	}
+	flush()
+
+	return sections
+}
+
+// diffStatusName returns the name of a git diff status letter
+func diffStatusName(code byte) string {
+	if name, ok := statusCodes[code]; ok {
+		return name
+	}
+	if code == 'U' {
+		return "unmerged"
+	}
+	return string(code)
+}
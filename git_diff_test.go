@@ -0,0 +1,262 @@
+package mcptools
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// initTestRepoWithChanges returns a repository whose working tree modifies, adds, deletes and
+// renames files relative to HEAD, with every change staged
+func initTestRepoWithChanges(t *testing.T) string {
+	t.Helper()
+	repoPath := initTestRepo(t)
+
+	writeFile := func(name, content string) {
+		require.NoError(t, os.WriteFile(filepath.Join(repoPath, name), []byte(content), 0644))
+	}
+	writeFile("remove.txt", "going away\n")
+	writeFile("old name.txt", "line one\nline two\nline three\nline four\n")
+	runTestGit(t, repoPath, "add", ".")
+	runTestGit(t, repoPath, "commit", "-m", "Add files")
+
+	writeFile("test.txt", "changed content\nand more\n")
+	writeFile("added.txt", "new file\n")
+	require.NoError(t, os.Remove(filepath.Join(repoPath, "remove.txt")))
+	runTestGit(t, repoPath, "mv", "old name.txt", "new name.txt")
+	runTestGit(t, repoPath, "add", "-A")
+
+	return repoPath
+}
+
+func TestDiffArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    diffQuery
+		format   []string
+		expected []string
+	}{
+		{
+			name:     "working tree patch",
+			query:    diffQuery{},
+			expected: []string{"diff", "--no-color", "--no-ext-diff", "-M", "--"},
+		},
+		{
+			name:     "staged numstat of paths",
+			query:    diffQuery{Staged: true, Paths: []string{"pkg/"}},
+			format:   []string{"--numstat", "-z"},
+			expected: []string{"diff", "--no-color", "--no-ext-diff", "-M", "--numstat", "-z", "--cached", "--", "pkg/"},
+		},
+		{
+			name:     "revisions",
+			query:    diffQuery{Base: "v1.0.0", Head: "main"},
+			format:   []string{"--name-status", "-z"},
+			expected: []string{"diff", "--no-color", "--no-ext-diff", "-M", "--name-status", "-z", "v1.0.0", "main", "--"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, diffArgs(tt.query, tt.format...))
+		})
+	}
+}
+
+func TestParseGitDiff(t *testing.T) {
+	nameStatus := "M\x00main.go\x00R086\x00old.go\x00new.go\x00A\x00logo.png\x00D\x00gone.txt\x00"
+	numstat := "3\t1\tmain.go\x002\t2\t\x00old.go\x00new.go\x00-\t-\tlogo.png\x000\t4\tgone.txt\x00"
+
+	diff, err := parseGitDiff(nameStatus, numstat)
+	require.NoError(t, err)
+	assert.Equal(t, &gitDiff{
+		FilesChanged: 4,
+		Added:        5,
+		Removed:      7,
+		Files: []gitDiffFile{
+			{Path: "main.go", Status: "modified", Added: 3, Removed: 1},
+			{Path: "new.go", OrigPath: "old.go", Status: "renamed", Added: 2, Removed: 2},
+			{Path: "logo.png", Status: "added", Binary: true},
+			{Path: "gone.txt", Status: "deleted", Removed: 4},
+		},
+	}, diff)
+
+	empty, err := parseGitDiff("", "")
+	require.NoError(t, err)
+	assert.Equal(t, &gitDiff{Files: []gitDiffFile{}}, empty)
+
+	_, err = parseGitDiff("M\x00main.go\x00", "bogus\x00")
+	assert.EqualError(t, err, `unexpected git diff numstat entry: "bogus"`)
+}
+
+func TestParsePatchHunks(t *testing.T) {
+	patch := "diff --git a/a.txt b/a.txt\n" +
+		"index 1111111..2222222 100644\n" +
+		"--- a/a.txt\n" +
+		"+++ b/a.txt\n" +
+		"@@ -1 +1 @@\n" +
+		"-old\n" +
+		"+new\n" +
+		"@@ -10,2 +10,2 @@ func main() {\n" +
+		" context\n" +
+		"-x\n" +
+		"+y\n" +
+		"diff --git a/logo.png b/logo.png\n" +
+		"Binary files a/logo.png and b/logo.png differ\n"
+
+	assert.Equal(t, [][]string{
+		{"@@ -1 +1 @@\n-old\n+new\n", "@@ -10,2 +10,2 @@ func main() {\n context\n-x\n+y\n"},
+		nil,
+	}, parsePatchHunks(patch))
+	assert.Empty(t, parsePatchHunks(""))
+}
+
+func TestGit_GitDiffTool(t *testing.T) {
+	repoPath := initTestRepoWithChanges(t)
+
+	result := callGitCommandTool(t, NewGit(newPermissiveLogger(), GitConfig{}).GitDiffTool(), map[string]interface{}{"repo_path": repoPath, "staged": true})
+	require.False(t, result.IsError, result.Content)
+	assert.Equal(t, "json", result.Content[0].Type)
+
+	var diff gitDiff
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &diff))
+	assert.Equal(t, gitDiff{
+		FilesChanged: 4,
+		Added:        3,
+		Removed:      2,
+		Files: []gitDiffFile{
+			{Path: "added.txt", Status: "added", Added: 1},
+			{Path: "new name.txt", OrigPath: "old name.txt", Status: "renamed"},
+			{Path: "remove.txt", Status: "deleted", Removed: 1},
+			{Path: "test.txt", Status: "modified", Added: 2, Removed: 1},
+		},
+	}, diff)
+
+	// Nothing is left unstaged
+	result = callGitCommandTool(t, NewGit(newPermissiveLogger(), GitConfig{}).GitDiffTool(), map[string]interface{}{"repo_path": repoPath})
+	require.False(t, result.IsError, result.Content)
+	assert.JSONEq(t, `{"files_changed": 0, "added": 0, "removed": 0, "files": []}`, result.Content[0].Text)
+}
+
+func TestGit_GitDiffTool_Revisions(t *testing.T) {
+	repoPath := initTestRepoWithChanges(t)
+	runTestGit(t, repoPath, "commit", "-m", "Change files")
+
+	result := callGitCommandTool(t, NewGit(newPermissiveLogger(), GitConfig{}).GitDiffTool(), map[string]interface{}{
+		"repo_path": repoPath,
+		"base":      "HEAD~1",
+		"head":      "HEAD",
+		"paths":     []string{"test.txt"},
+	})
+	require.False(t, result.IsError, result.Content)
+
+	var diff gitDiff
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &diff))
+	assert.Equal(t, []gitDiffFile{{Path: "test.txt", Status: "modified", Added: 2, Removed: 1}}, diff.Files)
+}
+
+func TestGit_GitDiffTool_Hunks(t *testing.T) {
+	repoPath := initTestRepoWithChanges(t)
+
+	result := callGitCommandTool(t, NewGit(newPermissiveLogger(), GitConfig{}).GitDiffTool(), map[string]interface{}{
+		"repo_path":     repoPath,
+		"staged":        true,
+		"include_hunks": true,
+	})
+	require.False(t, result.IsError, result.Content)
+
+	var diff gitDiff
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &diff))
+	require.Len(t, diff.Files, 4)
+	assert.Equal(t, []string{"@@ -0,0 +1 @@\n+new file\n"}, diff.Files[0].Hunks)
+	assert.Empty(t, diff.Files[1].Hunks)
+	assert.Equal(t, []string{"@@ -1 +0,0 @@\n-going away\n"}, diff.Files[2].Hunks)
+	assert.Equal(t, []string{"@@ -1 +1,2 @@\n-test content\n+changed content\n+and more\n"}, diff.Files[3].Hunks)
+}
+
+func TestGit_GitDiffTool_Raw(t *testing.T) {
+	repoPath := initTestRepoWithChanges(t)
+
+	result := callGitCommandTool(t, NewGit(newPermissiveLogger(), GitConfig{}).GitDiffTool(), map[string]interface{}{
+		"repo_path": repoPath,
+		"staged":    true,
+		"raw":       true,
+		"paths":     []string{"test.txt"},
+	})
+	require.False(t, result.IsError, result.Content)
+	assert.Equal(t, "text", result.Content[0].Type)
+	assert.True(t, strings.HasPrefix(result.Content[0].Text, "diff --git a/test.txt b/test.txt\n"), result.Content[0].Text)
+	assert.Contains(t, result.Content[0].Text, "+changed content\n")
+}
+
+func TestGit_GitDiffTool_MaxOutputBytes(t *testing.T) {
+	repoPath := initTestRepoWithChanges(t)
+	git := NewGit(newPermissiveLogger(), GitConfig{MaxOutputBytes: 200})
+
+	result := callGitCommandTool(t, git.GitDiffTool(), map[string]interface{}{
+		"repo_path": repoPath,
+		"staged":    true,
+		"raw":       true,
+	})
+	require.False(t, result.IsError, result.Content)
+	assert.True(t, strings.HasPrefix(result.Content[0].Text, "diff --git a/added.txt b/added.txt\n"), result.Content[0].Text)
+	assert.Regexp(t, `\n\.\.\. output truncated \(\d+ bytes total\)$`, result.Content[0].Text)
+	assert.LessOrEqual(t, len(result.Content[0].Text), 200+len("\n... output truncated (1000 bytes total)"))
+
+	result = callGitCommandTool(t, git.GitDiffTool(), map[string]interface{}{
+		"repo_path":     repoPath,
+		"staged":        true,
+		"include_hunks": true,
+	})
+	require.False(t, result.IsError, result.Content)
+
+	var diff gitDiff
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &diff))
+	require.Len(t, diff.Files, 4, "the summary lists every file")
+	assert.True(t, diff.Truncated)
+	assert.Equal(t, []string{"@@ -0,0 +1 @@\n+new file\n"}, diff.Files[0].Hunks)
+	assert.Empty(t, diff.Files[2].Hunks, "files after the cut have no hunks")
+	assert.Empty(t, diff.Files[3].Hunks, "files after the cut have no hunks")
+}
+
+func TestGit_GitDiffTool_InvalidInput(t *testing.T) {
+	repoPath := initTestRepo(t)
+
+	tests := []struct {
+		name     string
+		input    map[string]interface{}
+		expected string
+	}{
+		{
+			name:     "option as revision",
+			input:    map[string]interface{}{"repo_path": repoPath, "base": "--output=/tmp/x"},
+			expected: `invalid revision: "--output=/tmp/x"`,
+		},
+		{
+			name:     "head without base",
+			input:    map[string]interface{}{"repo_path": repoPath, "head": "main"},
+			expected: "base is required when head is set",
+		},
+		{
+			name:     "staged with head",
+			input:    map[string]interface{}{"repo_path": repoPath, "base": "main", "head": "main", "staged": true},
+			expected: "staged cannot be combined with head",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := callGitCommandTool(t, NewGit(newPermissiveLogger(), GitConfig{}).GitDiffTool(), tt.input)
+			assert.True(t, result.IsError)
+			assert.Equal(t, tt.expected, result.Content[0].Text)
+		})
+	}
+
+	result := callGitCommandTool(t, NewGit(newPermissiveLogger(), GitConfig{}).GitDiffTool(), map[string]interface{}{"repo_path": repoPath, "base": "no-such-revision"})
+	assert.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].Text, "no-such-revision")
+}
@@ -0,0 +1,173 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/shaharia-lab/goai"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// eolEntry is a single parsed line of "git ls-files --eol" output
+type eolEntry struct {
+	Path        string `json:"path"`
+	IndexEOL    string `json:"index_eol"`
+	WorktreeEOL string `json:"worktree_eol"`
+	Attribute   string `json:"attr"`
+	Problem     string `json:"problem,omitempty"`
+}
+
+// eolReport is the result of the EOL diagnostic
+type eolReport struct {
+	AutoCRLF     string     `json:"core_autocrlf"`
+	FilesChecked int        `json:"files_checked"`
+	Issues       []eolEntry `json:"issues"`
+	Entries      []eolEntry `json:"entries,omitempty"`
+}
+
+// GitEOLTool returns a goai.Tool that reports files with mixed or unexpected line endings
+// based on "git ls-files --eol" and the core.autocrlf setting.
+func (g *Git) GitEOLTool() goai.Tool {
+	return goai.Tool{
+		Name:        GitEOLToolName,
+		Description: "Reports files with mixed or unexpected line endings (CRLF/LF) in a Git repository",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"repo_path": {
+					"type": "string",
+					"description": "Path to Git repository"
+				},
+				"paths": {
+					"type": "array",
+					"items": {"type": "string"},
+					"description": "Limit the check to these paths"
+				},
+				"include_all": {
+					"type": "boolean",
+					"description": "Include every checked file in the report, not only the ones with issues"
+				}
+			},
+			"required": ["repo_path"]
+		}`),
+		Handler: func(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
+			ctx, span := goai.StartSpan(ctx, fmt.Sprintf("%s.Handler", params.Name))
+			span.SetAttributes(
+				attribute.String("tool_name", params.Name),
+				attribute.String("tool_argument", string(params.Arguments)),
+			)
+			defer span.End()
+
+			g.logger.WithFields(map[string]interface{}{
+				"tool_name": params.Name,
+				"arguments": string(params.Arguments),
+			}).Info("Received input")
+
+			var input struct {
+				RepoPath   string   `json:"repo_path"`
+				Paths      []string `json:"paths"`
+				IncludeAll bool     `json:"include_all"`
+			}
+
+			if err := json.Unmarshal(params.Arguments, &input); err != nil {
+				span.RecordError(err)
+				return goai.CallToolResult{}, fmt.Errorf("failed to unmarshal input: %w", err)
+			}
+
+			repoPath, err := g.resolveRepoPath(input.RepoPath)
+			if err != nil {
+				return returnErrorOutput(err), nil
+			}
+			input.RepoPath = repoPath
+
+			output, err := g.runGit(ctx, input.RepoPath, append([]string{"ls-files", "--eol", "--"}, input.Paths...)...)
+			if err != nil {
+				g.logger.WithFields(map[string]interface{}{
+					goai.ErrorLogField: err,
+					"output":           string(output),
+				}).Error("Git ls-files --eol failed")
+
+				span.RecordError(err)
+				return returnErrorOutput(gitOutputError(err, output)), nil
+			}
+
+			// "git config --get" exits non-zero when the key is unset, which simply means the default applies
+			autoCRLF, _ := g.runGit(ctx, input.RepoPath, "config", "--get", "core.autocrlf")
+
+			entries := parseEOLOutput(string(output))
+			report := eolReport{
+				AutoCRLF:     strings.TrimSpace(string(autoCRLF)),
+				FilesChecked: len(entries),
+				Issues:       []eolEntry{},
+			}
+			for _, entry := range entries {
+				if entry.Problem != "" {
+					report.Issues = append(report.Issues, entry)
+				}
+			}
+			if input.IncludeAll {
+				report.Entries = entries
+			}
+
+			g.logger.WithFields(map[string]interface{}{
+				"tool":          GitEOLToolName,
+				"files_checked": report.FilesChecked,
+				"issues":        len(report.Issues),
+			}).Info("Git EOL check completed successfully")
+
+			text, err := marshalResult(report)
+			if err != nil {
+				return returnErrorOutput(err), nil
+			}
+
+			return goai.CallToolResult{
+				Content: []goai.ToolResultContent{{
+					Type: "json",
+					Text: text,
+				}},
+			}, nil
+		},
+	}
+}
+
+// parseEOLOutput parses "git ls-files --eol" lines of the form
+// "i/<eol> w/<eol> attr/<attr> \t<path>" and flags entries with line-ending problems.
+func parseEOLOutput(output string) []eolEntry {
+	var entries []eolEntry
+	for _, line := range strings.Split(output, "\n") {
+		info, path, found := strings.Cut(line, "\t")
+		if !found {
+			continue
+		}
+
+		// The attribute column may itself contain spaces (e.g. "text eol=crlf"), so it runs to the tab
+		info, attr, _ := strings.Cut(info, "attr/")
+		entry := eolEntry{Path: path, Attribute: strings.TrimSpace(attr)}
+		for _, field := range strings.Fields(info) {
+			switch {
+			case strings.HasPrefix(field, "i/"):
+				entry.IndexEOL = strings.TrimPrefix(field, "i/")
+			case strings.HasPrefix(field, "w/"):
+				entry.WorktreeEOL = strings.TrimPrefix(field, "w/")
+			}
+		}
+		entry.Problem = eolProblem(entry)
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// eolProblem describes what is wrong with an entry's line endings, or returns "" when nothing is
+func eolProblem(entry eolEntry) string {
+	switch {
+	case entry.IndexEOL == "mixed" || entry.WorktreeEOL == "mixed":
+		return "mixed line endings"
+	case entry.IndexEOL == "crlf":
+		return "CRLF line endings committed to the index"
+	case entry.IndexEOL == "lf" && entry.WorktreeEOL == "crlf" && !strings.Contains(entry.Attribute, "eol=crlf"):
+		return "working tree uses CRLF while the index uses LF"
+	}
+	return ""
+}
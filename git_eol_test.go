@@ -0,0 +1,58 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shaharia-lab/goai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEOLOutput(t *testing.T) {
+	output := "i/lf    w/lf    attr/                 \t.gitattributes\n" +
+		"i/lf    w/crlf  attr/text=auto        \tcrlf.txt\n" +
+		"i/lf    w/crlf  attr/text eol=crlf    \twindows.bat\n" +
+		"i/mixed w/mixed attr/                 \tmixed.txt\n" +
+		"i/crlf  w/crlf  attr/                 \tcommitted.txt\n" +
+		"i/-text w/-text attr/                 \tlogo.png\n"
+
+	entries := parseEOLOutput(output)
+	require.Len(t, entries, 6)
+
+	assert.Equal(t, eolEntry{Path: ".gitattributes", IndexEOL: "lf", WorktreeEOL: "lf"}, entries[0])
+	assert.Equal(t, "text=auto", entries[1].Attribute)
+	assert.Equal(t, "working tree uses CRLF while the index uses LF", entries[1].Problem)
+	assert.Empty(t, entries[2].Problem)
+	assert.Equal(t, "mixed line endings", entries[3].Problem)
+	assert.Equal(t, "CRLF line endings committed to the index", entries[4].Problem)
+	assert.Empty(t, entries[5].Problem)
+}
+
+func TestGit_GitEOLTool(t *testing.T) {
+	repoPath := initTestRepo(t)
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "mixed.txt"), []byte("one\r\ntwo\n"), 0644))
+	runTestGit(t, repoPath, "add", "mixed.txt")
+	runTestGit(t, repoPath, "commit", "-m", "Add mixed endings")
+
+	git := NewGit(newPermissiveLogger(), GitConfig{})
+	tool := git.GitEOLTool()
+
+	args, err := json.Marshal(map[string]interface{}{"repo_path": repoPath, "include_all": true})
+	require.NoError(t, err)
+
+	result, err := tool.Handler(context.Background(), goai.CallToolParams{Name: GitEOLToolName, Arguments: args})
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+
+	var report eolReport
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &report))
+	assert.Equal(t, 2, report.FilesChecked)
+	assert.Len(t, report.Entries, 2)
+	require.Len(t, report.Issues, 1)
+	assert.Equal(t, "mixed.txt", report.Issues[0].Path)
+	assert.Equal(t, "mixed", report.Issues[0].IndexEOL)
+}
@@ -0,0 +1,143 @@
+package mcptools
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/shaharia-lab/goai"
+)
+
+// DefaultGitMaxConcurrentRepos is how many repositories a git tool call with repo_paths runs
+// its command in at once when GitConfig.MaxConcurrentRepos is unset
+const DefaultGitMaxConcurrentRepos = 8
+
+// gitRepoResult is the outcome of the git command in one repository of repo_paths. Command is
+// only set by dry runs, which report the invocation instead of running it.
+type gitRepoResult struct {
+	Output  string `json:"output"`
+	Error   string `json:"error,omitempty"`
+	Command string `json:"command,omitempty"`
+}
+
+// runAcrossRepos runs the git command args in each of repoPaths concurrently, bounded by
+// maxConcurrentRepos, and returns a json map of every path to its result. A repository that
+// fails does not stop the others; the result is only an error when the command failed in
+// every repository. Repositories still waiting for a worker when ctx is cancelled are
+// reported with the cancellation error and not run.
+func (g *Git) runAcrossRepos(ctx context.Context, repoPaths []string, args, env []string, dryRun bool) (goai.CallToolResult, error) {
+	paths := dedupePaths(repoPaths)
+	if len(paths) == 0 {
+		return returnErrorOutput(errors.New("repo_paths must list at least one repository path")), nil
+	}
+	workers := g.maxConcurrentRepos()
+
+	g.logger.WithFields(map[string]interface{}{
+		"tool":    GitToolName,
+		"command": args[0],
+		"repos":   len(paths),
+		"workers": workers,
+	}).Info("Running git command across repositories")
+
+	results := make(map[string]gitRepoResult, len(paths))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, workers)
+
+	for _, repoPath := range paths {
+		wg.Add(1)
+		go func(repoPath string) {
+			defer wg.Done()
+
+			var result gitRepoResult
+			select {
+			case slots <- struct{}{}:
+				result = g.runInRepo(ctx, repoPath, args, env, dryRun)
+				<-slots
+			case <-ctx.Done():
+				result = gitRepoResult{Error: ctx.Err().Error()}
+			}
+
+			mu.Lock()
+			results[repoPath] = result
+			mu.Unlock()
+		}(repoPath)
+	}
+	wg.Wait()
+
+	failed := 0
+	for _, result := range results {
+		if result.Error != "" {
+			failed++
+		}
+	}
+	g.logger.WithFields(map[string]interface{}{
+		"tool":    GitToolName,
+		"command": args[0],
+		"repos":   len(paths),
+		"failed":  failed,
+	}).Info("Git command completed across repositories")
+
+	text, err := marshalResult(results)
+	if err != nil {
+		return returnErrorOutput(err), nil
+	}
+
+	return goai.CallToolResult{
+		Content: []goai.ToolResultContent{{
+			Type: "json",
+			Text: text,
+		}},
+		IsError: failed == len(paths),
+	}, nil
+}
+
+// runInRepo runs the git command args in one repository of repo_paths. The output of a failed
+// command is kept next to the error, since git explains most failures on stderr.
+func (g *Git) runInRepo(ctx context.Context, repoPath string, args, env []string, dryRun bool) gitRepoResult {
+	resolved, err := g.resolveRepoPath(repoPath)
+	if err != nil {
+		return gitRepoResult{Error: err.Error()}
+	}
+
+	gitArgs := append([]string{"-C", resolved}, args...)
+	if dryRun {
+		return gitRepoResult{Command: shellJoin(append([]string{"git"}, gitArgs...))}
+	}
+
+	output, err := g.runGitCapped(ctx, env, gitArgs...)
+	if err != nil {
+		g.logger.WithFields(map[string]interface{}{
+			"tool":             GitToolName,
+			"command":          args[0],
+			"repo_path":        resolved,
+			goai.ErrorLogField: err,
+		}).Warn("Git command failed in repository")
+
+		return gitRepoResult{Output: output, Error: err.Error()}
+	}
+	return gitRepoResult{Output: output}
+}
+
+// maxConcurrentRepos returns the configured MaxConcurrentRepos, or DefaultGitMaxConcurrentRepos
+// when it is not positive
+func (g *Git) maxConcurrentRepos() int {
+	if g.config.MaxConcurrentRepos > 0 {
+		return g.config.MaxConcurrentRepos
+	}
+	return DefaultGitMaxConcurrentRepos
+}
+
+// dedupePaths returns paths without empty and repeated entries, in their original order
+func dedupePaths(paths []string) []string {
+	seen := make(map[string]bool, len(paths))
+	unique := make([]string, 0, len(paths))
+	for _, path := range paths {
+		if path == "" || seen[path] {
+			continue
+		}
+		seen[path] = true
+		unique = append(unique, path)
+	}
+	return unique
+}
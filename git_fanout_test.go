@@ -0,0 +1,173 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shaharia-lab/goai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func callGitFanOut(t *testing.T, ctx context.Context, git *Git, input map[string]interface{}) (goai.CallToolResult, map[string]gitRepoResult) {
+	t.Helper()
+	args, err := json.Marshal(input)
+	require.NoError(t, err)
+
+	result, err := git.GitAllInOneTool().Handler(ctx, goai.CallToolParams{Name: GitToolName, Arguments: args})
+	require.NoError(t, err)
+
+	var results map[string]gitRepoResult
+	if !result.IsError || result.Content[0].Type == "json" {
+		require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &results))
+	}
+	return result, results
+}
+
+func TestGit_GitAllInOneTool_RepoPaths(t *testing.T) {
+	first := initTestRepo(t)
+	second := initTestRepo(t)
+	runTestGit(t, second, "commit", "--allow-empty", "-m", "Second repository")
+	missing := filepath.Join(t.TempDir(), "missing")
+
+	git := NewGit(newPermissiveLogger(), GitConfig{})
+
+	result, results := callGitFanOut(t, context.Background(), git, map[string]interface{}{
+		"command":    "log",
+		"repo_paths": []string{first, second, missing, first},
+		"args":       []string{"-1", "--format=%s"},
+	})
+	require.False(t, result.IsError, result.Content)
+	require.Len(t, results, 3)
+
+	assert.Equal(t, gitRepoResult{Output: "Initial commit\n"}, results[first])
+	assert.Equal(t, gitRepoResult{Output: "Second repository\n"}, results[second])
+	assert.NotEmpty(t, results[missing].Error)
+
+	result, results = callGitFanOut(t, context.Background(), git, map[string]interface{}{
+		"command":    "status",
+		"repo_paths": []string{missing},
+	})
+	assert.True(t, result.IsError, "a command failing in every repository is an error")
+	assert.NotEmpty(t, results[missing].Error)
+}
+
+func TestGit_GitAllInOneTool_RepoPathsConcurrency(t *testing.T) {
+	var mu sync.Mutex
+	running, peak := 0, 0
+
+	mockExecutor := new(MockCommandExecutor)
+	mockExecutor.On("ExecuteCommand", mock.Anything, mock.Anything).Run(func(mock.Arguments) {
+		mu.Lock()
+		running++
+		if running > peak {
+			peak = running
+		}
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+
+		mu.Lock()
+		running--
+		mu.Unlock()
+	}).Return([]byte("ok\n"), nil)
+
+	git := NewGit(newPermissiveLogger(), GitConfig{MaxConcurrentRepos: 2})
+	git.cmdExecutor = mockExecutor
+
+	paths := make([]string, 6)
+	for i := range paths {
+		paths[i] = fmt.Sprintf("/repos/%d", i)
+	}
+
+	result, results := callGitFanOut(t, context.Background(), git, map[string]interface{}{
+		"command":    "fetch",
+		"repo_paths": paths,
+	})
+	require.False(t, result.IsError, result.Content)
+	require.Len(t, results, 6)
+	for _, path := range paths {
+		assert.Equal(t, gitRepoResult{Output: "ok\n"}, results[path])
+	}
+
+	assert.Equal(t, 2, peak)
+	mockExecutor.AssertNumberOfCalls(t, "ExecuteCommand", 6)
+}
+
+func TestGit_GitAllInOneTool_RepoPathsCancelled(t *testing.T) {
+	first := initTestRepo(t)
+	second := initTestRepo(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result, results := callGitFanOut(t, ctx, NewGit(newPermissiveLogger(), GitConfig{}), map[string]interface{}{
+		"command":    "status",
+		"repo_paths": []string{first, second},
+	})
+	assert.True(t, result.IsError)
+	assert.Equal(t, context.Canceled.Error(), results[first].Error)
+	assert.Equal(t, context.Canceled.Error(), results[second].Error)
+}
+
+func TestGit_GitAllInOneTool_RepoPathsDryRun(t *testing.T) {
+	git := NewGit(newPermissiveLogger(), GitConfig{})
+	git.cmdExecutor = new(MockCommandExecutor)
+
+	result, results := callGitFanOut(t, context.Background(), git, map[string]interface{}{
+		"command":    "pull",
+		"repo_paths": []string{"/repos/a", "/repos/my b"},
+		"args":       []string{"--rebase"},
+		"dry_run":    true,
+	})
+	require.False(t, result.IsError, result.Content)
+	assert.Equal(t, map[string]gitRepoResult{
+		"/repos/a":    {Command: "git -C /repos/a pull --rebase"},
+		"/repos/my b": {Command: "git -C '/repos/my b' pull --rebase"},
+	}, results)
+}
+
+func TestGit_GitAllInOneTool_RepoPathsInvalidInput(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   GitConfig
+		input    map[string]interface{}
+		expected string
+	}{
+		{
+			name:     "combined with repo_path",
+			input:    map[string]interface{}{"command": "status", "repo_path": "/repos/a", "repo_paths": []string{"/repos/b"}},
+			expected: "repo_path and repo_paths cannot be combined",
+		},
+		{
+			name:     "only empty paths",
+			input:    map[string]interface{}{"command": "status", "repo_paths": []string{""}},
+			expected: "repo_paths must list at least one repository path",
+		},
+		{
+			name:     "blocked command",
+			config:   GitConfig{BlockedCommands: []string{"push"}},
+			input:    map[string]interface{}{"command": "push", "repo_paths": []string{"/repos/a", "/repos/b"}},
+			expected: `command "push" is blocked by policy`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockExecutor := new(MockCommandExecutor)
+			git := NewGit(newPermissiveLogger(), tt.config)
+			git.cmdExecutor = mockExecutor
+
+			result, _ := callGitFanOut(t, context.Background(), git, tt.input)
+			assert.True(t, result.IsError)
+			assert.Equal(t, tt.expected, result.Content[0].Text)
+			mockExecutor.AssertNotCalled(t, "ExecuteCommand", mock.Anything, mock.Anything)
+		})
+	}
+}
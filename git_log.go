@@ -0,0 +1,215 @@
+package mcptools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/shaharia-lab/goai"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// commitLogFormat prints each commit as NUL-separated sha, author, email, date and subject
+const commitLogFormat = "--format=%H%x00%an%x00%ae%x00%aI%x00%s"
+
+// defaultLogMaxCount caps the number of commits returned by the log tool
+const defaultLogMaxCount = 50
+
+// logCommit is a commit parsed from commitLogFormat output
+type logCommit struct {
+	SHA         string `json:"sha"`
+	Author      string `json:"author"`
+	AuthorEmail string `json:"author_email"`
+	Date        string `json:"date"`
+	Subject     string `json:"subject"`
+}
+
+// logQuery describes the commits listed by the log tool
+type logQuery struct {
+	Ref      string
+	Since    string
+	Until    string
+	Author   string
+	Paths    []string
+	MaxCount int
+}
+
+// GitLogTool returns a goai.Tool that lists commits as JSON, optionally filtered by date,
+// author and paths. At most 50 commits are returned unless max_count says otherwise, and the
+// commits beyond MaxOutputBytes of git log output are left out.
+func (g *Git) GitLogTool() goai.Tool {
+	return goai.Tool{
+		Name:        GitLogToolName,
+		Description: "Lists commits with their hash, author, email, date and subject as JSON, optionally filtered by date range, author and paths",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"repo_path": {
+					"type": "string",
+					"description": "Path to Git repository (defaults to the configured default repository path)"
+				},
+				"ref": {
+					"type": "string",
+					"description": "Revision or range to list (defaults to HEAD)"
+				},
+				"since": {
+					"type": "string",
+					"description": "Only commits more recent than this date, e.g. 2024-01-01 or \"2 weeks ago\" (git log --since)"
+				},
+				"until": {
+					"type": "string",
+					"description": "Only commits older than this date (git log --until)"
+				},
+				"author": {
+					"type": "string",
+					"description": "Only commits whose author matches this pattern (git log --author)"
+				},
+				"paths": {
+					"type": "array",
+					"items": {"type": "string"},
+					"description": "Limit the log to commits touching these paths"
+				},
+				"max_count": {
+					"type": "integer",
+					"description": "Maximum number of commits to return (default 50)"
+				}
+			}
+		}`),
+		Handler: func(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
+			ctx, span := goai.StartSpan(ctx, fmt.Sprintf("%s.Handler", params.Name))
+			span.SetAttributes(
+				attribute.String("tool_name", params.Name),
+				attribute.String("tool_argument", string(params.Arguments)),
+			)
+			defer span.End()
+
+			g.logger.WithFields(map[string]interface{}{
+				"tool_name": params.Name,
+				"arguments": string(params.Arguments),
+			}).Info("Received input")
+
+			var input struct {
+				RepoPath string   `json:"repo_path"`
+				Ref      string   `json:"ref"`
+				Since    string   `json:"since"`
+				Until    string   `json:"until"`
+				Author   string   `json:"author"`
+				Paths    []string `json:"paths"`
+				MaxCount int      `json:"max_count"`
+			}
+
+			if err := json.Unmarshal(params.Arguments, &input); err != nil {
+				span.RecordError(err)
+				return goai.CallToolResult{}, fmt.Errorf("failed to unmarshal input: %w", err)
+			}
+
+			repoPath, err := g.resolveRepoPath(input.RepoPath)
+			if err != nil {
+				return returnErrorOutput(err), nil
+			}
+			if strings.HasPrefix(input.Ref, "-") {
+				return returnErrorOutput(fmt.Errorf("invalid ref: %q", input.Ref)), nil
+			}
+
+			args := logArgs(logQuery{
+				Ref:      input.Ref,
+				Since:    input.Since,
+				Until:    input.Until,
+				Author:   input.Author,
+				Paths:    input.Paths,
+				MaxCount: input.MaxCount,
+			})
+
+			output, truncated, err := g.runGitBounded(ctx, repoPath, args...)
+			if err != nil {
+				g.logger.WithFields(map[string]interface{}{
+					goai.ErrorLogField: err,
+					"output":           string(output),
+				}).Error("Git log failed")
+
+				span.RecordError(err)
+				return returnErrorOutput(gitOutputError(err, output)), nil
+			}
+
+			if truncated {
+				// The last line was cut at MaxOutputBytes
+				if i := bytes.LastIndexByte(output, '\n'); i >= 0 {
+					output = output[:i]
+				} else {
+					output = nil
+				}
+			}
+			commits := parseCommitLog(string(output))
+
+			g.logger.WithFields(map[string]interface{}{
+				"tool":    GitLogToolName,
+				"commits": len(commits),
+			}).Info("Git log completed successfully")
+
+			text, err := marshalResult(commits)
+			if err != nil {
+				return returnErrorOutput(err), nil
+			}
+
+			result := goai.CallToolResult{
+				Content: []goai.ToolResultContent{{
+					Type: "json",
+					Text: text,
+				}},
+			}
+			if truncated {
+				result.Content = append(result.Content, goai.ToolResultContent{
+					Type: "text",
+					Text: fmt.Sprintf("... output truncated at %d bytes, older commits are left out; narrow the filters or lower max_count", g.config.MaxOutputBytes),
+				})
+			}
+			return result, nil
+		},
+	}
+}
+
+// logArgs builds the git log arguments for a query. Filters are attached to their flags as
+// single arguments, so they are never interpreted as options, and paths always follow "--".
+func logArgs(q logQuery) []string {
+	if q.MaxCount <= 0 {
+		q.MaxCount = defaultLogMaxCount
+	}
+	if q.Ref == "" {
+		q.Ref = "HEAD"
+	}
+
+	args := []string{"log", "--max-count=" + strconv.Itoa(q.MaxCount), commitLogFormat}
+	if q.Since != "" {
+		args = append(args, "--since="+q.Since)
+	}
+	if q.Until != "" {
+		args = append(args, "--until="+q.Until)
+	}
+	if q.Author != "" {
+		args = append(args, "--author="+q.Author)
+	}
+	args = append(args, q.Ref, "--")
+	return append(args, q.Paths...)
+}
+
+// parseCommitLog parses commitLogFormat output into commits
+func parseCommitLog(output string) []logCommit {
+	commits := []logCommit{}
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		fields := strings.SplitN(line, "\x00", 5)
+		if len(fields) != 5 {
+			continue
+		}
+		commits = append(commits, logCommit{
+			SHA:         fields[0],
+			Author:      fields[1],
+			AuthorEmail: fields[2],
+			Date:        fields[3],
+			Subject:     fields[4],
+		})
+	}
+	return commits
+}
@@ -0,0 +1,108 @@
+package mcptools
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    logQuery
+		expected []string
+	}{
+		{
+			name:     "defaults",
+			query:    logQuery{},
+			expected: []string{"log", "--max-count=50", commitLogFormat, "HEAD", "--"},
+		},
+		{
+			name:     "filters and paths",
+			query:    logQuery{Ref: "v1.0.0..main", Since: "2024-01-01", Until: "2 days ago", Author: "Jane", Paths: []string{"pkg/"}, MaxCount: 5},
+			expected: []string{"log", "--max-count=5", commitLogFormat, "--since=2024-01-01", "--until=2 days ago", "--author=Jane", "v1.0.0..main", "--", "pkg/"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, logArgs(tt.query))
+		})
+	}
+}
+
+func TestParseCommitLog(t *testing.T) {
+	output := "1111111\x00Jane Doe\x00jane@example.com\x002024-03-01T10:00:00+00:00\x00Add retry: with backoff\n" +
+		"2222222\x00John Roe\x00john@example.com\x002024-02-01T09:30:00+01:00\x00Initial import\n"
+
+	assert.Equal(t, []logCommit{
+		{SHA: "1111111", Author: "Jane Doe", AuthorEmail: "jane@example.com", Date: "2024-03-01T10:00:00+00:00", Subject: "Add retry: with backoff"},
+		{SHA: "2222222", Author: "John Roe", AuthorEmail: "john@example.com", Date: "2024-02-01T09:30:00+01:00", Subject: "Initial import"},
+	}, parseCommitLog(output))
+	assert.Empty(t, parseCommitLog(""))
+}
+
+func TestGit_GitLogTool(t *testing.T) {
+	repoPath := initTestRepo(t)
+	runTestGit(t, repoPath, "commit", "--allow-empty", "-m", "Second commit")
+	runTestGit(t, repoPath, "-c", "user.name=Other Author", "-c", "user.email=other@example.com", "commit", "--allow-empty", "-m", "Third commit")
+
+	tests := []struct {
+		name     string
+		input    map[string]interface{}
+		expected []string
+	}{
+		{name: "all commits", input: map[string]interface{}{}, expected: []string{"Third commit", "Second commit", "Initial commit"}},
+		{name: "limited", input: map[string]interface{}{"max_count": 1}, expected: []string{"Third commit"}},
+		{name: "by author", input: map[string]interface{}{"author": "Other"}, expected: []string{"Third commit"}},
+		{name: "until the past", input: map[string]interface{}{"until": "2000-01-01"}, expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.input["repo_path"] = repoPath
+			result := callGitCommandTool(t, NewGit(newPermissiveLogger(), GitConfig{}).GitLogTool(), tt.input)
+			require.False(t, result.IsError, result.Content)
+
+			var commits []logCommit
+			require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &commits))
+
+			var subjects []string
+			for _, commit := range commits {
+				assert.Len(t, commit.SHA, 40)
+				subjects = append(subjects, commit.Subject)
+			}
+			assert.Equal(t, tt.expected, subjects)
+		})
+	}
+}
+
+func TestGit_GitLogTool_MaxOutputBytes(t *testing.T) {
+	repoPath := initTestRepo(t)
+	runTestGit(t, repoPath, "commit", "--allow-empty", "-m", "Second commit")
+	runTestGit(t, repoPath, "commit", "--allow-empty", "-m", "Third commit")
+
+	// Room for the newest commit only, with the next one cut off
+	line := runTestGit(t, repoPath, "log", "-1", commitLogFormat)
+	git := NewGit(newPermissiveLogger(), GitConfig{MaxOutputBytes: len(line) + 10})
+
+	result := callGitCommandTool(t, git.GitLogTool(), map[string]interface{}{"repo_path": repoPath})
+	require.False(t, result.IsError, result.Content)
+	require.Len(t, result.Content, 2)
+
+	var commits []logCommit
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &commits))
+	require.Len(t, commits, 1)
+	assert.Equal(t, "Third commit", commits[0].Subject)
+	assert.Equal(t, "text", result.Content[1].Type)
+	assert.Contains(t, result.Content[1].Text, "older commits are left out")
+}
+
+func TestGit_GitLogTool_InvalidRef(t *testing.T) {
+	result := callGitCommandTool(t, NewGit(newPermissiveLogger(), GitConfig{}).GitLogTool(), map[string]interface{}{"repo_path": initTestRepo(t), "ref": "--output=/tmp/x"})
+
+	assert.True(t, result.IsError)
+	assert.Equal(t, `invalid ref: "--output=/tmp/x"`, result.Content[0].Text)
+}
@@ -0,0 +1,103 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/shaharia-lab/goai"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// GitFormatPatchTool returns a goai.Tool that exports one commit or a range of commits
+// as email-style patches, returned directly in the result instead of written to files.
+func (g *Git) GitFormatPatchTool() goai.Tool {
+	return goai.Tool{
+		Name:        GitFormatPatchToolName,
+		Description: "Exports a commit or a commit range as an email-style (format-patch) patch string",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"repo_path": {
+					"type": "string",
+					"description": "Path to Git repository"
+				},
+				"revision": {
+					"type": "string",
+					"description": "A single commit (e.g. HEAD, a SHA) or a range (e.g. main..feature)"
+				}
+			},
+			"required": ["repo_path", "revision"]
+		}`),
+		Handler: func(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
+			ctx, span := goai.StartSpan(ctx, fmt.Sprintf("%s.Handler", params.Name))
+			span.SetAttributes(
+				attribute.String("tool_name", params.Name),
+				attribute.String("tool_argument", string(params.Arguments)),
+			)
+			defer span.End()
+
+			g.logger.WithFields(map[string]interface{}{
+				"tool_name": params.Name,
+				"arguments": string(params.Arguments),
+			}).Info("Received input")
+
+			var input struct {
+				RepoPath string `json:"repo_path"`
+				Revision string `json:"revision"`
+			}
+
+			if err := json.Unmarshal(params.Arguments, &input); err != nil {
+				span.RecordError(err)
+				return goai.CallToolResult{}, fmt.Errorf("failed to unmarshal input: %w", err)
+			}
+
+			repoPath, err := g.resolveRepoPath(input.RepoPath)
+			if err != nil {
+				return returnErrorOutput(err), nil
+			}
+			input.RepoPath = repoPath
+
+			if input.Revision == "" {
+				return returnErrorOutput(fmt.Errorf("revision is required")), nil
+			}
+			if strings.HasPrefix(input.Revision, "-") {
+				return returnErrorOutput(fmt.Errorf("invalid revision: %q", input.Revision)), nil
+			}
+
+			output, err := g.runGit(ctx, input.RepoPath, formatPatchArgs(input.Revision)...)
+			if err != nil {
+				g.logger.WithFields(map[string]interface{}{
+					goai.ErrorLogField: err,
+					"output":           string(output),
+					"revision":         input.Revision,
+				}).Error("Git format-patch failed")
+
+				span.RecordError(err)
+				return returnErrorOutput(gitOutputError(err, output)), nil
+			}
+
+			g.logger.WithFields(map[string]interface{}{
+				"tool":          GitFormatPatchToolName,
+				"revision":      input.Revision,
+				"output_length": len(output),
+			}).Info("Git format-patch completed successfully")
+
+			return goai.CallToolResult{
+				Content: []goai.ToolResultContent{{
+					Type: "text",
+					Text: truncateOutput(string(output), g.config.MaxOutputBytes),
+				}},
+			}, nil
+		},
+	}
+}
+
+// formatPatchArgs builds the format-patch arguments for a single commit or a range.
+func formatPatchArgs(revision string) []string {
+	if strings.Contains(revision, "..") {
+		return []string{"format-patch", "--stdout", revision, "--"}
+	}
+	return []string{"format-patch", "--stdout", "-1", revision, "--"}
+}
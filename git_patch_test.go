@@ -0,0 +1,106 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shaharia-lab/goai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGit_GitFormatPatchTool(t *testing.T) {
+	repoPath := initTestRepo(t)
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "test.txt"), []byte("updated content\n"), 0644))
+	runTestGit(t, repoPath, "commit", "-am", "Update test file")
+
+	tests := []struct {
+		name     string
+		revision string
+		contains []string
+		excludes []string
+	}{
+		{
+			name:     "single commit",
+			revision: "HEAD",
+			contains: []string{"Subject: [PATCH] Update test file", "From: Test User <test@example.com>", "-test content", "+updated content"},
+			excludes: []string{"Initial commit"},
+		},
+		{
+			name:     "commit range",
+			revision: "HEAD~1..HEAD",
+			contains: []string{"Subject: [PATCH] Update test file", "+updated content"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			git := NewGit(newTestGitLogger(), GitConfig{})
+			tool := git.GitFormatPatchTool()
+
+			args, err := json.Marshal(map[string]string{"repo_path": repoPath, "revision": tt.revision})
+			require.NoError(t, err)
+
+			result, err := tool.Handler(context.Background(), goai.CallToolParams{Name: GitFormatPatchToolName, Arguments: args})
+			require.NoError(t, err)
+			require.False(t, result.IsError, result.Content)
+
+			for _, s := range tt.contains {
+				assert.Contains(t, result.Content[0].Text, s)
+			}
+			for _, s := range tt.excludes {
+				assert.NotContains(t, result.Content[0].Text, s)
+			}
+		})
+	}
+}
+
+func TestGit_GitFormatPatchTool_MaxOutputBytes(t *testing.T) {
+	repoPath := initTestRepo(t)
+
+	git := NewGit(newTestGitLogger(), GitConfig{MaxOutputBytes: 20})
+	tool := git.GitFormatPatchTool()
+
+	args, err := json.Marshal(map[string]string{"repo_path": repoPath, "revision": "HEAD"})
+	require.NoError(t, err)
+
+	result, err := tool.Handler(context.Background(), goai.CallToolParams{Name: GitFormatPatchToolName, Arguments: args})
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+	assert.Contains(t, result.Content[0].Text, "... output truncated")
+}
+
+func TestGit_GitFormatPatchTool_InvalidRevision(t *testing.T) {
+	repoPath := initTestRepo(t)
+
+	git := NewGit(newTestGitLogger(), GitConfig{})
+	tool := git.GitFormatPatchTool()
+
+	args, err := json.Marshal(map[string]string{"repo_path": repoPath, "revision": "does-not-exist"})
+	require.NoError(t, err)
+
+	result, err := tool.Handler(context.Background(), goai.CallToolParams{Name: GitFormatPatchToolName, Arguments: args})
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+}
+
+func TestGit_GitFormatPatchTool_OptionRevision(t *testing.T) {
+	repoPath := initTestRepo(t)
+	target := filepath.Join(t.TempDir(), "written")
+
+	git := NewGit(newTestGitLogger(), GitConfig{})
+	tool := git.GitFormatPatchTool()
+
+	args, err := json.Marshal(map[string]string{"repo_path": repoPath, "revision": "--output=" + target})
+	require.NoError(t, err)
+
+	result, err := tool.Handler(context.Background(), goai.CallToolParams{Name: GitFormatPatchToolName, Arguments: args})
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Equal(t, fmt.Sprintf("invalid revision: %q", "--output="+target), result.Content[0].Text)
+	assert.NoFileExists(t, target)
+}
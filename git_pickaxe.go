@@ -0,0 +1,161 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/shaharia-lab/goai"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// defaultPickaxeMaxCount caps the number of commits returned by the pickaxe search
+const defaultPickaxeMaxCount = 50
+
+// pickaxeQuery describes a pickaxe search
+type pickaxeQuery struct {
+	Search   string
+	Regex    bool
+	Ref      string
+	Paths    []string
+	MaxCount int
+}
+
+// GitPickaxeTool returns a goai.Tool that finds the commits that added or removed a string
+// (git log -S), optionally limited to a ref and paths.
+func (g *Git) GitPickaxeTool() goai.Tool {
+	return goai.Tool{
+		Name:        GitPickaxeToolName,
+		Description: "Finds the commits that introduced or removed a string (git log -S pickaxe search)",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"repo_path": {
+					"type": "string",
+					"description": "Path to Git repository"
+				},
+				"search": {
+					"type": "string",
+					"description": "String whose number of occurrences changed in the commit"
+				},
+				"regex": {
+					"type": "boolean",
+					"description": "Treat search as a regular expression matched against added or removed lines (git log -G)"
+				},
+				"ref": {
+					"type": "string",
+					"description": "Revision or range to search (defaults to HEAD)"
+				},
+				"paths": {
+					"type": "array",
+					"items": {"type": "string"},
+					"description": "Limit the search to these paths"
+				},
+				"max_count": {
+					"type": "integer",
+					"description": "Maximum number of commits to return (default 50)"
+				}
+			},
+			"required": ["repo_path", "search"]
+		}`),
+		Handler: func(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
+			ctx, span := goai.StartSpan(ctx, fmt.Sprintf("%s.Handler", params.Name))
+			span.SetAttributes(
+				attribute.String("tool_name", params.Name),
+				attribute.String("tool_argument", string(params.Arguments)),
+			)
+			defer span.End()
+
+			g.logger.WithFields(map[string]interface{}{
+				"tool_name": params.Name,
+				"arguments": string(params.Arguments),
+			}).Info("Received input")
+
+			var input struct {
+				RepoPath string   `json:"repo_path"`
+				Search   string   `json:"search"`
+				Regex    bool     `json:"regex"`
+				Ref      string   `json:"ref"`
+				Paths    []string `json:"paths"`
+				MaxCount int      `json:"max_count"`
+			}
+
+			if err := json.Unmarshal(params.Arguments, &input); err != nil {
+				span.RecordError(err)
+				return goai.CallToolResult{}, fmt.Errorf("failed to unmarshal input: %w", err)
+			}
+
+			repoPath, err := g.resolveRepoPath(input.RepoPath)
+			if err != nil {
+				return returnErrorOutput(err), nil
+			}
+			input.RepoPath = repoPath
+
+			if input.Search == "" {
+				return returnErrorOutput(fmt.Errorf("search is required")), nil
+			}
+			if strings.HasPrefix(input.Ref, "-") {
+				return returnErrorOutput(fmt.Errorf("invalid ref: %q", input.Ref)), nil
+			}
+
+			args := pickaxeArgs(pickaxeQuery{
+				Search:   input.Search,
+				Regex:    input.Regex,
+				Ref:      input.Ref,
+				Paths:    input.Paths,
+				MaxCount: input.MaxCount,
+			})
+
+			output, err := g.runGit(ctx, input.RepoPath, args...)
+			if err != nil {
+				g.logger.WithFields(map[string]interface{}{
+					goai.ErrorLogField: err,
+					"output":           string(output),
+				}).Error("Git pickaxe search failed")
+
+				span.RecordError(err)
+				return returnErrorOutput(gitOutputError(err, output)), nil
+			}
+
+			commits := parseCommitLog(string(output))
+
+			g.logger.WithFields(map[string]interface{}{
+				"tool":    GitPickaxeToolName,
+				"commits": len(commits),
+			}).Info("Git pickaxe search completed successfully")
+
+			text, err := marshalResult(commits)
+			if err != nil {
+				return returnErrorOutput(err), nil
+			}
+
+			return goai.CallToolResult{
+				Content: []goai.ToolResultContent{{
+					Type: "json",
+					Text: text,
+				}},
+			}, nil
+		},
+	}
+}
+
+// pickaxeArgs builds the git log arguments for a pickaxe search. The search string is passed
+// attached to its flag as a single argument, so it is never interpreted as an option or a
+// revision, and paths always follow "--".
+func pickaxeArgs(q pickaxeQuery) []string {
+	flag := "-S"
+	if q.Regex {
+		flag = "-G"
+	}
+	if q.MaxCount <= 0 {
+		q.MaxCount = defaultPickaxeMaxCount
+	}
+	if q.Ref == "" {
+		q.Ref = "HEAD"
+	}
+
+	args := []string{"log", flag + q.Search, "--max-count=" + strconv.Itoa(q.MaxCount), commitLogFormat, q.Ref, "--"}
+	return append(args, q.Paths...)
+}
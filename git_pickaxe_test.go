@@ -0,0 +1,67 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shaharia-lab/goai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPickaxeArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    pickaxeQuery
+		expected []string
+	}{
+		{
+			name:     "defaults",
+			query:    pickaxeQuery{Search: "retryCount"},
+			expected: []string{"log", "-SretryCount", "--max-count=50", commitLogFormat, "HEAD", "--"},
+		},
+		{
+			name:     "regex with ref and paths",
+			query:    pickaxeQuery{Search: "func .*Retry", Regex: true, Ref: "v1.0.0..main", Paths: []string{"pkg/"}, MaxCount: 5},
+			expected: []string{"log", "-Gfunc .*Retry", "--max-count=5", commitLogFormat, "v1.0.0..main", "--", "pkg/"},
+		},
+		{
+			name:     "option-like search stays attached to the flag",
+			query:    pickaxeQuery{Search: "--output=/tmp/x"},
+			expected: []string{"log", "-S--output=/tmp/x", "--max-count=50", commitLogFormat, "HEAD", "--"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, pickaxeArgs(tt.query))
+		})
+	}
+}
+
+func TestGit_GitPickaxeTool(t *testing.T) {
+	repoPath := initTestRepo(t)
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "test.txt"), []byte("test content\nmaxRetries := 3\n"), 0644))
+	runTestGit(t, repoPath, "commit", "-am", "Add retries")
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "other.txt"), []byte("unrelated\n"), 0644))
+	runTestGit(t, repoPath, "add", "other.txt")
+	runTestGit(t, repoPath, "commit", "-m", "Add other file")
+
+	git := NewGit(newPermissiveLogger(), GitConfig{})
+	args, err := json.Marshal(map[string]interface{}{"repo_path": repoPath, "search": "maxRetries"})
+	require.NoError(t, err)
+
+	result, err := git.GitPickaxeTool().Handler(context.Background(), goai.CallToolParams{Name: GitPickaxeToolName, Arguments: args})
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+
+	var commits []logCommit
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &commits))
+	require.Len(t, commits, 1)
+	assert.Equal(t, "Add retries", commits[0].Subject)
+	assert.Equal(t, "Test User", commits[0].Author)
+	assert.Len(t, commits[0].SHA, 40)
+}
@@ -0,0 +1,284 @@
+package mcptools
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/shaharia-lab/goai"
+)
+
+// dangerousGitOptions are the long options that make git run arbitrary programs, load
+// configuration that does, or read and write outside of the repository. They are rejected by
+// the git tool when GitConfig.BlockDangerousArgs is set.
+var dangerousGitOptions = map[string]string{
+	// Configuration such as core.fsmonitor, core.sshCommand or core.pager names programs git runs
+	"--config":     "sets configuration values",
+	"--config-env": "sets configuration values",
+	// The programs run on the remote side of a fetch or push, executed locally for local remotes
+	"--upload-pack":  "runs a custom upload-pack program",
+	"--receive-pack": "runs a custom receive-pack program",
+	// git rebase --exec runs a shell command after every commit, git archive --exec names the
+	// remote archiver program
+	"--exec": "runs a custom command",
+	// The directory git programs are run from
+	"--exec-path": "runs git programs from another directory",
+	// git difftool --extcmd runs a custom diff program
+	"--extcmd": "runs a custom diff program",
+	// git grep --open-files-in-pager runs the given pager
+	"--open-files-in-pager": "runs a custom pager",
+	// Templates can install hooks into the new repository of git init and git clone
+	"--template": "installs hooks from a template directory",
+	// git diff --output and similar write to arbitrary files
+	"--output": "writes to an arbitrary file",
+	// These point git at a repository or working tree other than repo_path
+	"--git-dir":   "uses another repository",
+	"--work-tree": "uses another working tree",
+	"--namespace": "uses another ref namespace",
+}
+
+// dangerousGitShortFlags are the short forms of dangerous options, by the command whose meaning
+// of the flag is dangerous; other commands use the same letters harmlessly, such as -c of
+// git commit, which reuses a commit message.
+var dangerousGitShortFlags = map[string]string{
+	"clone":    "cu", // -c sets configuration, -u runs a custom upload-pack
+	"grep":     "O",  // -O runs a custom pager
+	"rebase":   "x",  // -x runs a shell command after every commit
+	"difftool": "x",  // -x runs a custom diff program
+}
+
+// gitConfigReadOptions are the options that make git config read configuration
+var gitConfigReadOptions = map[string]bool{
+	"--get": true, "--get-all": true, "--get-regexp": true, "--get-urlmatch": true,
+	"--get-color": true, "--get-colorbool": true, "--list": true, "-l": true,
+}
+
+// gitConfigReadSafeOptions are the options of git config that neither write configuration nor
+// run an editor, such as scopes and value types. The ones in gitConfigValueOptions take their
+// value as the next argument.
+var gitConfigReadSafeOptions = map[string]bool{
+	"--global": true, "--system": true, "--local": true, "--worktree": true,
+	"-f": true, "--file": true, "--blob": true, "--type": true, "--no-type": true,
+	"--bool": true, "--int": true, "--bool-or-int": true, "--path": true, "--expiry-date": true,
+	"-z": true, "--null": true, "--name-only": true, "--show-origin": true, "--show-scope": true,
+	"--includes": true, "--no-includes": true, "--default": true, "--fixed-value": true,
+	"--all": true, "--regexp": true, "--url": true, "--value": true,
+}
+
+var gitConfigValueOptions = map[string]bool{
+	"-f": true, "--file": true, "--blob": true, "--type": true, "--default": true, "--url": true, "--value": true,
+}
+
+// dangerousGitEnv are the environment variables that make git run arbitrary programs, load
+// configuration that does, or use another repository. They are rejected in the env of git tool
+// calls when GitConfig.BlockDangerousArgs is set, as are the GIT_CONFIG_* variables, which set
+// configuration values like -c does.
+var dangerousGitEnv = map[string]string{
+	"GIT_SSH":             "runs a custom ssh program",
+	"GIT_SSH_COMMAND":     "runs a custom ssh command",
+	"GIT_ASKPASS":         "runs a custom credential prompt",
+	"SSH_ASKPASS":         "runs a custom credential prompt",
+	"GIT_PROXY_COMMAND":   "runs a custom proxy command",
+	"GIT_EDITOR":          "runs a custom editor",
+	"GIT_SEQUENCE_EDITOR": "runs a custom editor",
+	"EDITOR":              "runs a custom editor",
+	"VISUAL":              "runs a custom editor",
+	"GIT_PAGER":           "runs a custom pager",
+	"PAGER":               "runs a custom pager",
+	"GIT_EXTERNAL_DIFF":   "runs a custom diff program",
+	"GIT_EXEC_PATH":       "runs git programs from another directory",
+	"GIT_TEMPLATE_DIR":    "installs hooks from a template directory",
+	"GIT_DIR":             "uses another repository",
+	"GIT_WORK_TREE":       "uses another working tree",
+	"GIT_NAMESPACE":       "uses another ref namespace",
+	"PATH":                "changes where programs are found",
+	"LD_PRELOAD":          "loads a custom library into git",
+	"LD_LIBRARY_PATH":     "loads libraries from another directory",
+	"HOME":                "loads another global configuration",
+	"XDG_CONFIG_HOME":     "loads another global configuration",
+}
+
+// checkPolicy returns an error when a git invocation is blocked by BlockedCommands or rejected
+// by checkArgumentPolicy, and logs why
+func (g *Git) checkPolicy(command string, args []string) error {
+	if blocked, ok := g.blockedBy(command, args); ok {
+		g.logger.WithFields(map[string]interface{}{
+			"command": command,
+			"pattern": blocked,
+		}).Warn("Blocked git command")
+
+		return fmt.Errorf("command %q is blocked by policy", command)
+	}
+	if err := g.checkArgumentPolicy(command, args); err != nil {
+		g.logger.WithFields(map[string]interface{}{
+			"command":          command,
+			goai.ErrorLogField: err,
+		}).Warn("Rejected git command")
+
+		return err
+	}
+	return nil
+}
+
+// checkArgumentPolicy returns an error when a git invocation runs a command outside of the
+// AllowedCommands, or, with BlockDangerousArgs set, passes a dangerous option that is not in
+// AllowedDangerousArgs. Options are recognized in every form git accepts: "--opt value",
+// "--opt=value", unambiguous abbreviations like "--upload-p" and grouped short flags like "-qc".
+// A command that is itself an option, such as "-c" or "--exec-path=...", is always dangerous:
+// git parses it as a global option.
+func (g *Git) checkArgumentPolicy(command string, args []string) error {
+	name := strings.ToLower(strings.TrimSpace(command))
+	if len(g.config.AllowedCommands) > 0 {
+		allowed := false
+		for _, pattern := range g.config.AllowedCommands {
+			if blockedCommandPattern(pattern).MatchString(name) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return fmt.Errorf("command %q is not in the allowed commands", command)
+		}
+	}
+
+	if !g.config.BlockDangerousArgs {
+		return nil
+	}
+	if strings.HasPrefix(name, "-") {
+		return fmt.Errorf("command %q is not allowed: global git options cannot be passed as the command", command)
+	}
+
+	allowedArgs := toSet(g.config.AllowedDangerousArgs)
+	// Configuration such as core.fsmonitor, core.sshCommand or alias.* names programs the next
+	// git invocation runs, so git config may only read
+	if name == "config" && !allowedArgs["config"] && !isGitConfigRead(args) {
+		return fmt.Errorf("command %q is not allowed: only reads are permitted, configuration values can make git run programs", command)
+	}
+	for _, arg := range args {
+		// Everything after "--" is a path or a revision, not an option
+		if arg == "--" {
+			break
+		}
+		option, reason, dangerous := dangerousGitArg(name, arg)
+		if dangerous && !allowedArgs[option] {
+			return fmt.Errorf("argument %q is not allowed: %s %s", arg, option, reason)
+		}
+	}
+	return nil
+}
+
+// isGitConfigRead reports whether the arguments of git config only read configuration: they
+// pass a read option such as --get or --list, use the get or list subcommand, or name a single
+// key, and every other option is one of gitConfigReadSafeOptions
+func isGitConfigRead(args []string) bool {
+	read := false
+	var positional []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			positional = append(positional, args[i+1:]...)
+			break
+		}
+		if !strings.HasPrefix(arg, "-") {
+			positional = append(positional, arg)
+			continue
+		}
+
+		option, _, hasValue := strings.Cut(arg, "=")
+		switch {
+		case gitConfigReadOptions[option]:
+			read = true
+		case !gitConfigReadSafeOptions[option]:
+			return false
+		case gitConfigValueOptions[option] && !hasValue:
+			i++
+		}
+	}
+
+	if len(positional) > 0 {
+		switch positional[0] {
+		case "get", "list":
+			return true
+		case "set", "unset", "rename-section", "remove-section", "edit":
+			return false
+		}
+	}
+	return read || len(positional) == 1
+}
+
+// dangerousGitArg reports whether an argument of a command is a dangerous option, and if so the
+// option it names and why it is dangerous
+func dangerousGitArg(command, arg string) (string, string, bool) {
+	if strings.HasPrefix(arg, "--") {
+		name, _, _ := strings.Cut(arg, "=")
+		if reason, ok := dangerousGitOptions[name]; ok {
+			return name, reason, true
+		}
+		// git accepts any unambiguous prefix of a long option. Prefixes are rejected even where
+		// they abbreviate a harmless option of the command, such as --con for --continue.
+		if len(name) < 4 {
+			return "", "", false
+		}
+		options := make([]string, 0, len(dangerousGitOptions))
+		for option := range dangerousGitOptions {
+			options = append(options, option)
+		}
+		sort.Strings(options)
+		for _, option := range options {
+			if strings.HasPrefix(option, name) {
+				return option, dangerousGitOptions[option], true
+			}
+		}
+		return "", "", false
+	}
+
+	if strings.HasPrefix(arg, "-") && len(arg) > 1 {
+		flags := dangerousGitShortFlags[command]
+		for _, flag := range arg[1:] {
+			if strings.ContainsRune(flags, flag) {
+				return "-" + string(flag), shortFlagReason(command, flag), true
+			}
+		}
+	}
+	return "", "", false
+}
+
+// shortFlagReason returns why a dangerous short flag of a command is dangerous
+func shortFlagReason(command string, flag rune) string {
+	switch {
+	case command == "clone" && flag == 'c':
+		return dangerousGitOptions["--config"]
+	case command == "clone" && flag == 'u':
+		return dangerousGitOptions["--upload-pack"]
+	case command == "grep":
+		return dangerousGitOptions["--open-files-in-pager"]
+	case command == "difftool":
+		return dangerousGitOptions["--extcmd"]
+	default:
+		return dangerousGitOptions["--exec"]
+	}
+}
+
+// checkEnvPolicy returns an error when the env of a git tool call has an invalid variable name
+// or, with BlockDangerousArgs set, sets a dangerous variable that is not in AllowedDangerousArgs
+func (g *Git) checkEnvPolicy(env map[string]string) error {
+	allowed := toSet(g.config.AllowedDangerousArgs)
+	for _, name := range envKeys(env) {
+		if name == "" || strings.ContainsAny(name, "=\x00") {
+			return fmt.Errorf("invalid environment variable name %q", name)
+		}
+		if !g.config.BlockDangerousArgs || allowed[name] {
+			continue
+		}
+
+		upper := strings.ToUpper(name)
+		reason, dangerous := dangerousGitEnv[upper]
+		if !dangerous && strings.HasPrefix(upper, "GIT_CONFIG") {
+			reason, dangerous = dangerousGitOptions["--config"], true
+		}
+		if dangerous {
+			return fmt.Errorf("environment variable %s is not allowed: it %s", name, reason)
+		}
+	}
+	return nil
+}
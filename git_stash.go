@@ -0,0 +1,183 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/shaharia-lab/goai"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// stashEntry is a single entry of the stash list
+type stashEntry struct {
+	Index   int    `json:"index"`
+	Ref     string `json:"ref"`
+	Message string `json:"message"`
+}
+
+// GitStashTool returns a goai.Tool that inspects stash entries: listing them and showing
+// the diff of a single entry.
+func (g *Git) GitStashTool() goai.Tool {
+	return goai.Tool{
+		Name:        GitStashToolName,
+		Description: "Lists stash entries and shows the diff of a stash entry as a patch or a diffstat",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"repo_path": {
+					"type": "string",
+					"description": "Path to Git repository"
+				},
+				"operation": {
+					"type": "string",
+					"enum": ["list", "show"],
+					"description": "Operation to perform"
+				},
+				"index": {
+					"type": "integer",
+					"description": "Stash index N of stash@{N} for show (defaults to 0)"
+				},
+				"stat": {
+					"type": "boolean",
+					"description": "Show a diffstat instead of the full patch"
+				}
+			},
+			"required": ["repo_path", "operation"]
+		}`),
+		Handler: func(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
+			ctx, span := goai.StartSpan(ctx, fmt.Sprintf("%s.Handler", params.Name))
+			span.SetAttributes(
+				attribute.String("tool_name", params.Name),
+				attribute.String("tool_argument", string(params.Arguments)),
+			)
+			defer span.End()
+
+			g.logger.WithFields(map[string]interface{}{
+				"tool_name": params.Name,
+				"arguments": string(params.Arguments),
+			}).Info("Received input")
+
+			var input struct {
+				RepoPath  string `json:"repo_path"`
+				Operation string `json:"operation"`
+				Index     int    `json:"index"`
+				Stat      bool   `json:"stat"`
+			}
+
+			if err := json.Unmarshal(params.Arguments, &input); err != nil {
+				span.RecordError(err)
+				return goai.CallToolResult{}, fmt.Errorf("failed to unmarshal input: %w", err)
+			}
+
+			repoPath, err := g.resolveRepoPath(input.RepoPath)
+			if err != nil {
+				return returnErrorOutput(err), nil
+			}
+			input.RepoPath = repoPath
+
+			var result goai.ToolResultContent
+
+			switch input.Operation {
+			case "list":
+				var entries []stashEntry
+				entries, err = g.listStash(ctx, input.RepoPath)
+				if err == nil {
+					var text string
+					text, err = marshalResult(entries)
+					result = goai.ToolResultContent{Type: "json", Text: text}
+				}
+			case "show":
+				var patch string
+				patch, err = g.showStash(ctx, input.RepoPath, input.Index, input.Stat)
+				result = goai.ToolResultContent{Type: "text", Text: truncateOutput(patch, g.config.MaxOutputBytes)}
+			default:
+				return returnErrorOutput(fmt.Errorf("unsupported operation: %s", input.Operation)), nil
+			}
+
+			if err != nil {
+				g.logger.WithFields(map[string]interface{}{
+					goai.ErrorLogField: err,
+					"operation":        input.Operation,
+				}).Error("Git stash operation failed")
+
+				span.RecordError(err)
+				return returnErrorOutput(err), nil
+			}
+
+			g.logger.WithFields(map[string]interface{}{
+				"tool":          GitStashToolName,
+				"operation":     input.Operation,
+				"output_length": len(result.Text),
+			}).Info("Git stash operation completed successfully")
+
+			return goai.CallToolResult{Content: []goai.ToolResultContent{result}}, nil
+		},
+	}
+}
+
+// listStash returns the entries of the stash, most recent first
+func (g *Git) listStash(ctx context.Context, repoPath string) ([]stashEntry, error) {
+	output, err := g.runGit(ctx, repoPath, "stash", "list", "--format=%gd%x00%gs")
+	if err != nil {
+		return nil, gitOutputError(err, output)
+	}
+
+	entries := []stashEntry{}
+	for i, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if line == "" {
+			continue
+		}
+		ref, message, _ := strings.Cut(line, "\x00")
+		entries = append(entries, stashEntry{Index: i, Ref: ref, Message: message})
+	}
+	return entries, nil
+}
+
+// showStash returns the diff of stash@{index} as a patch, or as a diffstat when stat is set
+func (g *Git) showStash(ctx context.Context, repoPath string, index int, stat bool) (string, error) {
+	if index < 0 {
+		return "", fmt.Errorf("invalid stash index: %d", index)
+	}
+
+	ref := fmt.Sprintf("stash@{%d}", index)
+	if output, err := g.runGit(ctx, repoPath, "rev-parse", "--verify", ref); err != nil {
+		if isMissingRevision(err, output) {
+			return "", fmt.Errorf("stash entry %s does not exist", ref)
+		}
+		return "", gitOutputError(err, output)
+	}
+
+	args := []string{"stash", "show"}
+	if stat {
+		args = append(args, "--stat")
+	} else {
+		args = append(args, "-p")
+	}
+
+	output, err := g.runGit(ctx, repoPath, append(args, ref)...)
+	if err != nil {
+		return "", gitOutputError(err, output)
+	}
+	return string(output), nil
+}
+
+// isMissingRevision reports whether git rev-parse --verify failed because the revision does not
+// exist, rather than because of the repository, a policy or a timeout. git exits with status
+// 128 and names the missing revision, or an empty or too short reflog for stash@{n}.
+func isMissingRevision(err error, output []byte) bool {
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != 128 {
+		return false
+	}
+	message := string(output)
+	for _, reason := range []string{"unknown revision", "bad revision", "Needed a single revision", "only has"} {
+		if strings.Contains(message, reason) {
+			return true
+		}
+	}
+	return false
+}
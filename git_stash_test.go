@@ -0,0 +1,137 @@
+package mcptools
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// initTestRepoWithStash returns a test repository holding two stash entries
+func initTestRepoWithStash(t *testing.T) string {
+	t.Helper()
+	repoPath := initTestRepo(t)
+
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "test.txt"), []byte("first stash\n"), 0644))
+	runTestGit(t, repoPath, "stash", "push", "-m", "first")
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "test.txt"), []byte("second stash\n"), 0644))
+	runTestGit(t, repoPath, "stash", "push", "-m", "second")
+
+	return repoPath
+}
+
+func TestGit_GitStashTool_List(t *testing.T) {
+	repoPath := initTestRepoWithStash(t)
+
+	result := callGitCommandTool(t, NewGit(newPermissiveLogger(), GitConfig{}).GitStashTool(), map[string]interface{}{"repo_path": repoPath, "operation": "list"})
+	require.False(t, result.IsError, result.Content)
+
+	var entries []stashEntry
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &entries))
+	require.Len(t, entries, 2)
+	assert.Equal(t, "stash@{0}", entries[0].Ref)
+	assert.Equal(t, "On main: second", entries[0].Message)
+	assert.Equal(t, 1, entries[1].Index)
+}
+
+func TestGit_GitStashTool_Show(t *testing.T) {
+	repoPath := initTestRepoWithStash(t)
+
+	tests := []struct {
+		name     string
+		input    map[string]interface{}
+		contains []string
+		excludes []string
+	}{
+		{
+			name:     "patch of the latest entry",
+			input:    map[string]interface{}{"operation": "show"},
+			contains: []string{"diff --git a/test.txt b/test.txt", "-test content", "+second stash"},
+			excludes: []string{"first stash"},
+		},
+		{
+			name:     "patch of an older entry",
+			input:    map[string]interface{}{"operation": "show", "index": 1},
+			contains: []string{"+first stash"},
+			excludes: []string{"second stash"},
+		},
+		{
+			name:     "stat mode",
+			input:    map[string]interface{}{"operation": "show", "index": 1, "stat": true},
+			contains: []string{"test.txt | 2 +-", "1 file changed"},
+			excludes: []string{"diff --git"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.input["repo_path"] = repoPath
+			result := callGitCommandTool(t, NewGit(newPermissiveLogger(), GitConfig{}).GitStashTool(), tt.input)
+			require.False(t, result.IsError, result.Content)
+
+			for _, s := range tt.contains {
+				assert.Contains(t, result.Content[0].Text, s)
+			}
+			for _, s := range tt.excludes {
+				assert.NotContains(t, result.Content[0].Text, s)
+			}
+		})
+	}
+}
+
+func TestGit_GitStashTool_ShowMissingEntry(t *testing.T) {
+	repoPath := initTestRepoWithStash(t)
+
+	result := callGitCommandTool(t, NewGit(newPermissiveLogger(), GitConfig{}).GitStashTool(), map[string]interface{}{"repo_path": repoPath, "operation": "show", "index": 5})
+	assert.True(t, result.IsError)
+	assert.Equal(t, "stash entry stash@{5} does not exist", result.Content[0].Text)
+}
+
+func TestGit_GitStashTool_ShowWithoutStash(t *testing.T) {
+	repoPath := initTestRepo(t)
+
+	result := callGitCommandTool(t, NewGit(newPermissiveLogger(), GitConfig{}).GitStashTool(), map[string]interface{}{"repo_path": repoPath, "operation": "show"})
+	assert.True(t, result.IsError)
+	assert.Equal(t, "stash entry stash@{0} does not exist", result.Content[0].Text)
+}
+
+func TestGit_GitStashTool_ShowOtherFailures(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   GitConfig
+		repoPath string
+		expected string
+	}{
+		{
+			name:     "policy rejection",
+			config:   GitConfig{AllowedCommands: []string{"stash"}},
+			repoPath: initTestRepoWithStash(t),
+			expected: `command "rev-parse" is not in the allowed commands`,
+		},
+		{
+			name:     "not a repository",
+			repoPath: t.TempDir(),
+			expected: "not a git repository",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := callGitCommandTool(t, NewGit(newPermissiveLogger(), tt.config).GitStashTool(), map[string]interface{}{"repo_path": tt.repoPath, "operation": "show"})
+			assert.True(t, result.IsError)
+			assert.Contains(t, result.Content[0].Text, tt.expected)
+			assert.NotContains(t, result.Content[0].Text, "does not exist")
+		})
+	}
+}
+
+func TestGit_GitStashTool_ShowMaxOutputBytes(t *testing.T) {
+	repoPath := initTestRepoWithStash(t)
+
+	result := callGitCommandTool(t, NewGit(newPermissiveLogger(), GitConfig{MaxOutputBytes: 20}).GitStashTool(), map[string]interface{}{"repo_path": repoPath, "operation": "show"})
+	require.False(t, result.IsError, result.Content)
+	assert.Contains(t, result.Content[0].Text, "... output truncated")
+}
@@ -0,0 +1,215 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/shaharia-lab/goai"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// gitStatus is the working tree state reported by the status tool
+type gitStatus struct {
+	Branch     string            `json:"branch"`
+	Detached   bool              `json:"detached"`
+	Commit     string            `json:"commit,omitempty"`
+	Upstream   string            `json:"upstream,omitempty"`
+	Ahead      int               `json:"ahead"`
+	Behind     int               `json:"behind"`
+	Staged     []gitStatusChange `json:"staged"`
+	Unstaged   []gitStatusChange `json:"unstaged"`
+	Untracked  []string          `json:"untracked"`
+	Conflicted []string          `json:"conflicted"`
+}
+
+// gitStatusChange is a changed file in the index or the working tree
+type gitStatusChange struct {
+	Path     string `json:"path"`
+	OrigPath string `json:"orig_path,omitempty"`
+	Status   string `json:"status"`
+}
+
+// statusCodes are the names of the change codes of git status
+var statusCodes = map[byte]string{
+	'M': "modified",
+	'T': "type_changed",
+	'A': "added",
+	'D': "deleted",
+	'R': "renamed",
+	'C': "copied",
+}
+
+// GitStatusTool returns a goai.Tool that reports the working tree state of a repository as
+// structured JSON: the current branch and its upstream, and the staged, unstaged, untracked
+// and conflicted files.
+func (g *Git) GitStatusTool() goai.Tool {
+	return goai.Tool{
+		Name:        GitStatusToolName,
+		Description: "Reports the branch, ahead/behind counts and staged, unstaged, untracked and conflicted files of a repository as JSON",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"repo_path": {
+					"type": "string",
+					"description": "Path to Git repository (defaults to the configured default repository path)"
+				}
+			}
+		}`),
+		Handler: func(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
+			ctx, span := goai.StartSpan(ctx, fmt.Sprintf("%s.Handler", params.Name))
+			span.SetAttributes(
+				attribute.String("tool_name", params.Name),
+				attribute.String("tool_argument", string(params.Arguments)),
+			)
+			defer span.End()
+
+			g.logger.WithFields(map[string]interface{}{
+				"tool_name": params.Name,
+				"arguments": string(params.Arguments),
+			}).Info("Received input")
+
+			var input struct {
+				RepoPath string `json:"repo_path"`
+			}
+
+			if err := json.Unmarshal(params.Arguments, &input); err != nil {
+				span.RecordError(err)
+				return goai.CallToolResult{}, fmt.Errorf("failed to unmarshal input: %w", err)
+			}
+
+			repoPath, err := g.resolveRepoPath(input.RepoPath)
+			if err != nil {
+				return returnErrorOutput(err), nil
+			}
+
+			output, err := g.runGit(ctx, repoPath, "status", "--porcelain=v2", "--branch", "-z")
+			if err != nil {
+				err = gitOutputError(err, output)
+				g.logger.WithFields(map[string]interface{}{
+					goai.ErrorLogField: err,
+					"repo_path":        repoPath,
+				}).Error("Git status failed")
+
+				span.RecordError(err)
+				return returnErrorOutput(err), nil
+			}
+
+			status, err := parseGitStatus(string(output))
+			if err != nil {
+				span.RecordError(err)
+				return returnErrorOutput(err), nil
+			}
+
+			m, err := marshalResult(status)
+			if err != nil {
+				return returnErrorOutput(err), nil
+			}
+			g.logger.WithFields(map[string]interface{}{
+				"tool":          GitStatusToolName,
+				"repo_path":     repoPath,
+				"output_length": len(m),
+			}).Info("Git status completed successfully")
+
+			return goai.CallToolResult{
+				Content: []goai.ToolResultContent{{
+					Type: "json",
+					Text: m,
+				}},
+			}, nil
+		},
+	}
+}
+
+// parseGitStatus parses the NUL-separated output of "git status --porcelain=v2 --branch -z"
+func parseGitStatus(output string) (*gitStatus, error) {
+	status := &gitStatus{
+		Staged:     []gitStatusChange{},
+		Unstaged:   []gitStatusChange{},
+		Untracked:  []string{},
+		Conflicted: []string{},
+	}
+
+	entries := strings.Split(output, "\x00")
+	for i := 0; i < len(entries); i++ {
+		entry := entries[i]
+		if entry == "" {
+			continue
+		}
+
+		switch entry[0] {
+		case '#':
+			parseStatusHeader(status, entry)
+		case '1', '2':
+			// 1 XY sub mH mI mW hH hI path
+			// 2 XY sub mH mI mW hH hI Xscore path, followed by the original path as the next entry
+			fieldCount := 9
+			if entry[0] == '2' {
+				fieldCount = 10
+			}
+			fields := strings.SplitN(entry, " ", fieldCount)
+			if len(fields) < fieldCount || len(fields[1]) != 2 {
+				return nil, fmt.Errorf("unexpected git status entry: %q", entry)
+			}
+
+			change := gitStatusChange{Path: fields[fieldCount-1]}
+			if entry[0] == '2' && i+1 < len(entries) {
+				i++
+				change.OrigPath = entries[i]
+			}
+
+			xy := fields[1]
+			if name, ok := statusCodes[xy[0]]; ok {
+				staged := change
+				staged.Status = name
+				status.Staged = append(status.Staged, staged)
+			}
+			if name, ok := statusCodes[xy[1]]; ok {
+				unstaged := change
+				unstaged.Status = name
+				status.Unstaged = append(status.Unstaged, unstaged)
+			}
+		case 'u':
+			// u XY sub m1 m2 m3 mW h1 h2 h3 path
+			fields := strings.SplitN(entry, " ", 11)
+			if len(fields) < 11 {
+				return nil, fmt.Errorf("unexpected git status entry: %q", entry)
+			}
+			status.Conflicted = append(status.Conflicted, fields[10])
+		case '?':
+			status.Untracked = append(status.Untracked, strings.TrimPrefix(entry, "? "))
+		case '!':
+			// Ignored files are only listed with --ignored
+		default:
+			return nil, fmt.Errorf("unexpected git status entry: %q", entry)
+		}
+	}
+
+	return status, nil
+}
+
+// parseStatusHeader records a "# branch.*" header line of the porcelain v2 output
+func parseStatusHeader(status *gitStatus, header string) {
+	key, value, _ := strings.Cut(strings.TrimPrefix(header, "# "), " ")
+	switch key {
+	case "branch.oid":
+		if value != "(initial)" {
+			status.Commit = value
+		}
+	case "branch.head":
+		if value == "(detached)" {
+			status.Detached = true
+		} else {
+			status.Branch = value
+		}
+	case "branch.upstream":
+		status.Upstream = value
+	case "branch.ab":
+		// branch.ab +<ahead> -<behind>
+		ahead, behind, _ := strings.Cut(value, " ")
+		status.Ahead, _ = strconv.Atoi(strings.TrimPrefix(ahead, "+"))
+		status.Behind, _ = strconv.Atoi(strings.TrimPrefix(behind, "-"))
+	}
+}
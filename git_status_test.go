@@ -0,0 +1,77 @@
+package mcptools
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGit_GitStatusTool(t *testing.T) {
+	repoPath := initTestRepo(t)
+
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "staged.txt"), []byte("new\n"), 0644))
+	runTestGit(t, repoPath, "add", "staged.txt")
+	runTestGit(t, repoPath, "mv", "test.txt", "renamed.txt")
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "renamed.txt"), []byte("changed\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "untracked file.txt"), []byte("?\n"), 0644))
+
+	result := callGitCommandTool(t, NewGit(newPermissiveLogger(), GitConfig{}).GitStatusTool(), map[string]interface{}{"repo_path": repoPath})
+	require.False(t, result.IsError, result.Content)
+	assert.Equal(t, "json", result.Content[0].Type)
+
+	var status gitStatus
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &status))
+	assert.Equal(t, "main", status.Branch)
+	assert.False(t, status.Detached)
+	assert.Len(t, status.Commit, 40)
+	assert.ElementsMatch(t, []gitStatusChange{
+		{Path: "renamed.txt", OrigPath: "test.txt", Status: "renamed"},
+		{Path: "staged.txt", Status: "added"},
+	}, status.Staged)
+	assert.Equal(t, []gitStatusChange{{Path: "renamed.txt", OrigPath: "test.txt", Status: "modified"}}, status.Unstaged)
+	assert.Equal(t, []string{"untracked file.txt"}, status.Untracked)
+	assert.Empty(t, status.Conflicted)
+}
+
+func TestParseGitStatus(t *testing.T) {
+	output := "# branch.oid 1234567890abcdef1234567890abcdef12345678\x00" +
+		"# branch.head feature\x00" +
+		"# branch.upstream origin/feature\x00" +
+		"# branch.ab +2 -3\x00" +
+		"1 .D N... 100644 100644 000000 1234567 1234567 gone.txt\x00" +
+		"u UU N... 100644 100644 100644 100644 1234567 2345678 3456789 conflict.txt\x00" +
+		"? new dir/file.txt\x00"
+
+	status, err := parseGitStatus(output)
+	require.NoError(t, err)
+	assert.Equal(t, &gitStatus{
+		Branch:     "feature",
+		Commit:     "1234567890abcdef1234567890abcdef12345678",
+		Upstream:   "origin/feature",
+		Ahead:      2,
+		Behind:     3,
+		Staged:     []gitStatusChange{},
+		Unstaged:   []gitStatusChange{{Path: "gone.txt", Status: "deleted"}},
+		Untracked:  []string{"new dir/file.txt"},
+		Conflicted: []string{"conflict.txt"},
+	}, status)
+}
+
+func TestParseGitStatus_DetachedInitial(t *testing.T) {
+	status, err := parseGitStatus("# branch.oid (initial)\x00# branch.head (detached)\x00")
+	require.NoError(t, err)
+	assert.True(t, status.Detached)
+	assert.Empty(t, status.Branch)
+	assert.Empty(t, status.Commit)
+}
+
+func TestGit_GitStatusTool_NotARepository(t *testing.T) {
+	result := callGitCommandTool(t, NewGit(newPermissiveLogger(), GitConfig{}).GitStatusTool(), map[string]interface{}{"repo_path": t.TempDir()})
+
+	assert.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].Text, "not a git repository")
+}
@@ -0,0 +1,157 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/shaharia-lab/goai"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// workspaceStep records the outcome of a single step of a composite git operation
+type workspaceStep struct {
+	Step    string   `json:"step"`
+	Args    []string `json:"args"`
+	Output  string   `json:"output"`
+	Success bool     `json:"success"`
+}
+
+// GitPrepareWorkspaceTool returns a goai.Tool that brings a repository to a clean state
+// on a target branch: fetch, checkout, hard reset to the remote branch and clean untracked files.
+// Each step is subject to BlockedCommands, AllowedCommands and BlockDangerousArgs.
+func (g *Git) GitPrepareWorkspaceTool() goai.Tool {
+	return goai.Tool{
+		Name:        GitWorkspaceToolName,
+		Description: "Prepares a clean workspace: fetches, checks out a branch, hard resets it to the remote and removes untracked files",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"repo_path": {
+					"type": "string",
+					"description": "Path to Git repository"
+				},
+				"branch": {
+					"type": "string",
+					"description": "Branch to check out and reset"
+				},
+				"remote": {
+					"type": "string",
+					"description": "Remote to fetch from (defaults to origin)"
+				}
+			},
+			"required": ["repo_path", "branch"]
+		}`),
+		Handler: func(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
+			ctx, span := goai.StartSpan(ctx, fmt.Sprintf("%s.Handler", params.Name))
+			span.SetAttributes(
+				attribute.String("tool_name", params.Name),
+				attribute.String("tool_argument", string(params.Arguments)),
+			)
+			defer span.End()
+
+			g.logger.WithFields(map[string]interface{}{
+				"tool_name": params.Name,
+				"arguments": string(params.Arguments),
+			}).Info("Received input")
+
+			var input struct {
+				RepoPath string `json:"repo_path"`
+				Branch   string `json:"branch"`
+				Remote   string `json:"remote"`
+			}
+
+			if err := json.Unmarshal(params.Arguments, &input); err != nil {
+				span.RecordError(err)
+				return goai.CallToolResult{}, fmt.Errorf("failed to unmarshal input: %w", err)
+			}
+
+			repoPath, err := g.resolveRepoPath(input.RepoPath)
+			if err != nil {
+				return returnErrorOutput(err), nil
+			}
+			input.RepoPath = repoPath
+
+			if input.Branch == "" {
+				return returnErrorOutput(fmt.Errorf("branch is required")), nil
+			}
+			if input.Remote == "" {
+				input.Remote = "origin"
+			}
+			if strings.HasPrefix(input.Remote, "-") {
+				return returnErrorOutput(fmt.Errorf("invalid remote: %q", input.Remote)), nil
+			}
+			if strings.HasPrefix(input.Branch, "-") {
+				return returnErrorOutput(fmt.Errorf("invalid branch: %q", input.Branch)), nil
+			}
+
+			if !g.config.AllowHardReset {
+				return returnErrorOutput(fmt.Errorf("prepare workspace aborted: hard reset is disabled (AllowHardReset)")), nil
+			}
+			if !g.config.AllowClean {
+				return returnErrorOutput(fmt.Errorf("prepare workspace aborted: cleaning untracked files is disabled (AllowClean)")), nil
+			}
+
+			steps := []workspaceStep{
+				{Step: "fetch", Args: []string{"fetch", "--", input.Remote}},
+				{Step: "checkout", Args: []string{"checkout", input.Branch, "--"}},
+				{Step: "reset", Args: []string{"reset", "--hard", input.Remote + "/" + input.Branch, "--"}},
+				{Step: "clean", Args: []string{"clean", "-fd"}},
+			}
+
+			// Every step is checked before the first runs, so that a rejected step leaves the
+			// repository untouched
+			for _, step := range steps {
+				if err := g.checkPolicy(step.Args[0], step.Args[1:]); err != nil {
+					return returnErrorOutput(err), nil
+				}
+			}
+
+			for i := range steps {
+				output, err := g.runGit(ctx, input.RepoPath, steps[i].Args...)
+				steps[i].Output = strings.TrimSpace(string(output))
+				steps[i].Success = err == nil
+
+				if err != nil {
+					g.logger.WithFields(map[string]interface{}{
+						goai.ErrorLogField: err,
+						"step":             steps[i].Step,
+						"output":           steps[i].Output,
+					}).Error("Prepare workspace step failed")
+
+					span.RecordError(err)
+					text, err := marshalResult(map[string]interface{}{"success": false, "steps": steps[:i+1]})
+					if err != nil {
+						return returnErrorOutput(err), nil
+					}
+
+					return goai.CallToolResult{
+						Content: []goai.ToolResultContent{{
+							Type: "json",
+							Text: text,
+						}},
+						IsError: true,
+					}, nil
+				}
+			}
+
+			g.logger.WithFields(map[string]interface{}{
+				"tool":   GitWorkspaceToolName,
+				"branch": input.Branch,
+			}).Info("Workspace prepared successfully")
+
+			text, err := marshalResult(map[string]interface{}{"success": true, "steps": steps})
+			if err != nil {
+				return returnErrorOutput(err), nil
+			}
+
+			return goai.CallToolResult{
+				Content: []goai.ToolResultContent{{
+					Type: "json",
+					Text: text,
+				}},
+			}, nil
+		},
+	}
+}
@@ -0,0 +1,150 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shaharia-lab/goai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGit_GitPrepareWorkspaceTool(t *testing.T) {
+	upstream := initTestRepo(t)
+	clone := filepath.Join(t.TempDir(), "clone")
+	runTestGit(t, upstream, "clone", upstream, clone)
+	runTestGit(t, clone, "config", "user.email", "test@example.com")
+	runTestGit(t, clone, "config", "user.name", "Test User")
+
+	// Advance the upstream, and leave local commits plus dirty and untracked files in the clone.
+	require.NoError(t, os.WriteFile(filepath.Join(upstream, "test.txt"), []byte("upstream change\n"), 0644))
+	runTestGit(t, upstream, "commit", "-am", "Upstream change")
+	require.NoError(t, os.WriteFile(filepath.Join(clone, "local.txt"), []byte("local\n"), 0644))
+	runTestGit(t, clone, "add", "local.txt")
+	runTestGit(t, clone, "commit", "-m", "Local change")
+	require.NoError(t, os.WriteFile(filepath.Join(clone, "test.txt"), []byte("dirty\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(clone, "untracked.txt"), []byte("untracked\n"), 0644))
+
+	git := NewGit(newPermissiveLogger(), GitConfig{AllowHardReset: true, AllowClean: true})
+	tool := git.GitPrepareWorkspaceTool()
+
+	args, err := json.Marshal(map[string]string{"repo_path": clone, "branch": "main"})
+	require.NoError(t, err)
+
+	result, err := tool.Handler(context.Background(), goai.CallToolParams{Name: GitWorkspaceToolName, Arguments: args})
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+
+	var response struct {
+		Success bool            `json:"success"`
+		Steps   []workspaceStep `json:"steps"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &response))
+	assert.True(t, response.Success)
+
+	var stepNames []string
+	for _, step := range response.Steps {
+		stepNames = append(stepNames, step.Step)
+		assert.True(t, step.Success)
+	}
+	assert.Equal(t, []string{"fetch", "checkout", "reset", "clean"}, stepNames)
+
+	content, err := os.ReadFile(filepath.Join(clone, "test.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "upstream change\n", string(content))
+	assert.NoFileExists(t, filepath.Join(clone, "local.txt"))
+	assert.NoFileExists(t, filepath.Join(clone, "untracked.txt"))
+}
+
+func TestGit_GitPrepareWorkspaceTool_GateDisabled(t *testing.T) {
+	repoPath := initTestRepo(t)
+	untracked := filepath.Join(repoPath, "untracked.txt")
+	require.NoError(t, os.WriteFile(untracked, []byte("untracked\n"), 0644))
+
+	tests := []struct {
+		name     string
+		config   GitConfig
+		expected string
+	}{
+		{
+			name:     "hard reset disabled",
+			config:   GitConfig{AllowClean: true},
+			expected: "AllowHardReset",
+		},
+		{
+			name:     "clean disabled",
+			config:   GitConfig{AllowHardReset: true},
+			expected: "AllowClean",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			git := NewGit(newPermissiveLogger(), tt.config)
+			tool := git.GitPrepareWorkspaceTool()
+
+			args, err := json.Marshal(map[string]string{"repo_path": repoPath, "branch": "main"})
+			require.NoError(t, err)
+
+			result, err := tool.Handler(context.Background(), goai.CallToolParams{Name: GitWorkspaceToolName, Arguments: args})
+			require.NoError(t, err)
+			assert.True(t, result.IsError)
+			assert.Contains(t, result.Content[0].Text, tt.expected)
+			assert.FileExists(t, untracked)
+		})
+	}
+}
+
+func TestGit_GitPrepareWorkspaceTool_RejectedInput(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   GitConfig
+		input    map[string]string
+		expected string
+	}{
+		{
+			name:     "remote looking like an option",
+			input:    map[string]string{"branch": "main", "remote": "--upload-pack=touch pwned;"},
+			expected: `invalid remote: "--upload-pack=touch pwned;"`,
+		},
+		{
+			name:     "branch looking like an option",
+			input:    map[string]string{"branch": "--orphan=x"},
+			expected: `invalid branch: "--orphan=x"`,
+		},
+		{
+			name:     "blocked step",
+			config:   GitConfig{BlockedCommands: []string{"fetch"}},
+			input:    map[string]string{"branch": "main"},
+			expected: `command "fetch" is blocked by policy`,
+		},
+		{
+			name:     "step outside of the allowed commands",
+			config:   GitConfig{AllowedCommands: []string{"fetch", "checkout", "reset"}},
+			input:    map[string]string{"branch": "main"},
+			expected: `command "clean" is not in the allowed commands`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockExecutor := new(MockCommandExecutor)
+			tt.config.AllowHardReset, tt.config.AllowClean = true, true
+			git := NewGit(newPermissiveLogger(), tt.config)
+			git.cmdExecutor = mockExecutor
+
+			tt.input["repo_path"] = "/repos/app"
+			args, err := json.Marshal(tt.input)
+			require.NoError(t, err)
+
+			result, err := git.GitPrepareWorkspaceTool().Handler(context.Background(), goai.CallToolParams{Name: GitWorkspaceToolName, Arguments: args})
+			require.NoError(t, err)
+			assert.True(t, result.IsError)
+			assert.Equal(t, tt.expected, result.Content[0].Text)
+			mockExecutor.AssertNotCalled(t, "ExecuteCommand")
+		})
+	}
+}
@@ -3,6 +3,11 @@ package mcptools
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
 
 	"github.com/google/go-github/v60/github"
 	"github.com/shaharia-lab/goai"
@@ -10,10 +15,24 @@ import (
 )
 
 const (
-	GitHubIssuesToolName       = "github_issues"
-	GitHubPullRequestsToolName = "github_pull_requests"
-	GitHubRepositoryToolName   = "github_repository"
-	GitHubSearchToolName       = "github_search"
+	GitHubIssuesToolName        = "github_issues"
+	GitHubPullRequestsToolName  = "github_pull_requests"
+	GitHubRepositoryToolName    = "github_repository"
+	GitHubSearchToolName        = "github_search"
+	GitHubWorkflowsToolName     = "github_workflows"
+	GitHubUserToolName          = "github_user"
+	GitHubDiscussionsToolName   = "github_discussions"
+	GitHubContentsToolName      = "github_contents"
+	GitHubReleasesToolName      = "github_releases"
+	GitHubCollaboratorsToolName = "github_collaborators"
+	GitHubTagsToolName          = "github_tags"
+	GitHubGistsToolName         = "github_gists"
+	GitHubStatusesToolName      = "github_statuses"
+	GitHubDeployKeysToolName    = "github_deploy_keys"
+	GitHubRateLimitToolName     = "github_rate_limit"
+	GitHubLabelsToolName        = "github_labels"
+	GitHubMilestonesToolName    = "github_milestones"
+	GitHubWebhooksToolName      = "github_webhooks"
 )
 
 // GitHub represents a wrapper around GitHub API client
@@ -25,29 +44,231 @@ type GitHub struct {
 
 type GitHubConfig struct {
 	Token string
+	// CloneProtocol selects the URL returned by the clone_url operation: "https" (default),
+	// with the token embedded for authentication, or "ssh".
+	CloneProtocol string
+	// RedactionRules scrub secrets from everything the tools log. Nil means DefaultRedactionRules.
+	RedactionRules []RedactionRule
+	// MaxAttempts is how many times an API request rejected by a rate limit is attempted in
+	// total. Zero means DefaultGitHubMaxAttempts; 1 disables retries.
+	MaxAttempts int
+	// BaseURL is the REST API root of a GitHub Enterprise Server instance, e.g.
+	// https://github.example.com/api/v3/. When empty the tools use github.com.
+	BaseURL string
+	// UploadURL is the upload API root of the GitHub Enterprise Server instance. When empty
+	// BaseURL is used.
+	UploadURL string
+	// DryRun makes the repository tool simulate every mutating operation, regardless of the
+	// dry_run input, so an agent can be evaluated without write access.
+	DryRun bool
 }
 
-// NewGitHubTool to perform operations on GitHub
+// NewGitHubTool to perform operations on GitHub. It panics when the configuration is invalid;
+// use NewGitHub to handle the error instead.
 func NewGitHubTool(logger goai.Logger, config GitHubConfig) *GitHub {
+	g, err := NewGitHub(logger, config)
+	if err != nil {
+		panic(err)
+	}
+	return g
+}
+
+// NewGitHub returns a GitHub to perform operations on github.com or, when config.BaseURL is
+// set, on a GitHub Enterprise Server instance. An invalid BaseURL or UploadURL is reported as
+// an error.
+func NewGitHub(logger goai.Logger, config GitHubConfig) (*GitHub, error) {
+	if err := validateEnterpriseURLs(config); err != nil {
+		return nil, err
+	}
+
 	ctx := context.Background()
 	ts := oauth2.StaticTokenSource(
 		&oauth2.Token{AccessToken: config.Token},
 	)
 	tc := oauth2.NewClient(ctx, ts)
+	logger = newRedactingLogger(logger, config.RedactionRules)
+	tc.Transport = newRateLimitTransport(tc.Transport, config.MaxAttempts, logger)
 	client := github.NewClient(tc)
+	if config.BaseURL != "" {
+		uploadURL := config.UploadURL
+		if uploadURL == "" {
+			uploadURL = config.BaseURL
+		}
+
+		var err error
+		if client, err = client.WithEnterpriseURLs(config.BaseURL, uploadURL); err != nil {
+			return nil, fmt.Errorf("invalid GitHub Enterprise URLs: %w", err)
+		}
+	}
 
 	return &GitHub{
 		client: client,
 		logger: logger,
 		config: config,
+	}, nil
+}
+
+// validateEnterpriseURLs checks that the GitHub Enterprise URLs of config, when set, are
+// absolute http or https URLs
+func validateEnterpriseURLs(config GitHubConfig) error {
+	if config.BaseURL == "" && config.UploadURL != "" {
+		return errors.New("GitHub UploadURL is set without a BaseURL")
 	}
+
+	if err := validateGitHubURL("BaseURL", config.BaseURL); err != nil {
+		return err
+	}
+	return validateGitHubURL("UploadURL", config.UploadURL)
 }
 
-// Helper function for JSON marshaling
-func mustMarshal(v interface{}) string {
-	b, err := json.Marshal(v)
+// validateGitHubURL checks that raw, when set, is an absolute http or https URL
+func validateGitHubURL(name, raw string) error {
+	if raw == "" {
+		return nil
+	}
+	u, err := url.Parse(raw)
 	if err != nil {
-		panic(err)
+		return fmt.Errorf("invalid GitHub %s %q: %w", name, raw, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid GitHub %s %q: must be an absolute http or https URL", name, raw)
+	}
+	return nil
+}
+
+// paginate calls list for successive pages until they are exhausted or maxItems results
+// have been collected. A non-positive maxItems means no cap.
+func paginate[T any](perPage, maxItems int, list func(opts github.ListOptions) ([]T, *github.Response, error)) ([]T, error) {
+	var all []T
+	opts := github.ListOptions{PerPage: perPage}
+	for {
+		items, resp, err := list(opts)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+
+		if maxItems > 0 && len(all) >= maxItems {
+			return all[:maxItems], nil
+		}
+		if resp == nil || resp.NextPage == 0 {
+			return all, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// requiredFields are the input fields an operation requires, in reporting order
+type requiredFields map[string][]string
+
+// validate checks that every field operation requires has a non-empty value in values and
+// reports the first missing one
+func (r requiredFields) validate(operation string, values map[string]string) error {
+	for _, field := range r[operation] {
+		if values[field] == "" {
+			return fmt.Errorf("%s is required for operation '%s'", field, operation)
+		}
+	}
+	return nil
+}
+
+// isNotFound reports whether err is a GitHub API error with a 404 status
+func isNotFound(err error) bool {
+	var errResp *github.ErrorResponse
+	return errors.As(err, &errResp) && errResp.Response != nil && errResp.Response.StatusCode == http.StatusNotFound
+}
+
+// graphQLError is an error entry of a GitHub GraphQL response
+type graphQLError struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+// graphQL executes a GitHub GraphQL query and decodes its data into out
+func (g *GitHub) graphQL(ctx context.Context, query string, variables map[string]interface{}, out interface{}) error {
+	// The GraphQL endpoint lives next to the REST API root: /graphql on github.com and
+	// /api/graphql on GitHub Enterprise, whose REST root is /api/v3/
+	endpoint := "graphql"
+	if strings.HasSuffix(g.client.BaseURL.Path, "/api/v3/") {
+		endpoint = "../graphql"
+	}
+
+	req, err := g.client.NewRequest(http.MethodPost, endpoint, map[string]interface{}{
+		"query":     query,
+		"variables": variables,
+	})
+	if err != nil {
+		return err
+	}
+
+	var resp struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []graphQLError  `json:"errors"`
+	}
+	if _, err := g.client.Do(ctx, req, &resp); err != nil {
+		return err
+	}
+
+	if len(resp.Errors) > 0 {
+		messages := make([]string, len(resp.Errors))
+		for i, e := range resp.Errors {
+			messages[i] = e.Message
+		}
+		return fmt.Errorf("graphql error: %s", strings.Join(messages, "; "))
+	}
+
+	return json.Unmarshal(resp.Data, out)
+}
+
+// isForbidden reports whether err is a GitHub API error with a 403 status
+func isForbidden(err error) bool {
+	var errResp *github.ErrorResponse
+	return errors.As(err, &errResp) && errResp.Response != nil && errResp.Response.StatusCode == http.StatusForbidden
+}
+
+// isUnprocessable reports whether err is a GitHub API validation error (422)
+func isUnprocessable(err error) bool {
+	var errResp *github.ErrorResponse
+	return errors.As(err, &errResp) && errResp.Response != nil && errResp.Response.StatusCode == http.StatusUnprocessableEntity
+}
+
+// isAlreadyExists reports whether err is a 422 GitHub API error rejecting a resource that
+// already exists
+func isAlreadyExists(err error) bool {
+	var errResp *github.ErrorResponse
+	if !isUnprocessable(err) || !errors.As(err, &errResp) {
+		return false
+	}
+	for _, e := range errResp.Errors {
+		if e.Code == "already_exists" {
+			return true
+		}
+	}
+	return false
+}
+
+// describeGitHubError returns a readable message for a GitHub API error, including the
+// individual validation messages GitHub attaches to 422 responses.
+func describeGitHubError(err error) string {
+	var errResp *github.ErrorResponse
+	if !errors.As(err, &errResp) {
+		return err.Error()
+	}
+
+	details := make([]string, 0, len(errResp.Errors))
+	for _, e := range errResp.Errors {
+		switch {
+		case e.Message != "":
+			details = append(details, e.Message)
+		case e.Field != "":
+			details = append(details, fmt.Sprintf("%s %s is %s", e.Resource, e.Field, e.Code))
+		case e.Code != "":
+			details = append(details, e.Code)
+		}
+	}
+
+	if len(details) == 0 {
+		return errResp.Message
 	}
-	return string(b)
+	return fmt.Sprintf("%s: %s", errResp.Message, strings.Join(details, "; "))
 }
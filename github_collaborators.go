@@ -0,0 +1,222 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/shaharia-lab/goai"
+)
+
+// collaboratorPermissions are the permission levels a collaborator can be granted
+var collaboratorPermissions = map[string]bool{
+	"pull":     true,
+	"triage":   true,
+	"push":     true,
+	"maintain": true,
+	"admin":    true,
+}
+
+// collaboratorSummary is the collaborator information reported by the collaborators tool
+type collaboratorSummary struct {
+	Login      string `json:"login"`
+	RoleName   string `json:"role_name,omitempty"`
+	Permission string `json:"permission,omitempty"`
+}
+
+// collaboratorChange is the outcome of adding or removing a collaborator
+type collaboratorChange struct {
+	Username     string `json:"username"`
+	Status       string `json:"status"`
+	Permission   string `json:"permission,omitempty"`
+	InvitationID int64  `json:"invitation_id,omitempty"`
+	Message      string `json:"message,omitempty"`
+}
+
+// GetCollaboratorTool returns a tool for managing the collaborators of GitHub repositories
+func (g *GitHub) GetCollaboratorTool() goai.Tool {
+	return goai.Tool{
+		Name:        GitHubCollaboratorsToolName,
+		Description: "Manages GitHub repository collaborators - list, add, remove, check permission",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"operation": {
+					"type": "string",
+					"enum": ["list", "add", "remove", "check_permission"],
+					"description": "Collaborator operation to perform"
+				},
+				"owner": {
+					"type": "string",
+					"description": "Repository owner"
+				},
+				"repo": {
+					"type": "string",
+					"description": "Repository name"
+				},
+				"username": {
+					"type": "string",
+					"description": "Login of the collaborator for add, remove and check_permission"
+				},
+				"permission": {
+					"type": "string",
+					"enum": ["pull", "triage", "push", "maintain", "admin"],
+					"description": "Permission granted by add (default push)"
+				},
+				"per_page": {
+					"type": "integer",
+					"description": "Page size used when listing collaborators (max 100)"
+				},
+				"max_results": {
+					"type": "integer",
+					"description": "Maximum number of collaborators listed (default 100)"
+				}
+			},
+			"required": ["operation", "owner", "repo"]
+		}`),
+		Handler: g.handleCollaboratorOperation,
+	}
+}
+
+func (g *GitHub) handleCollaboratorOperation(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
+	ctx, span := goai.StartSpan(ctx, fmt.Sprintf("%s.Handler", params.Name))
+	defer span.End()
+
+	g.logger.WithFields(map[string]interface{}{
+		"tool":          params.Name,
+		"tool_argument": string(params.Arguments),
+	}).Info("handling collaborator operation")
+
+	var input struct {
+		Operation  string `json:"operation"`
+		Owner      string `json:"owner"`
+		Repo       string `json:"repo"`
+		Username   string `json:"username"`
+		Permission string `json:"permission"`
+		PerPage    int    `json:"per_page"`
+		MaxResults int    `json:"max_results"`
+	}
+
+	if err := json.Unmarshal(params.Arguments, &input); err != nil {
+		return goai.CallToolResult{}, fmt.Errorf("failed to unmarshal input: %w", err)
+	}
+
+	if input.Operation != "list" && input.Username == "" {
+		return returnErrorOutput(fmt.Errorf("username is required for %s", input.Operation)), nil
+	}
+
+	var result interface{}
+	var err error
+
+	switch input.Operation {
+	case "list":
+		result, err = g.listCollaborators(ctx, input.Owner, input.Repo, input.PerPage, input.MaxResults)
+	case "add":
+		if input.Permission != "" && !collaboratorPermissions[input.Permission] {
+			return returnErrorOutput(fmt.Errorf("unsupported permission %q: must be one of pull, triage, push, maintain or admin", input.Permission)), nil
+		}
+		result, err = g.addCollaborator(ctx, input.Owner, input.Repo, input.Username, input.Permission)
+	case "remove":
+		result, err = g.removeCollaborator(ctx, input.Owner, input.Repo, input.Username)
+	case "check_permission":
+		var level *github.RepositoryPermissionLevel
+		level, _, err = g.client.Repositories.GetPermissionLevel(ctx, input.Owner, input.Repo, input.Username)
+		if err == nil {
+			result = collaboratorSummary{
+				Login:      input.Username,
+				RoleName:   level.GetUser().GetRoleName(),
+				Permission: level.GetPermission(),
+			}
+		}
+	default:
+		return returnErrorOutput(fmt.Errorf("unsupported operation: %s", input.Operation)), nil
+	}
+
+	if err != nil {
+		g.logger.WithFields(map[string]interface{}{
+			"tool":             params.Name,
+			goai.ErrorLogField: err,
+			"operation":        input.Operation,
+		}).Error("GitHub collaborator operation failed")
+
+		return returnErrorOutput(fmt.Errorf("github collaborator %s error: %w", input.Operation, err)), nil
+	}
+
+	m, err := marshalResult(result)
+	if err != nil {
+		return returnErrorOutput(err), nil
+	}
+	g.logger.WithFields(map[string]interface{}{
+		"tool":          params.Name,
+		"operation":     input.Operation,
+		"result_length": len(m),
+	}).Info("GitHub collaborator operation completed successfully")
+
+	return goai.CallToolResult{
+		Content: []goai.ToolResultContent{{
+			Type: "json",
+			Text: m,
+		}},
+	}, nil
+}
+
+// listCollaborators pages through the collaborators of a repository up to maxResults
+func (g *GitHub) listCollaborators(ctx context.Context, owner, repo string, perPage, maxResults int) ([]collaboratorSummary, error) {
+	users, err := paginate(listPageSize(perPage), listResultCap(maxResults), func(opts github.ListOptions) ([]*github.User, *github.Response, error) {
+		return g.client.Repositories.ListCollaborators(ctx, owner, repo, &github.ListCollaboratorsOptions{ListOptions: opts})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make([]collaboratorSummary, 0, len(users))
+	for _, user := range users {
+		summaries = append(summaries, collaboratorSummary{
+			Login:    user.GetLogin(),
+			RoleName: user.GetRoleName(),
+		})
+	}
+	return summaries, nil
+}
+
+// addCollaborator grants username access to the repository. GitHub invites users who are not
+// yet collaborators, in which case the invitation ID is reported; existing collaborators have
+// their permission updated directly.
+func (g *GitHub) addCollaborator(ctx context.Context, owner, repo, username, permission string) (*collaboratorChange, error) {
+	var opts *github.RepositoryAddCollaboratorOptions
+	if permission != "" {
+		opts = &github.RepositoryAddCollaboratorOptions{Permission: permission}
+	}
+
+	invitation, resp, err := g.client.Repositories.AddCollaborator(ctx, owner, repo, username, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	change := &collaboratorChange{Username: username, Permission: permission, Status: "updated"}
+	if resp != nil && resp.StatusCode == http.StatusCreated && invitation != nil {
+		change.Status = "invited"
+		change.InvitationID = invitation.GetID()
+		change.Permission = invitation.GetPermissions()
+	}
+	return change, nil
+}
+
+// removeCollaborator revokes the access of username. Removing a user who is not a
+// collaborator is reported as a no-op rather than an error.
+func (g *GitHub) removeCollaborator(ctx context.Context, owner, repo, username string) (*collaboratorChange, error) {
+	_, err := g.client.Repositories.RemoveCollaborator(ctx, owner, repo, username)
+	if isNotFound(err) {
+		return &collaboratorChange{
+			Username: username,
+			Status:   "unchanged",
+			Message:  fmt.Sprintf("%s is not a collaborator of %s/%s, nothing to remove", username, owner, repo),
+		}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &collaboratorChange{Username: username, Status: "removed"}, nil
+}
@@ -0,0 +1,224 @@
+package mcptools
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetCollaboratorTool(t *testing.T) {
+	gh := &GitHub{client: github.NewClient(nil), logger: &MockLogger{}}
+
+	tool := gh.GetCollaboratorTool()
+
+	assert.Equal(t, GitHubCollaboratorsToolName, tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.NotNil(t, tool.Handler)
+
+	var schema map[string]interface{}
+	require.NoError(t, json.Unmarshal(tool.InputSchema, &schema))
+	assert.Equal(t, []interface{}{"operation", "owner", "repo"}, schema["required"])
+}
+
+func TestHandleCollaboratorOperation_List(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/collaborators", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+
+		var users []*github.User
+		switch r.URL.Query().Get("page") {
+		case "", "1":
+			w.Header().Set("Link", `<`+server.URL+`/repos/test-owner/test-repo/collaborators?page=2>; rel="next"`)
+			users = []*github.User{{Login: github.String("alice"), RoleName: github.String("admin")}}
+		case "2":
+			users = []*github.User{{Login: github.String("bob"), RoleName: github.String("write")}}
+		}
+		assert.NoError(t, json.NewEncoder(w).Encode(users))
+	})
+
+	result := callGitHubHandler(t, gh.handleCollaboratorOperation, GitHubCollaboratorsToolName, map[string]interface{}{
+		"operation": "list",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+	})
+	require.False(t, result.IsError, result.Content)
+
+	var collaborators []collaboratorSummary
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &collaborators))
+	assert.Equal(t, []collaboratorSummary{
+		{Login: "alice", RoleName: "admin"},
+		{Login: "bob", RoleName: "write"},
+	}, collaborators)
+}
+
+func TestHandleCollaboratorOperation_Add(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		expected collaboratorChange
+	}{
+		{
+			name:     "invites a new collaborator",
+			status:   http.StatusCreated,
+			expected: collaboratorChange{Username: "carol", Status: "invited", Permission: "triage", InvitationID: 42},
+		},
+		{
+			name:     "updates an existing collaborator",
+			status:   http.StatusNoContent,
+			expected: collaboratorChange{Username: "carol", Status: "updated", Permission: "triage"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gh, server, cleanup := setupGitHubTest(t)
+			gh.logger = newPermissiveLogger()
+			defer cleanup()
+
+			mux := http.NewServeMux()
+			server.Config.Handler = mux
+
+			mux.HandleFunc("/repos/test-owner/test-repo/collaborators/carol", func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "PUT", r.Method)
+
+				var body github.RepositoryAddCollaboratorOptions
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				assert.Equal(t, "triage", body.Permission)
+
+				w.WriteHeader(tt.status)
+				if tt.status == http.StatusCreated {
+					assert.NoError(t, json.NewEncoder(w).Encode(&github.CollaboratorInvitation{
+						ID:          github.Int64(42),
+						Permissions: github.String("triage"),
+					}))
+				}
+			})
+
+			result := callGitHubHandler(t, gh.handleCollaboratorOperation, GitHubCollaboratorsToolName, map[string]interface{}{
+				"operation":  "add",
+				"owner":      "test-owner",
+				"repo":       "test-repo",
+				"username":   "carol",
+				"permission": "triage",
+			})
+			require.False(t, result.IsError, result.Content)
+
+			var change collaboratorChange
+			require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &change))
+			assert.Equal(t, tt.expected, change)
+		})
+	}
+}
+
+func TestHandleCollaboratorOperation_Remove(t *testing.T) {
+	tests := []struct {
+		name           string
+		status         int
+		expectedStatus string
+	}{
+		{name: "removes a collaborator", status: http.StatusNoContent, expectedStatus: "removed"},
+		{name: "not a collaborator", status: http.StatusNotFound, expectedStatus: "unchanged"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gh, server, cleanup := setupGitHubTest(t)
+			gh.logger = newPermissiveLogger()
+			defer cleanup()
+
+			mux := http.NewServeMux()
+			server.Config.Handler = mux
+
+			mux.HandleFunc("/repos/test-owner/test-repo/collaborators/dave", func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "DELETE", r.Method)
+				w.WriteHeader(tt.status)
+			})
+
+			result := callGitHubHandler(t, gh.handleCollaboratorOperation, GitHubCollaboratorsToolName, map[string]interface{}{
+				"operation": "remove",
+				"owner":     "test-owner",
+				"repo":      "test-repo",
+				"username":  "dave",
+			})
+			require.False(t, result.IsError, result.Content)
+
+			var change collaboratorChange
+			require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &change))
+			assert.Equal(t, tt.expectedStatus, change.Status)
+			if tt.status == http.StatusNotFound {
+				assert.Equal(t, "dave is not a collaborator of test-owner/test-repo, nothing to remove", change.Message)
+			}
+		})
+	}
+}
+
+func TestHandleCollaboratorOperation_CheckPermission(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/collaborators/alice/permission", func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, json.NewEncoder(w).Encode(&github.RepositoryPermissionLevel{
+			Permission: github.String("write"),
+			User:       &github.User{Login: github.String("alice"), RoleName: github.String("maintain")},
+		}))
+	})
+
+	result := callGitHubHandler(t, gh.handleCollaboratorOperation, GitHubCollaboratorsToolName, map[string]interface{}{
+		"operation": "check_permission",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+		"username":  "alice",
+	})
+	require.False(t, result.IsError, result.Content)
+	assert.JSONEq(t, `{"login": "alice", "role_name": "maintain", "permission": "write"}`, result.Content[0].Text)
+}
+
+func TestHandleCollaboratorOperation_InvalidInput(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    map[string]interface{}
+		expected string
+	}{
+		{
+			name:     "missing username",
+			input:    map[string]interface{}{"operation": "remove", "owner": "o", "repo": "r"},
+			expected: "username is required for remove",
+		},
+		{
+			name:     "unsupported permission",
+			input:    map[string]interface{}{"operation": "add", "owner": "o", "repo": "r", "username": "u", "permission": "write"},
+			expected: `unsupported permission "write": must be one of pull, triage, push, maintain or admin`,
+		},
+		{
+			name:     "unsupported operation",
+			input:    map[string]interface{}{"operation": "transfer", "owner": "o", "repo": "r", "username": "u"},
+			expected: "unsupported operation: transfer",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gh, _, cleanup := setupGitHubTest(t)
+			gh.logger = newPermissiveLogger()
+			defer cleanup()
+
+			result := callGitHubHandler(t, gh.handleCollaboratorOperation, GitHubCollaboratorsToolName, tt.input)
+			assert.True(t, result.IsError)
+			assert.Equal(t, tt.expected, result.Content[0].Text)
+		})
+	}
+}
@@ -0,0 +1,298 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/shaharia-lab/goai"
+)
+
+// contentFile is a decoded file returned by the get operation
+type contentFile struct {
+	Type    string `json:"type"`
+	Path    string `json:"path"`
+	SHA     string `json:"sha"`
+	Size    int    `json:"size"`
+	Content string `json:"content"`
+}
+
+// contentEntry is a single entry of a directory listing
+type contentEntry struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+	Type string `json:"type"`
+	Size int    `json:"size"`
+	SHA  string `json:"sha"`
+}
+
+// contentDirectory is a directory listing returned by the get operation
+type contentDirectory struct {
+	Type    string         `json:"type"`
+	Path    string         `json:"path"`
+	Entries []contentEntry `json:"entries"`
+}
+
+// contentWriteResult describes the outcome of a create, update or delete operation
+type contentWriteResult struct {
+	Path      string `json:"path"`
+	SHA       string `json:"sha,omitempty"`
+	CommitSHA string `json:"commit_sha"`
+	CommitURL string `json:"commit_url,omitempty"`
+}
+
+// GetContentsTool returns a tool for reading and writing files in a GitHub repository
+func (g *GitHub) GetContentsTool() goai.Tool {
+	return goai.Tool{
+		Name:        GitHubContentsToolName,
+		Description: "Reads, creates, updates and deletes files in a GitHub repository",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"operation": {
+					"type": "string",
+					"enum": ["get", "create", "update", "delete"],
+					"description": "Operation to perform"
+				},
+				"owner": {
+					"type": "string",
+					"description": "Repository owner"
+				},
+				"repo": {
+					"type": "string",
+					"description": "Repository name"
+				},
+				"path": {
+					"type": "string",
+					"description": "Path of the file or directory in the repository"
+				},
+				"content": {
+					"type": "string",
+					"description": "Plain text file content for create and update"
+				},
+				"message": {
+					"type": "string",
+					"description": "Commit message for create, update and delete"
+				},
+				"branch": {
+					"type": "string",
+					"description": "Branch to read from or commit to (defaults to the default branch)"
+				},
+				"sha": {
+					"type": "string",
+					"description": "Blob SHA of the file being updated or deleted; fetched automatically when omitted"
+				}
+			},
+			"required": ["operation", "owner", "repo", "path"]
+		}`),
+		Handler: g.handleContentsOperation,
+	}
+}
+
+func (g *GitHub) handleContentsOperation(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
+	ctx, span := goai.StartSpan(ctx, fmt.Sprintf("%s.Handler", params.Name))
+	defer span.End()
+
+	g.logger.WithFields(map[string]interface{}{
+		"tool":          params.Name,
+		"tool_argument": string(params.Arguments),
+	}).Info("handling contents operation")
+
+	var input struct {
+		Operation string `json:"operation"`
+		Owner     string `json:"owner"`
+		Repo      string `json:"repo"`
+		Path      string `json:"path"`
+		Content   string `json:"content"`
+		Message   string `json:"message"`
+		Branch    string `json:"branch"`
+		SHA       string `json:"sha"`
+	}
+
+	if err := json.Unmarshal(params.Arguments, &input); err != nil {
+		return goai.CallToolResult{}, fmt.Errorf("failed to unmarshal input: %w", err)
+	}
+
+	if input.Path == "" {
+		return returnErrorOutput(fmt.Errorf("path is required")), nil
+	}
+	if input.Operation != "get" && input.Message == "" {
+		return returnErrorOutput(fmt.Errorf("message is required for %s", input.Operation)), nil
+	}
+
+	var result interface{}
+	var err error
+
+	switch input.Operation {
+	case "get":
+		result, err = g.getContents(ctx, input.Owner, input.Repo, input.Path, input.Branch)
+
+	case "create":
+		var resp *github.RepositoryContentResponse
+		resp, _, err = g.client.Repositories.CreateFile(ctx, input.Owner, input.Repo, input.Path, &github.RepositoryContentFileOptions{
+			Message: &input.Message,
+			Content: []byte(input.Content),
+			Branch:  optionalString(input.Branch),
+		})
+		if err == nil {
+			result = newContentWriteResult(input.Path, resp)
+		}
+
+	case "update":
+		result, err = g.writeWithSHA(ctx, input.Owner, input.Repo, input.Path, input.Branch, input.SHA, func(sha string) (*github.RepositoryContentResponse, error) {
+			resp, _, err := g.client.Repositories.UpdateFile(ctx, input.Owner, input.Repo, input.Path, &github.RepositoryContentFileOptions{
+				Message: &input.Message,
+				Content: []byte(input.Content),
+				Branch:  optionalString(input.Branch),
+				SHA:     &sha,
+			})
+			return resp, err
+		})
+
+	case "delete":
+		result, err = g.writeWithSHA(ctx, input.Owner, input.Repo, input.Path, input.Branch, input.SHA, func(sha string) (*github.RepositoryContentResponse, error) {
+			resp, _, err := g.client.Repositories.DeleteFile(ctx, input.Owner, input.Repo, input.Path, &github.RepositoryContentFileOptions{
+				Message: &input.Message,
+				Branch:  optionalString(input.Branch),
+				SHA:     &sha,
+			})
+			return resp, err
+		})
+
+	default:
+		return returnErrorOutput(fmt.Errorf("unsupported operation: %s", input.Operation)), nil
+	}
+
+	if err != nil {
+		g.logger.WithFields(map[string]interface{}{
+			"tool":             params.Name,
+			goai.ErrorLogField: err,
+			"operation":        input.Operation,
+			"path":             input.Path,
+		}).Error("GitHub contents operation failed")
+
+		if isNotFound(err) {
+			return returnErrorOutput(fmt.Errorf("path %q not found in %s/%s", input.Path, input.Owner, input.Repo)), nil
+		}
+		if isStaleSHA(err) {
+			return returnErrorOutput(fmt.Errorf("path %q in %s/%s has changed since its sha was read; get the file again before writing: %w", input.Path, input.Owner, input.Repo, err)), nil
+		}
+		return returnErrorOutput(fmt.Errorf("github contents %s error: %w", input.Operation, err)), nil
+	}
+
+	m, err := marshalResult(result)
+	if err != nil {
+		return returnErrorOutput(err), nil
+	}
+	g.logger.WithFields(map[string]interface{}{
+		"tool":          params.Name,
+		"operation":     input.Operation,
+		"path":          input.Path,
+		"result_length": len(m),
+	}).Info("GitHub contents operation completed successfully")
+
+	return goai.CallToolResult{
+		Content: []goai.ToolResultContent{{
+			Type: "json",
+			Text: m,
+		}},
+	}, nil
+}
+
+// getContents returns the decoded file at path, or the entries of the directory at path
+func (g *GitHub) getContents(ctx context.Context, owner, repo, path, ref string) (interface{}, error) {
+	file, dir, _, err := g.client.Repositories.GetContents(ctx, owner, repo, path, &github.RepositoryContentGetOptions{Ref: ref})
+	if err != nil {
+		return nil, err
+	}
+
+	if file == nil {
+		entries := make([]contentEntry, 0, len(dir))
+		for _, entry := range dir {
+			entries = append(entries, contentEntry{
+				Name: entry.GetName(),
+				Path: entry.GetPath(),
+				Type: entry.GetType(),
+				Size: entry.GetSize(),
+				SHA:  entry.GetSHA(),
+			})
+		}
+		return contentDirectory{Type: "dir", Path: path, Entries: entries}, nil
+	}
+
+	content, err := file.GetContent()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+
+	return contentFile{
+		Type:    file.GetType(),
+		Path:    file.GetPath(),
+		SHA:     file.GetSHA(),
+		Size:    file.GetSize(),
+		Content: content,
+	}, nil
+}
+
+// fileSHA returns the blob SHA of the file at path
+func (g *GitHub) fileSHA(ctx context.Context, owner, repo, path, ref string) (string, error) {
+	file, _, _, err := g.client.Repositories.GetContents(ctx, owner, repo, path, &github.RepositoryContentGetOptions{Ref: ref})
+	if err != nil {
+		return "", err
+	}
+	if file == nil {
+		return "", fmt.Errorf("%s is a directory", path)
+	}
+	return file.GetSHA(), nil
+}
+
+// writeWithSHA runs a write that requires the current blob SHA of the file. A missing SHA is
+// fetched before writing. A supplied SHA is used as is, so a write based on a stale SHA fails
+// with a conflict instead of overwriting a change made in the meantime.
+func (g *GitHub) writeWithSHA(ctx context.Context, owner, repo, path, branch, sha string, write func(sha string) (*github.RepositoryContentResponse, error)) (*contentWriteResult, error) {
+	if sha == "" {
+		var err error
+		if sha, err = g.fileSHA(ctx, owner, repo, path, branch); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := write(sha)
+	if err != nil {
+		return nil, err
+	}
+
+	return newContentWriteResult(path, resp), nil
+}
+
+// isStaleSHA reports whether err is GitHub rejecting a write because the supplied SHA does
+// not match the current blob
+func isStaleSHA(err error) bool {
+	var errResp *github.ErrorResponse
+	return errors.As(err, &errResp) && errResp.Response != nil && errResp.Response.StatusCode == http.StatusConflict
+}
+
+// newContentWriteResult summarizes the response of a contents write
+func newContentWriteResult(path string, resp *github.RepositoryContentResponse) *contentWriteResult {
+	result := &contentWriteResult{Path: path}
+	if resp == nil {
+		return result
+	}
+	if resp.Content != nil {
+		result.SHA = resp.Content.GetSHA()
+	}
+	result.CommitSHA = resp.Commit.GetSHA()
+	result.CommitURL = resp.Commit.GetHTMLURL()
+	return result
+}
+
+// optionalString returns nil for an empty string so it is omitted from API requests
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,237 @@
+package mcptools
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetContentsTool(t *testing.T) {
+	gh := &GitHub{
+		client: github.NewClient(nil),
+		logger: &MockLogger{},
+	}
+
+	tool := gh.GetContentsTool()
+
+	assert.Equal(t, GitHubContentsToolName, tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.NotNil(t, tool.Handler)
+}
+
+func TestHandleContentsOperation_GetFile(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/contents/README.md", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "dev", r.URL.Query().Get("ref"))
+		assert.NoError(t, json.NewEncoder(w).Encode(&github.RepositoryContent{
+			Type:     github.String("file"),
+			Path:     github.String("README.md"),
+			SHA:      github.String("abc123"),
+			Size:     github.Int(6),
+			Encoding: github.String("base64"),
+			Content:  github.String(base64.StdEncoding.EncodeToString([]byte("hello\n"))),
+		}))
+	})
+
+	result := callGitHubHandler(t, gh.handleContentsOperation, GitHubContentsToolName, map[string]interface{}{
+		"operation": "get",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+		"path":      "README.md",
+		"branch":    "dev",
+	})
+	require.False(t, result.IsError, result.Content)
+
+	var file contentFile
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &file))
+	assert.Equal(t, "hello\n", file.Content)
+	assert.Equal(t, "abc123", file.SHA)
+}
+
+func TestHandleContentsOperation_GetDirectory(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/contents/docs", func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, json.NewEncoder(w).Encode([]*github.RepositoryContent{
+			{Name: github.String("guide.md"), Path: github.String("docs/guide.md"), Type: github.String("file"), Size: github.Int(10)},
+			{Name: github.String("images"), Path: github.String("docs/images"), Type: github.String("dir")},
+		}))
+	})
+
+	result := callGitHubHandler(t, gh.handleContentsOperation, GitHubContentsToolName, map[string]interface{}{
+		"operation": "get",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+		"path":      "docs",
+	})
+	require.False(t, result.IsError, result.Content)
+
+	var dir contentDirectory
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &dir))
+	assert.Equal(t, "dir", dir.Type)
+	require.Len(t, dir.Entries, 2)
+	assert.Equal(t, "docs/guide.md", dir.Entries[0].Path)
+	assert.Equal(t, "dir", dir.Entries[1].Type)
+}
+
+func TestHandleContentsOperation_Create(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/contents/new.txt", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+
+		var body map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("new file")), body["content"])
+		assert.Equal(t, "Add new.txt", body["message"])
+		assert.NotContains(t, body, "sha")
+
+		assert.NoError(t, json.NewEncoder(w).Encode(&github.RepositoryContentResponse{
+			Content: &github.RepositoryContent{SHA: github.String("blob1")},
+			Commit:  github.Commit{SHA: github.String("commit1")},
+		}))
+	})
+
+	result := callGitHubHandler(t, gh.handleContentsOperation, GitHubContentsToolName, map[string]interface{}{
+		"operation": "create",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+		"path":      "new.txt",
+		"content":   "new file",
+		"message":   "Add new.txt",
+	})
+	require.False(t, result.IsError, result.Content)
+
+	var written contentWriteResult
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &written))
+	assert.Equal(t, "blob1", written.SHA)
+	assert.Equal(t, "commit1", written.CommitSHA)
+}
+
+func TestHandleContentsOperation_UpdateFetchesMissingSHA(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/contents/README.md", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			assert.NoError(t, json.NewEncoder(w).Encode(&github.RepositoryContent{
+				Type: github.String("file"),
+				SHA:  github.String("current-sha"),
+			}))
+		case "PUT":
+			var body map[string]interface{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "current-sha", body["sha"])
+
+			assert.NoError(t, json.NewEncoder(w).Encode(&github.RepositoryContentResponse{
+				Content: &github.RepositoryContent{SHA: github.String("new-sha")},
+				Commit:  github.Commit{SHA: github.String("commit2")},
+			}))
+		}
+	})
+
+	result := callGitHubHandler(t, gh.handleContentsOperation, GitHubContentsToolName, map[string]interface{}{
+		"operation": "update",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+		"path":      "README.md",
+		"content":   "updated",
+		"message":   "Update README",
+	})
+	require.False(t, result.IsError, result.Content)
+
+	var written contentWriteResult
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &written))
+	assert.Equal(t, "new-sha", written.SHA)
+}
+
+func TestHandleContentsOperation_UpdateStaleSHA(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	var shas []string
+	mux.HandleFunc("/repos/test-owner/test-repo/contents/README.md", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			assert.NoError(t, json.NewEncoder(w).Encode(&github.RepositoryContent{Type: github.String("file"), SHA: github.String("current-sha")}))
+			return
+		}
+
+		var body map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		shas = append(shas, body["sha"].(string))
+		if body["sha"] != "current-sha" {
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"message": "README.md does not match stale-sha"}`))
+			return
+		}
+		assert.NoError(t, json.NewEncoder(w).Encode(&github.RepositoryContentResponse{Commit: github.Commit{SHA: github.String("commit3")}}))
+	})
+
+	result := callGitHubHandler(t, gh.handleContentsOperation, GitHubContentsToolName, map[string]interface{}{
+		"operation": "update",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+		"path":      "README.md",
+		"content":   "updated",
+		"message":   "Update README",
+		"sha":       "stale-sha",
+	})
+	assert.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].Text, `path "README.md" in test-owner/test-repo has changed since its sha was read`)
+	assert.Equal(t, []string{"stale-sha"}, shas, "a supplied sha is never replaced by a fetched one")
+}
+
+func TestHandleContentsOperation_DeleteMissingFile(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/contents/gone.txt", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+	})
+
+	result := callGitHubHandler(t, gh.handleContentsOperation, GitHubContentsToolName, map[string]interface{}{
+		"operation": "delete",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+		"path":      "gone.txt",
+		"message":   "Remove gone.txt",
+	})
+
+	assert.True(t, result.IsError)
+	assert.Equal(t, `path "gone.txt" not found in test-owner/test-repo`, result.Content[0].Text)
+}
@@ -0,0 +1,209 @@
+package mcptools
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/shaharia-lab/goai"
+)
+
+// deployKeyRequiredFields are the fields each deploy key operation requires
+var deployKeyRequiredFields = requiredFields{
+	"list":   {"owner", "repo"},
+	"create": {"owner", "repo", "title", "key"},
+	"delete": {"owner", "repo"},
+}
+
+// deployKeySummary is the deploy key information reported by the deploy keys tool
+type deployKeySummary struct {
+	ID          int64  `json:"id"`
+	Title       string `json:"title"`
+	Fingerprint string `json:"fingerprint,omitempty"`
+	ReadOnly    bool   `json:"read_only"`
+	Verified    bool   `json:"verified"`
+	CreatedAt   string `json:"created_at,omitempty"`
+}
+
+// GetDeployKeyTool returns a tool for managing the deploy keys of GitHub repositories
+func (g *GitHub) GetDeployKeyTool() goai.Tool {
+	return goai.Tool{
+		Name:        GitHubDeployKeysToolName,
+		Description: "Manages GitHub repository deploy keys - list, create, delete",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"operation": {
+					"type": "string",
+					"enum": ["list", "create", "delete"],
+					"description": "Deploy key operation to perform"
+				},
+				"owner": {
+					"type": "string",
+					"description": "Repository owner"
+				},
+				"repo": {
+					"type": "string",
+					"description": "Repository name"
+				},
+				"key_id": {
+					"type": "integer",
+					"description": "ID of the deploy key to delete"
+				},
+				"title": {
+					"type": "string",
+					"description": "Name of the deploy key for create"
+				},
+				"key": {
+					"type": "string",
+					"description": "Public SSH key for create, such as the contents of id_ed25519.pub"
+				},
+				"read_only": {
+					"type": "boolean",
+					"description": "Whether the key can only read the repository (default true)"
+				}
+			},
+			"required": ["operation", "owner", "repo"]
+		}`),
+		Handler: g.handleDeployKeyOperation,
+	}
+}
+
+func (g *GitHub) handleDeployKeyOperation(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
+	ctx, span := goai.StartSpan(ctx, fmt.Sprintf("%s.Handler", params.Name))
+	defer span.End()
+
+	g.logger.WithFields(map[string]interface{}{
+		"tool":          params.Name,
+		"tool_argument": string(params.Arguments),
+	}).Info("handling deploy key operation")
+
+	var input struct {
+		Operation string `json:"operation"`
+		Owner     string `json:"owner"`
+		Repo      string `json:"repo"`
+		KeyID     int64  `json:"key_id"`
+		Title     string `json:"title"`
+		Key       string `json:"key"`
+		ReadOnly  *bool  `json:"read_only"`
+	}
+
+	if err := json.Unmarshal(params.Arguments, &input); err != nil {
+		return goai.CallToolResult{}, fmt.Errorf("failed to unmarshal input: %w", err)
+	}
+
+	if err := deployKeyRequiredFields.validate(input.Operation, map[string]string{
+		"owner": input.Owner,
+		"repo":  input.Repo,
+		"title": input.Title,
+		"key":   input.Key,
+	}); err != nil {
+		return returnErrorOutput(err), nil
+	}
+
+	var result interface{}
+	var err error
+
+	switch input.Operation {
+	case "list":
+		var keys []*github.Key
+		keys, err = paginate(listPageSize(0), listResultCap(0), func(opts github.ListOptions) ([]*github.Key, *github.Response, error) {
+			return g.client.Repositories.ListKeys(ctx, input.Owner, input.Repo, &opts)
+		})
+		summaries := make([]deployKeySummary, 0, len(keys))
+		for _, key := range keys {
+			summaries = append(summaries, newDeployKeySummary(key))
+		}
+		result = summaries
+	case "create":
+		readOnly := true
+		if input.ReadOnly != nil {
+			readOnly = *input.ReadOnly
+		}
+
+		var key *github.Key
+		key, _, err = g.client.Repositories.CreateKey(ctx, input.Owner, input.Repo, &github.Key{
+			Title:    github.String(input.Title),
+			Key:      github.String(strings.TrimSpace(input.Key)),
+			ReadOnly: github.Bool(readOnly),
+		})
+		if err == nil {
+			result = newDeployKeySummary(key)
+		}
+	case "delete":
+		if input.KeyID <= 0 {
+			return returnErrorOutput(fmt.Errorf("key_id is required for operation 'delete'")), nil
+		}
+		_, err = g.client.Repositories.DeleteKey(ctx, input.Owner, input.Repo, input.KeyID)
+		if err == nil {
+			result = map[string]interface{}{"key_id": input.KeyID, "status": "deleted"}
+		}
+	default:
+		return returnErrorOutput(fmt.Errorf("unsupported operation: %s", input.Operation)), nil
+	}
+
+	if err != nil {
+		g.logger.WithFields(map[string]interface{}{
+			"tool":             params.Name,
+			goai.ErrorLogField: err,
+			"operation":        input.Operation,
+		}).Error("GitHub deploy key operation failed")
+
+		if isUnprocessable(err) {
+			// GitHub rejects keys that are invalid or already registered with a 422
+			return returnErrorOutput(fmt.Errorf("github deploy key %s rejected: %s", input.Operation, describeGitHubError(err))), nil
+		}
+		return returnErrorOutput(fmt.Errorf("github deploy key %s error: %w", input.Operation, err)), nil
+	}
+
+	m, err := marshalResult(result)
+	if err != nil {
+		return returnErrorOutput(err), nil
+	}
+	g.logger.WithFields(map[string]interface{}{
+		"tool":          params.Name,
+		"operation":     input.Operation,
+		"result_length": len(m),
+	}).Info("GitHub deploy key operation completed successfully")
+
+	return goai.CallToolResult{
+		Content: []goai.ToolResultContent{{
+			Type: "json",
+			Text: m,
+		}},
+	}, nil
+}
+
+func newDeployKeySummary(key *github.Key) deployKeySummary {
+	summary := deployKeySummary{
+		ID:          key.GetID(),
+		Title:       key.GetTitle(),
+		Fingerprint: sshKeyFingerprint(key.GetKey()),
+		ReadOnly:    key.GetReadOnly(),
+		Verified:    key.GetVerified(),
+	}
+	if key.CreatedAt != nil {
+		summary.CreatedAt = key.GetCreatedAt().UTC().Format("2006-01-02T15:04:05Z")
+	}
+	return summary
+}
+
+// sshKeyFingerprint returns the SHA256 fingerprint of an authorized_keys formatted public key,
+// in the format printed by ssh-keygen -l. GitHub does not report fingerprints for deploy keys,
+// so it is computed from the key itself; an empty string is returned for malformed keys.
+func sshKeyFingerprint(publicKey string) string {
+	fields := strings.Fields(publicKey)
+	if len(fields) < 2 {
+		return ""
+	}
+	blob, err := base64.StdEncoding.DecodeString(fields[1])
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(blob)
+	return "SHA256:" + base64.RawStdEncoding.EncodeToString(sum[:])
+}
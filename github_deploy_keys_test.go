@@ -0,0 +1,207 @@
+package mcptools
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testDeployKey            = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIG2JnHVAfwtkwqr9V1KUz0zLXI0jcNk/Fkv1M+dzxVta ci@example.com"
+	testDeployKeyFingerprint = "SHA256:m3oBkRmdBKxyTiHuus9rjTavIpL+esCwWW6PMN2YrJg"
+)
+
+func TestGetDeployKeyTool(t *testing.T) {
+	gh := &GitHub{client: github.NewClient(nil), logger: &MockLogger{}}
+
+	tool := gh.GetDeployKeyTool()
+
+	assert.Equal(t, GitHubDeployKeysToolName, tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.NotNil(t, tool.Handler)
+
+	var schema map[string]interface{}
+	require.NoError(t, json.Unmarshal(tool.InputSchema, &schema))
+	assert.Equal(t, []interface{}{"operation", "owner", "repo"}, schema["required"])
+}
+
+func TestSSHKeyFingerprint(t *testing.T) {
+	assert.Equal(t, testDeployKeyFingerprint, sshKeyFingerprint(testDeployKey))
+	assert.Empty(t, sshKeyFingerprint("not a key"))
+	assert.Empty(t, sshKeyFingerprint("ssh-ed25519 !!!"))
+}
+
+func TestHandleDeployKeyOperation_List(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/keys", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.NoError(t, json.NewEncoder(w).Encode([]*github.Key{
+			{ID: github.Int64(1), Title: github.String("ci"), Key: github.String(testDeployKey), ReadOnly: github.Bool(true), Verified: github.Bool(true)},
+		}))
+	})
+
+	result := callGitHubHandler(t, gh.handleDeployKeyOperation, GitHubDeployKeysToolName, map[string]interface{}{
+		"operation": "list",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+	})
+	require.False(t, result.IsError, result.Content)
+
+	var keys []deployKeySummary
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &keys))
+	assert.Equal(t, []deployKeySummary{
+		{ID: 1, Title: "ci", Fingerprint: testDeployKeyFingerprint, ReadOnly: true, Verified: true},
+	}, keys)
+}
+
+func TestHandleDeployKeyOperation_Create(t *testing.T) {
+	tests := []struct {
+		name             string
+		readOnly         interface{}
+		expectedReadOnly bool
+	}{
+		{name: "read only by default", readOnly: nil, expectedReadOnly: true},
+		{name: "read write", readOnly: false, expectedReadOnly: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gh, server, cleanup := setupGitHubTest(t)
+			gh.logger = newPermissiveLogger()
+			defer cleanup()
+
+			mux := http.NewServeMux()
+			server.Config.Handler = mux
+
+			mux.HandleFunc("/repos/test-owner/test-repo/keys", func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "POST", r.Method)
+
+				var body github.Key
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				assert.Equal(t, "ci", body.GetTitle())
+				assert.Equal(t, testDeployKey, body.GetKey())
+				assert.Equal(t, tt.expectedReadOnly, body.GetReadOnly())
+
+				w.WriteHeader(http.StatusCreated)
+				body.ID = github.Int64(42)
+				assert.NoError(t, json.NewEncoder(w).Encode(&body))
+			})
+
+			input := map[string]interface{}{
+				"operation": "create",
+				"owner":     "test-owner",
+				"repo":      "test-repo",
+				"title":     "ci",
+				"key":       testDeployKey + "\n",
+			}
+			if tt.readOnly != nil {
+				input["read_only"] = tt.readOnly
+			}
+
+			result := callGitHubHandler(t, gh.handleDeployKeyOperation, GitHubDeployKeysToolName, input)
+			require.False(t, result.IsError, result.Content)
+
+			var key deployKeySummary
+			require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &key))
+			assert.Equal(t, deployKeySummary{ID: 42, Title: "ci", Fingerprint: testDeployKeyFingerprint, ReadOnly: tt.expectedReadOnly}, key)
+		})
+	}
+}
+
+func TestHandleDeployKeyOperation_CreateAlreadyRegistered(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/keys", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"message": "Validation Failed", "errors": [{"resource": "PublicKey", "code": "custom", "field": "key", "message": "key is already in use"}]}`))
+	})
+
+	result := callGitHubHandler(t, gh.handleDeployKeyOperation, GitHubDeployKeysToolName, map[string]interface{}{
+		"operation": "create",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+		"title":     "ci",
+		"key":       testDeployKey,
+	})
+	assert.True(t, result.IsError)
+	assert.Equal(t, "github deploy key create rejected: Validation Failed: key is already in use", result.Content[0].Text)
+}
+
+func TestHandleDeployKeyOperation_Delete(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/keys/42", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "DELETE", r.Method)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	result := callGitHubHandler(t, gh.handleDeployKeyOperation, GitHubDeployKeysToolName, map[string]interface{}{
+		"operation": "delete",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+		"key_id":    42,
+	})
+	require.False(t, result.IsError, result.Content)
+	assert.JSONEq(t, `{"key_id": 42, "status": "deleted"}`, result.Content[0].Text)
+}
+
+func TestHandleDeployKeyOperation_InvalidInput(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    map[string]interface{}
+		expected string
+	}{
+		{
+			name:     "create without key",
+			input:    map[string]interface{}{"operation": "create", "owner": "o", "repo": "r", "title": "ci"},
+			expected: "key is required for operation 'create'",
+		},
+		{
+			name:     "delete without key id",
+			input:    map[string]interface{}{"operation": "delete", "owner": "o", "repo": "r"},
+			expected: "key_id is required for operation 'delete'",
+		},
+		{
+			name:     "list without repo",
+			input:    map[string]interface{}{"operation": "list", "owner": "o"},
+			expected: "repo is required for operation 'list'",
+		},
+		{
+			name:     "unsupported operation",
+			input:    map[string]interface{}{"operation": "rotate", "owner": "o", "repo": "r"},
+			expected: "unsupported operation: rotate",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gh, _, cleanup := setupGitHubTest(t)
+			gh.logger = newPermissiveLogger()
+			defer cleanup()
+
+			result := callGitHubHandler(t, gh.handleDeployKeyOperation, GitHubDeployKeysToolName, tt.input)
+			assert.True(t, result.IsError)
+			assert.Equal(t, tt.expected, result.Content[0].Text)
+		})
+	}
+}
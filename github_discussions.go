@@ -0,0 +1,309 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/shaharia-lab/goai"
+)
+
+const (
+	discussionRepositoryQuery = `query($owner: String!, $repo: String!) {
+  repository(owner: $owner, name: $repo) {
+    id
+    hasDiscussionsEnabled
+    discussionCategories(first: 100) { nodes { id name } }
+  }
+}`
+
+	discussionListQuery = `query($owner: String!, $repo: String!, $first: Int!) {
+  repository(owner: $owner, name: $repo) {
+    hasDiscussionsEnabled
+    discussions(first: $first, orderBy: {field: CREATED_AT, direction: DESC}) {
+      nodes { id number title url createdAt author { login } category { name } }
+    }
+  }
+}`
+
+	discussionGetQuery = `query($owner: String!, $repo: String!, $number: Int!) {
+  repository(owner: $owner, name: $repo) {
+    hasDiscussionsEnabled
+    discussion(number: $number) { id }
+  }
+}`
+
+	discussionCreateMutation = `mutation($repositoryId: ID!, $categoryId: ID!, $title: String!, $body: String!) {
+  createDiscussion(input: {repositoryId: $repositoryId, categoryId: $categoryId, title: $title, body: $body}) {
+    discussion { id number title url }
+  }
+}`
+
+	discussionCommentMutation = `mutation($discussionId: ID!, $body: String!) {
+  addDiscussionComment(input: {discussionId: $discussionId, body: $body}) {
+    comment { id url }
+  }
+}`
+)
+
+// discussion is a GitHub discussion as returned by the discussions tool
+type discussion struct {
+	ID        string `json:"id"`
+	Number    int    `json:"number"`
+	Title     string `json:"title"`
+	URL       string `json:"url"`
+	CreatedAt string `json:"createdAt,omitempty"`
+	Author    *struct {
+		Login string `json:"login"`
+	} `json:"author,omitempty"`
+	Category *struct {
+		Name string `json:"name"`
+	} `json:"category,omitempty"`
+}
+
+// GetDiscussionsTool returns a tool for managing GitHub discussions
+func (g *GitHub) GetDiscussionsTool() goai.Tool {
+	return goai.Tool{
+		Name:        GitHubDiscussionsToolName,
+		Description: "Manages GitHub discussions - create, list, comment",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"operation": {
+					"type": "string",
+					"enum": ["create", "list", "comment"],
+					"description": "Discussion operation to perform"
+				},
+				"owner": {
+					"type": "string",
+					"description": "Repository owner"
+				},
+				"repo": {
+					"type": "string",
+					"description": "Repository name"
+				},
+				"category": {
+					"type": "string",
+					"description": "Discussion category name for creation"
+				},
+				"title": {
+					"type": "string",
+					"description": "Discussion title for creation"
+				},
+				"body": {
+					"type": "string",
+					"description": "Discussion body or comment content"
+				},
+				"number": {
+					"type": "integer",
+					"description": "Discussion number to comment on"
+				},
+				"limit": {
+					"type": "integer",
+					"description": "Maximum number of discussions to list (default 25, max 100)"
+				}
+			},
+			"required": ["operation", "owner", "repo"]
+		}`),
+		Handler: g.handleDiscussionsOperation,
+	}
+}
+
+func (g *GitHub) handleDiscussionsOperation(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
+	ctx, span := goai.StartSpan(ctx, fmt.Sprintf("%s.Handler", params.Name))
+	defer span.End()
+
+	g.logger.WithFields(map[string]interface{}{
+		"tool":      params.Name,
+		"operation": params.Arguments,
+	}).Info("handling discussions operation")
+
+	var input struct {
+		Operation string `json:"operation"`
+		Owner     string `json:"owner"`
+		Repo      string `json:"repo"`
+		Category  string `json:"category"`
+		Title     string `json:"title"`
+		Body      string `json:"body"`
+		Number    int    `json:"number"`
+		Limit     int    `json:"limit"`
+	}
+
+	if err := json.Unmarshal(params.Arguments, &input); err != nil {
+		return goai.CallToolResult{}, fmt.Errorf("failed to unmarshal input: %w", err)
+	}
+
+	var result interface{}
+	var err error
+
+	switch input.Operation {
+	case "create":
+		result, err = g.createDiscussion(ctx, input.Owner, input.Repo, input.Category, input.Title, input.Body)
+	case "list":
+		result, err = g.listDiscussions(ctx, input.Owner, input.Repo, input.Limit)
+	case "comment":
+		result, err = g.commentOnDiscussion(ctx, input.Owner, input.Repo, input.Number, input.Body)
+	default:
+		return returnErrorOutput(fmt.Errorf("unsupported operation: %s", input.Operation)), nil
+	}
+
+	if err != nil {
+		g.logger.WithFields(map[string]interface{}{
+			"tool":             params.Name,
+			goai.ErrorLogField: err,
+			"operation":        input.Operation,
+		}).Error("GitHub discussions operation failed")
+
+		return returnErrorOutput(fmt.Errorf("github discussions %s error: %w", input.Operation, err)), nil
+	}
+
+	m, err := marshalResult(result)
+	if err != nil {
+		return returnErrorOutput(err), nil
+	}
+	g.logger.WithFields(map[string]interface{}{
+		"tool":          params.Name,
+		"operation":     input.Operation,
+		"result_length": len(m),
+	}).Info("GitHub discussions operation completed successfully")
+
+	return goai.CallToolResult{
+		Content: []goai.ToolResultContent{{
+			Type: "json",
+			Text: m,
+		}},
+	}, nil
+}
+
+// discussionsDisabledError reports that a repository does not have discussions enabled
+func discussionsDisabledError(owner, repo string) error {
+	return fmt.Errorf("discussions are disabled for repository %s/%s", owner, repo)
+}
+
+// createDiscussion creates a discussion in the category with the given name
+func (g *GitHub) createDiscussion(ctx context.Context, owner, repo, category, title, body string) (*discussion, error) {
+	if category == "" || title == "" {
+		return nil, fmt.Errorf("category and title are required")
+	}
+
+	var repository struct {
+		Repository struct {
+			ID                    string `json:"id"`
+			HasDiscussionsEnabled bool   `json:"hasDiscussionsEnabled"`
+			DiscussionCategories  struct {
+				Nodes []struct {
+					ID   string `json:"id"`
+					Name string `json:"name"`
+				} `json:"nodes"`
+			} `json:"discussionCategories"`
+		} `json:"repository"`
+	}
+	if err := g.graphQL(ctx, discussionRepositoryQuery, map[string]interface{}{"owner": owner, "repo": repo}, &repository); err != nil {
+		return nil, err
+	}
+	if !repository.Repository.HasDiscussionsEnabled {
+		return nil, discussionsDisabledError(owner, repo)
+	}
+
+	var categoryID string
+	var available []string
+	for _, c := range repository.Repository.DiscussionCategories.Nodes {
+		if strings.EqualFold(c.Name, category) {
+			categoryID = c.ID
+		}
+		available = append(available, c.Name)
+	}
+	if categoryID == "" {
+		return nil, fmt.Errorf("discussion category %q not found (available: %s)", category, strings.Join(available, ", "))
+	}
+
+	var created struct {
+		CreateDiscussion struct {
+			Discussion discussion `json:"discussion"`
+		} `json:"createDiscussion"`
+	}
+	err := g.graphQL(ctx, discussionCreateMutation, map[string]interface{}{
+		"repositoryId": repository.Repository.ID,
+		"categoryId":   categoryID,
+		"title":        title,
+		"body":         body,
+	}, &created)
+	if err != nil {
+		return nil, err
+	}
+
+	return &created.CreateDiscussion.Discussion, nil
+}
+
+// listDiscussions returns the most recently created discussions of a repository
+func (g *GitHub) listDiscussions(ctx context.Context, owner, repo string, limit int) ([]discussion, error) {
+	if limit <= 0 {
+		limit = 25
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	var listed struct {
+		Repository struct {
+			HasDiscussionsEnabled bool `json:"hasDiscussionsEnabled"`
+			Discussions           struct {
+				Nodes []discussion `json:"nodes"`
+			} `json:"discussions"`
+		} `json:"repository"`
+	}
+	err := g.graphQL(ctx, discussionListQuery, map[string]interface{}{"owner": owner, "repo": repo, "first": limit}, &listed)
+	if err != nil {
+		return nil, err
+	}
+	if !listed.Repository.HasDiscussionsEnabled {
+		return nil, discussionsDisabledError(owner, repo)
+	}
+
+	return listed.Repository.Discussions.Nodes, nil
+}
+
+// commentOnDiscussion adds a comment to the discussion with the given number
+func (g *GitHub) commentOnDiscussion(ctx context.Context, owner, repo string, number int, body string) (interface{}, error) {
+	if number == 0 || body == "" {
+		return nil, fmt.Errorf("number and body are required")
+	}
+
+	var found struct {
+		Repository struct {
+			HasDiscussionsEnabled bool `json:"hasDiscussionsEnabled"`
+			Discussion            *struct {
+				ID string `json:"id"`
+			} `json:"discussion"`
+		} `json:"repository"`
+	}
+	err := g.graphQL(ctx, discussionGetQuery, map[string]interface{}{"owner": owner, "repo": repo, "number": number}, &found)
+	if err != nil {
+		return nil, err
+	}
+	if !found.Repository.HasDiscussionsEnabled {
+		return nil, discussionsDisabledError(owner, repo)
+	}
+	if found.Repository.Discussion == nil {
+		return nil, fmt.Errorf("discussion #%d not found", number)
+	}
+
+	var comment struct {
+		AddDiscussionComment struct {
+			Comment struct {
+				ID  string `json:"id"`
+				URL string `json:"url"`
+			} `json:"comment"`
+		} `json:"addDiscussionComment"`
+	}
+	err = g.graphQL(ctx, discussionCommentMutation, map[string]interface{}{
+		"discussionId": found.Repository.Discussion.ID,
+		"body":         body,
+	}, &comment)
+	if err != nil {
+		return nil, err
+	}
+
+	return comment.AddDiscussionComment.Comment, nil
+}
@@ -0,0 +1,158 @@
+package mcptools
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// graphQLRequest is the body of a GraphQL request received by the mocked endpoint
+type graphQLRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables"`
+}
+
+// serveGraphQL registers a /graphql handler that answers each request with respond
+func serveGraphQL(t *testing.T, mux *http.ServeMux, respond func(req graphQLRequest) string) {
+	mux.HandleFunc("/graphql", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+
+		var req graphQLRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(respond(req)))
+	})
+}
+
+func TestGetDiscussionsTool(t *testing.T) {
+	gh := &GitHub{
+		client: github.NewClient(nil),
+		logger: &MockLogger{},
+	}
+
+	tool := gh.GetDiscussionsTool()
+
+	assert.Equal(t, GitHubDiscussionsToolName, tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.NotNil(t, tool.Handler)
+}
+
+func TestHandleDiscussionsOperation_Create(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	serveGraphQL(t, mux, func(req graphQLRequest) string {
+		if strings.Contains(req.Query, "createDiscussion") {
+			assert.Equal(t, "R_1", req.Variables["repositoryId"])
+			assert.Equal(t, "DIC_ideas", req.Variables["categoryId"])
+			assert.Equal(t, "New idea", req.Variables["title"])
+			return `{"data": {"createDiscussion": {"discussion": {"id": "D_1", "number": 7, "title": "New idea", "url": "https://github.com/test-owner/test-repo/discussions/7"}}}}`
+		}
+		return `{"data": {"repository": {"id": "R_1", "hasDiscussionsEnabled": true, "discussionCategories": {"nodes": [
+			{"id": "DIC_general", "name": "General"},
+			{"id": "DIC_ideas", "name": "Ideas"}
+		]}}}}`
+	})
+
+	result := callGitHubHandler(t, gh.handleDiscussionsOperation, GitHubDiscussionsToolName, map[string]interface{}{
+		"operation": "create",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+		"category":  "ideas",
+		"title":     "New idea",
+		"body":      "Let's do this",
+	})
+	require.False(t, result.IsError, result.Content)
+
+	var created discussion
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &created))
+	assert.Equal(t, 7, created.Number)
+	assert.Equal(t, "https://github.com/test-owner/test-repo/discussions/7", created.URL)
+}
+
+func TestHandleDiscussionsOperation_List(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	serveGraphQL(t, mux, func(req graphQLRequest) string {
+		assert.EqualValues(t, 25, req.Variables["first"])
+		return `{"data": {"repository": {"hasDiscussionsEnabled": true, "discussions": {"nodes": [
+			{"id": "D_2", "number": 2, "title": "Second", "url": "https://github.com/test-owner/test-repo/discussions/2", "author": {"login": "octocat"}, "category": {"name": "Q&A"}},
+			{"id": "D_1", "number": 1, "title": "First", "url": "https://github.com/test-owner/test-repo/discussions/1"}
+		]}}}}`
+	})
+
+	result := callGitHubHandler(t, gh.handleDiscussionsOperation, GitHubDiscussionsToolName, map[string]interface{}{
+		"operation": "list",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+	})
+	require.False(t, result.IsError, result.Content)
+
+	var listed []discussion
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &listed))
+	require.Len(t, listed, 2)
+	assert.Equal(t, "Second", listed[0].Title)
+	assert.Equal(t, "octocat", listed[0].Author.Login)
+	assert.Equal(t, "Q&A", listed[0].Category.Name)
+}
+
+func TestHandleDiscussionsOperation_Disabled(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	serveGraphQL(t, mux, func(req graphQLRequest) string {
+		return `{"data": {"repository": {"id": "R_1", "hasDiscussionsEnabled": false, "discussionCategories": {"nodes": []}}}}`
+	})
+
+	result := callGitHubHandler(t, gh.handleDiscussionsOperation, GitHubDiscussionsToolName, map[string]interface{}{
+		"operation": "create",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+		"category":  "General",
+		"title":     "Hello",
+	})
+
+	assert.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].Text, "discussions are disabled for repository test-owner/test-repo")
+}
+
+func TestHandleDiscussionsOperation_GraphQLError(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	serveGraphQL(t, mux, func(req graphQLRequest) string {
+		return `{"data": null, "errors": [{"type": "NOT_FOUND", "message": "Could not resolve to a Repository"}]}`
+	})
+
+	result := callGitHubHandler(t, gh.handleDiscussionsOperation, GitHubDiscussionsToolName, map[string]interface{}{
+		"operation": "list",
+		"owner":     "test-owner",
+		"repo":      "missing",
+	})
+
+	assert.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].Text, "Could not resolve to a Repository")
+}
@@ -0,0 +1,258 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/shaharia-lab/goai"
+)
+
+// gistFileSummary is a file of a gist reported by the gists tool
+type gistFileSummary struct {
+	Filename string `json:"filename"`
+	RawURL   string `json:"raw_url"`
+	Content  string `json:"content,omitempty"`
+}
+
+// gistSummary is the gist information reported by the gists tool
+type gistSummary struct {
+	ID          string            `json:"id"`
+	HTMLURL     string            `json:"html_url"`
+	Description string            `json:"description,omitempty"`
+	Public      bool              `json:"public"`
+	Files       []gistFileSummary `json:"files"`
+}
+
+// GetGistTool returns a tool for managing GitHub gists
+func (g *GitHub) GetGistTool() goai.Tool {
+	return goai.Tool{
+		Name:        GitHubGistsToolName,
+		Description: "Manages GitHub gists - create, get, list, update, delete",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"operation": {
+					"type": "string",
+					"enum": ["create", "get", "list", "update", "delete"],
+					"description": "Gist operation to perform"
+				},
+				"gist_id": {
+					"type": "string",
+					"description": "Gist ID for get, update and delete"
+				},
+				"username": {
+					"type": "string",
+					"description": "List the public gists of this user instead of the authenticated user's gists"
+				},
+				"description": {
+					"type": "string",
+					"description": "Gist description for create and update"
+				},
+				"public": {
+					"type": "boolean",
+					"description": "Create a public gist (default secret)"
+				},
+				"files": {
+					"type": "object",
+					"additionalProperties": {"type": ["string", "null"]},
+					"description": "Map of file name to content. On update, a null content deletes the file"
+				},
+				"per_page": {
+					"type": "integer",
+					"description": "Page size used when listing gists (max 100)"
+				},
+				"max_results": {
+					"type": "integer",
+					"description": "Maximum number of gists listed (default 100)"
+				}
+			},
+			"required": ["operation"]
+		}`),
+		Handler: g.handleGistOperation,
+	}
+}
+
+func (g *GitHub) handleGistOperation(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
+	ctx, span := goai.StartSpan(ctx, fmt.Sprintf("%s.Handler", params.Name))
+	defer span.End()
+
+	g.logger.WithFields(map[string]interface{}{
+		"tool":          params.Name,
+		"tool_argument": string(params.Arguments),
+	}).Info("handling gist operation")
+
+	var input struct {
+		Operation   string             `json:"operation"`
+		GistID      string             `json:"gist_id"`
+		Username    string             `json:"username"`
+		Description *string            `json:"description"`
+		Public      bool               `json:"public"`
+		Files       map[string]*string `json:"files"`
+		PerPage     int                `json:"per_page"`
+		MaxResults  int                `json:"max_results"`
+	}
+
+	if err := json.Unmarshal(params.Arguments, &input); err != nil {
+		return goai.CallToolResult{}, fmt.Errorf("failed to unmarshal input: %w", err)
+	}
+
+	if input.Operation != "create" && input.Operation != "list" && input.GistID == "" {
+		return returnErrorOutput(fmt.Errorf("gist_id is required for %s", input.Operation)), nil
+	}
+
+	var result interface{}
+	var err error
+
+	switch input.Operation {
+	case "create":
+		if len(input.Files) == 0 {
+			return returnErrorOutput(fmt.Errorf("files are required for create")), nil
+		}
+		files := make(map[github.GistFilename]github.GistFile, len(input.Files))
+		for name, content := range input.Files {
+			if content == nil || *content == "" {
+				return returnErrorOutput(fmt.Errorf("file %s has no content", name)), nil
+			}
+			files[github.GistFilename(name)] = github.GistFile{Content: content}
+		}
+
+		var gist *github.Gist
+		gist, _, err = g.client.Gists.Create(ctx, &github.Gist{
+			Description: input.Description,
+			Public:      github.Bool(input.Public),
+			Files:       files,
+		})
+		if err == nil {
+			result = newGistSummary(gist, false)
+		}
+	case "get":
+		var gist *github.Gist
+		gist, _, err = g.client.Gists.Get(ctx, input.GistID)
+		if err == nil {
+			result = newGistSummary(gist, true)
+		}
+	case "list":
+		result, err = g.listGists(ctx, input.Username, input.PerPage, input.MaxResults)
+	case "update":
+		if input.Description == nil && len(input.Files) == 0 {
+			return returnErrorOutput(fmt.Errorf("update requires a description or files")), nil
+		}
+		var gist *github.Gist
+		gist, err = g.editGist(ctx, input.GistID, input.Description, input.Files)
+		if err == nil {
+			result = newGistSummary(gist, false)
+		}
+	case "delete":
+		_, err = g.client.Gists.Delete(ctx, input.GistID)
+		if err == nil {
+			result = map[string]interface{}{"gist_id": input.GistID, "status": "deleted"}
+		}
+	default:
+		return returnErrorOutput(fmt.Errorf("unsupported operation: %s", input.Operation)), nil
+	}
+
+	if err != nil {
+		g.logger.WithFields(map[string]interface{}{
+			"tool":             params.Name,
+			goai.ErrorLogField: err,
+			"operation":        input.Operation,
+		}).Error("GitHub gist operation failed")
+
+		if isNotFound(err) {
+			return returnErrorOutput(fmt.Errorf("gist %s not found", input.GistID)), nil
+		}
+		return returnErrorOutput(fmt.Errorf("github gist %s error: %w", input.Operation, err)), nil
+	}
+
+	m, err := marshalResult(result)
+	if err != nil {
+		return returnErrorOutput(err), nil
+	}
+	g.logger.WithFields(map[string]interface{}{
+		"tool":          params.Name,
+		"operation":     input.Operation,
+		"result_length": len(m),
+	}).Info("GitHub gist operation completed successfully")
+
+	return goai.CallToolResult{
+		Content: []goai.ToolResultContent{{
+			Type: "json",
+			Text: m,
+		}},
+	}, nil
+}
+
+// listGists pages through the gists of username, or of the authenticated user when it is empty
+func (g *GitHub) listGists(ctx context.Context, username string, perPage, maxResults int) ([]gistSummary, error) {
+	gists, err := paginate(listPageSize(perPage), listResultCap(maxResults), func(opts github.ListOptions) ([]*github.Gist, *github.Response, error) {
+		return g.client.Gists.List(ctx, username, &github.GistListOptions{ListOptions: opts})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make([]gistSummary, 0, len(gists))
+	for _, gist := range gists {
+		summaries = append(summaries, newGistSummary(gist, false))
+	}
+	return summaries, nil
+}
+
+// editGist updates the description and files of a gist. go-github cannot express the null file
+// value GitHub uses to delete a file, so the request body is built here: files with nil content
+// are sent as null and deleted, the others have their content replaced.
+func (g *GitHub) editGist(ctx context.Context, id string, description *string, files map[string]*string) (*github.Gist, error) {
+	body := map[string]interface{}{}
+	if description != nil {
+		body["description"] = *description
+	}
+	if len(files) > 0 {
+		changes := make(map[string]interface{}, len(files))
+		for name, content := range files {
+			if content == nil {
+				changes[name] = nil
+				continue
+			}
+			changes[name] = map[string]string{"content": *content}
+		}
+		body["files"] = changes
+	}
+
+	req, err := g.client.NewRequest(http.MethodPatch, "gists/"+id, body)
+	if err != nil {
+		return nil, err
+	}
+
+	gist := new(github.Gist)
+	if _, err := g.client.Do(ctx, req, gist); err != nil {
+		return nil, err
+	}
+	return gist, nil
+}
+
+// newGistSummary converts a gist to its summary, sorting files by name. File contents are
+// only included when withContent is set.
+func newGistSummary(gist *github.Gist, withContent bool) gistSummary {
+	summary := gistSummary{
+		ID:          gist.GetID(),
+		HTMLURL:     gist.GetHTMLURL(),
+		Description: gist.GetDescription(),
+		Public:      gist.GetPublic(),
+		Files:       make([]gistFileSummary, 0, len(gist.Files)),
+	}
+	for name, file := range gist.Files {
+		f := gistFileSummary{Filename: string(name), RawURL: file.GetRawURL()}
+		if withContent {
+			f.Content = file.GetContent()
+		}
+		summary.Files = append(summary.Files, f)
+	}
+	sort.Slice(summary.Files, func(i, j int) bool {
+		return summary.Files[i].Filename < summary.Files[j].Filename
+	})
+	return summary
+}
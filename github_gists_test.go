@@ -0,0 +1,255 @@
+package mcptools
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetGistTool(t *testing.T) {
+	gh := &GitHub{client: github.NewClient(nil), logger: &MockLogger{}}
+
+	tool := gh.GetGistTool()
+
+	assert.Equal(t, GitHubGistsToolName, tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.NotNil(t, tool.Handler)
+
+	var schema map[string]interface{}
+	require.NoError(t, json.Unmarshal(tool.InputSchema, &schema))
+	assert.Equal(t, []interface{}{"operation"}, schema["required"])
+}
+
+func TestHandleGistOperation_Create(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/gists", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "Snippets", body["description"])
+		assert.Equal(t, true, body["public"])
+		assert.Equal(t, map[string]interface{}{
+			"main.go":   map[string]interface{}{"content": "package main"},
+			"README.md": map[string]interface{}{"content": "# Snippets"},
+		}, body["files"])
+
+		w.WriteHeader(http.StatusCreated)
+		assert.NoError(t, json.NewEncoder(w).Encode(&github.Gist{
+			ID:          github.String("g1"),
+			HTMLURL:     github.String("https://gist.github.com/g1"),
+			Description: github.String("Snippets"),
+			Public:      github.Bool(true),
+			Files: map[github.GistFilename]github.GistFile{
+				"main.go":   {RawURL: github.String("https://gist.githubusercontent.com/g1/raw/main.go")},
+				"README.md": {RawURL: github.String("https://gist.githubusercontent.com/g1/raw/README.md")},
+			},
+		}))
+	})
+
+	result := callGitHubHandler(t, gh.handleGistOperation, GitHubGistsToolName, map[string]interface{}{
+		"operation":   "create",
+		"description": "Snippets",
+		"public":      true,
+		"files":       map[string]interface{}{"main.go": "package main", "README.md": "# Snippets"},
+	})
+	require.False(t, result.IsError, result.Content)
+
+	var gist gistSummary
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &gist))
+	assert.Equal(t, gistSummary{
+		ID:          "g1",
+		HTMLURL:     "https://gist.github.com/g1",
+		Description: "Snippets",
+		Public:      true,
+		Files: []gistFileSummary{
+			{Filename: "README.md", RawURL: "https://gist.githubusercontent.com/g1/raw/README.md"},
+			{Filename: "main.go", RawURL: "https://gist.githubusercontent.com/g1/raw/main.go"},
+		},
+	}, gist)
+}
+
+func TestHandleGistOperation_Get(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/gists/g1", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.NoError(t, json.NewEncoder(w).Encode(&github.Gist{
+			ID:    github.String("g1"),
+			Files: map[github.GistFilename]github.GistFile{"main.go": {Content: github.String("package main")}},
+		}))
+	})
+
+	result := callGitHubHandler(t, gh.handleGistOperation, GitHubGistsToolName, map[string]interface{}{
+		"operation": "get",
+		"gist_id":   "g1",
+	})
+	require.False(t, result.IsError, result.Content)
+
+	var gist gistSummary
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &gist))
+	assert.Equal(t, []gistFileSummary{{Filename: "main.go", Content: "package main"}}, gist.Files)
+}
+
+func TestHandleGistOperation_List(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/users/octocat/gists", func(w http.ResponseWriter, r *http.Request) {
+		var gists []*github.Gist
+		switch r.URL.Query().Get("page") {
+		case "", "1":
+			w.Header().Set("Link", `<`+server.URL+`/users/octocat/gists?page=2>; rel="next"`)
+			gists = []*github.Gist{{ID: github.String("g1")}}
+		case "2":
+			gists = []*github.Gist{{ID: github.String("g2")}}
+		}
+		assert.NoError(t, json.NewEncoder(w).Encode(gists))
+	})
+
+	result := callGitHubHandler(t, gh.handleGistOperation, GitHubGistsToolName, map[string]interface{}{
+		"operation": "list",
+		"username":  "octocat",
+	})
+	require.False(t, result.IsError, result.Content)
+
+	var gists []gistSummary
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &gists))
+	require.Len(t, gists, 2)
+	assert.Equal(t, "g1", gists[0].ID)
+	assert.Equal(t, "g2", gists[1].ID)
+}
+
+func TestHandleGistOperation_Update(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/gists/g1", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PATCH", r.Method)
+
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, map[string]interface{}{
+			"files": map[string]interface{}{
+				"main.go": map[string]interface{}{"content": "package util"},
+				"old.txt": nil,
+			},
+		}, body)
+
+		assert.NoError(t, json.NewEncoder(w).Encode(&github.Gist{
+			ID:    github.String("g1"),
+			Files: map[github.GistFilename]github.GistFile{"main.go": {RawURL: github.String("https://gist.githubusercontent.com/g1/raw/main.go")}},
+		}))
+	})
+
+	result := callGitHubHandler(t, gh.handleGistOperation, GitHubGistsToolName, map[string]interface{}{
+		"operation": "update",
+		"gist_id":   "g1",
+		"files":     map[string]interface{}{"main.go": "package util", "old.txt": nil},
+	})
+	require.False(t, result.IsError, result.Content)
+
+	var gist gistSummary
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &gist))
+	assert.Equal(t, []gistFileSummary{{Filename: "main.go", RawURL: "https://gist.githubusercontent.com/g1/raw/main.go"}}, gist.Files)
+}
+
+func TestHandleGistOperation_Delete(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/gists/g1", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "DELETE", r.Method)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/gists/missing", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	result := callGitHubHandler(t, gh.handleGistOperation, GitHubGistsToolName, map[string]interface{}{
+		"operation": "delete",
+		"gist_id":   "g1",
+	})
+	require.False(t, result.IsError, result.Content)
+	assert.JSONEq(t, `{"gist_id": "g1", "status": "deleted"}`, result.Content[0].Text)
+
+	result = callGitHubHandler(t, gh.handleGistOperation, GitHubGistsToolName, map[string]interface{}{
+		"operation": "delete",
+		"gist_id":   "missing",
+	})
+	assert.True(t, result.IsError)
+	assert.Equal(t, "gist missing not found", result.Content[0].Text)
+}
+
+func TestHandleGistOperation_InvalidInput(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    map[string]interface{}
+		expected string
+	}{
+		{
+			name:     "missing gist id",
+			input:    map[string]interface{}{"operation": "get"},
+			expected: "gist_id is required for get",
+		},
+		{
+			name:     "create without files",
+			input:    map[string]interface{}{"operation": "create"},
+			expected: "files are required for create",
+		},
+		{
+			name:     "create with a null file",
+			input:    map[string]interface{}{"operation": "create", "files": map[string]interface{}{"a.txt": nil}},
+			expected: "file a.txt has no content",
+		},
+		{
+			name:     "empty update",
+			input:    map[string]interface{}{"operation": "update", "gist_id": "g1"},
+			expected: "update requires a description or files",
+		},
+		{
+			name:     "unsupported operation",
+			input:    map[string]interface{}{"operation": "fork", "gist_id": "g1"},
+			expected: "unsupported operation: fork",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gh, _, cleanup := setupGitHubTest(t)
+			gh.logger = newPermissiveLogger()
+			defer cleanup()
+
+			result := callGitHubHandler(t, gh.handleGistOperation, GitHubGistsToolName, tt.input)
+			assert.True(t, result.IsError)
+			assert.Equal(t, tt.expected, result.Content[0].Text)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package mcptools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v60/github"
+)
+
+// GitHubConnection is the identity behind the configured token, reported by CheckConnection
+type GitHubConnection struct {
+	Login string `json:"login"`
+	// Scopes are the OAuth scopes of a classic token. Fine-grained tokens and GitHub App
+	// tokens report none, since their permissions are not advertised in the response.
+	Scopes []string `json:"scopes"`
+}
+
+// CheckConnection verifies that the GitHub API is reachable and the configured token is valid
+// by fetching the authenticated user, so that an operator can find out at startup rather than
+// on the first tool call. A missing, invalid or unauthorized token is reported as an error
+// saying so.
+func (g *GitHub) CheckConnection(ctx context.Context) (*GitHubConnection, error) {
+	if g.config.Token == "" {
+		return nil, errors.New("github connection check failed: no token is configured")
+	}
+
+	user, resp, err := g.client.Users.Get(ctx, "")
+	if err != nil {
+		var errResp *github.ErrorResponse
+		if errors.As(err, &errResp) && errResp.Response != nil {
+			switch errResp.Response.StatusCode {
+			case http.StatusUnauthorized:
+				return nil, fmt.Errorf("github connection check failed: the token is invalid or expired: %s", errResp.Message)
+			case http.StatusForbidden:
+				return nil, fmt.Errorf("github connection check failed: the token is not authorized to read the authenticated user: %s", errResp.Message)
+			}
+		}
+		return nil, fmt.Errorf("github connection check failed: %w", err)
+	}
+
+	connection := &GitHubConnection{Login: user.GetLogin(), Scopes: []string{}}
+	for _, scope := range strings.Split(resp.Header.Get("X-OAuth-Scopes"), ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			connection.Scopes = append(connection.Scopes, scope)
+		}
+	}
+
+	g.logger.WithFields(map[string]interface{}{
+		"login":  connection.Login,
+		"scopes": connection.Scopes,
+	}).Info("GitHub connection check succeeded")
+
+	return connection, nil
+}
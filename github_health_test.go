@@ -0,0 +1,100 @@
+package mcptools
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitHub_CheckConnection(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/api/v3/user", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+
+		w.Header().Set("X-OAuth-Scopes", "repo, read:org, workflow")
+		_, _ = w.Write([]byte(`{"login": "octocat", "id": 1}`))
+	})
+
+	gh, err := NewGitHub(newPermissiveLogger(), GitHubConfig{Token: "test-token", BaseURL: server.URL + "/api/v3/"})
+	require.NoError(t, err)
+
+	connection, err := gh.CheckConnection(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &GitHubConnection{Login: "octocat", Scopes: []string{"repo", "read:org", "workflow"}}, connection)
+}
+
+func TestGitHub_CheckConnection_FineGrainedToken(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	gh.config.Token = "github_pat_test"
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"login": "release-bot"}`))
+	})
+
+	connection, err := gh.CheckConnection(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &GitHubConnection{Login: "release-bot", Scopes: []string{}}, connection)
+}
+
+func TestGitHub_CheckConnection_Failures(t *testing.T) {
+	tests := []struct {
+		name     string
+		token    string
+		status   int
+		body     string
+		expected string
+	}{
+		{
+			name:     "missing token",
+			expected: "github connection check failed: no token is configured",
+		},
+		{
+			name:     "invalid token",
+			token:    "expired",
+			status:   http.StatusUnauthorized,
+			body:     `{"message": "Bad credentials"}`,
+			expected: "github connection check failed: the token is invalid or expired: Bad credentials",
+		},
+		{
+			name:     "unauthorized token",
+			token:    "limited",
+			status:   http.StatusForbidden,
+			body:     `{"message": "Resource not accessible by integration"}`,
+			expected: "github connection check failed: the token is not authorized to read the authenticated user: Resource not accessible by integration",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gh, server, cleanup := setupGitHubTest(t)
+			gh.logger = newPermissiveLogger()
+			gh.config.Token = tt.token
+			defer cleanup()
+
+			mux := http.NewServeMux()
+			server.Config.Handler = mux
+
+			mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			})
+
+			connection, err := gh.CheckConnection(context.Background())
+			assert.Nil(t, connection)
+			assert.EqualError(t, err, tt.expected)
+		})
+	}
+}
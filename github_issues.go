@@ -19,7 +19,7 @@ func (g *GitHub) GetIssuesTool() goai.Tool {
 			"properties": {
 				"operation": {
 					"type": "string",
-					"enum": ["create", "get", "list", "update", "comment", "close"],
+					"enum": ["create", "get", "list", "update", "comment", "close", "bulk_close"],
 					"description": "Issue operation to perform"
 				},
 				"owner": {
@@ -51,6 +51,18 @@ func (g *GitHub) GetIssuesTool() goai.Tool {
 					"type": "array",
 					"items": {"type": "string"},
 					"description": "Issue assignees"
+				},
+				"query": {
+					"type": "string",
+					"description": "Issue search query for bulk_close, e.g. 'label:stale updated:<2024-01-01'; scoped to open issues of the repository"
+				},
+				"max_items": {
+					"type": "integer",
+					"description": "Maximum number of issues bulk_close may close (default 30, max 100)"
+				},
+				"dry_run": {
+					"type": "boolean",
+					"description": "Preview the issues bulk_close would close without changing them"
 				}
 			},
 			"required": ["operation", "owner", "repo"]
@@ -77,6 +89,9 @@ func (g *GitHub) handleIssuesOperation(ctx context.Context, params goai.CallTool
 		Body      string   `json:"body"`
 		Labels    []string `json:"labels"`
 		Assignees []string `json:"assignees"`
+		Query     string   `json:"query"`
+		MaxItems  int      `json:"max_items"`
+		DryRun    bool     `json:"dry_run"`
 	}
 
 	if err := json.Unmarshal(params.Arguments, &input); err != nil {
@@ -114,6 +129,11 @@ func (g *GitHub) handleIssuesOperation(ctx context.Context, params goai.CallTool
 		result, _, err = g.client.Issues.Edit(ctx, input.Owner, input.Repo, input.Number, &github.IssueRequest{
 			State: &state,
 		})
+	case "bulk_close":
+		if input.Query == "" {
+			return returnErrorOutput(fmt.Errorf("query is required for bulk_close")), nil
+		}
+		result, err = g.bulkCloseIssues(ctx, input.Owner, input.Repo, input.Query, input.Body, input.MaxItems, input.DryRun)
 	default:
 		return returnErrorOutput(fmt.Errorf("unsupported operation: %s", input.Operation)), nil
 	}
@@ -128,7 +148,10 @@ func (g *GitHub) handleIssuesOperation(ctx context.Context, params goai.CallTool
 		return returnErrorOutput(err), nil
 	}
 
-	marshalledResult := mustMarshal(result)
+	marshalledResult, err := marshalResult(result)
+	if err != nil {
+		return returnErrorOutput(err), nil
+	}
 
 	g.logger.WithFields(map[string]interface{}{
 		"tool":          params.Name,
@@ -143,3 +166,87 @@ func (g *GitHub) handleIssuesOperation(ctx context.Context, params goai.CallTool
 		}},
 	}, nil
 }
+
+// defaultBulkCloseMaxItems caps bulk_close when the caller does not provide max_items
+const defaultBulkCloseMaxItems = 30
+
+// bulkCloseResult is the outcome of closing a single issue in bulk_close
+type bulkCloseResult struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	URL    string `json:"url"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// bulkCloseReport summarizes a bulk_close run
+type bulkCloseReport struct {
+	Query   string            `json:"query"`
+	DryRun  bool              `json:"dry_run"`
+	Matched int               `json:"matched"`
+	Closed  int               `json:"closed"`
+	Failed  int               `json:"failed"`
+	Results []bulkCloseResult `json:"results"`
+}
+
+// bulkCloseIssues closes up to maxItems open issues of owner/repo matching query, commenting
+// on each first when comment is set. A failure on one issue is recorded in its result and
+// does not stop the others. With dryRun set the matching issues are only reported.
+func (g *GitHub) bulkCloseIssues(ctx context.Context, owner, repo, query, comment string, maxItems int, dryRun bool) (*bulkCloseReport, error) {
+	if maxItems <= 0 {
+		maxItems = defaultBulkCloseMaxItems
+	}
+	if maxItems > 100 {
+		maxItems = 100
+	}
+
+	fullQuery := fmt.Sprintf("repo:%s/%s is:issue is:open %s", owner, repo, query)
+	issues, err := paginate(maxItems, maxItems, func(opts github.ListOptions) ([]*github.Issue, *github.Response, error) {
+		found, resp, err := g.client.Search.Issues(ctx, fullQuery, &github.SearchOptions{ListOptions: opts})
+		if err != nil {
+			return nil, resp, err
+		}
+		return found.Issues, resp, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	report := &bulkCloseReport{Query: fullQuery, DryRun: dryRun, Matched: len(issues), Results: []bulkCloseResult{}}
+	for _, issue := range issues {
+		res := bulkCloseResult{Number: issue.GetNumber(), Title: issue.GetTitle(), URL: issue.GetHTMLURL()}
+
+		if dryRun {
+			res.Status = "would_close"
+			report.Results = append(report.Results, res)
+			continue
+		}
+
+		if err := g.closeIssue(ctx, owner, repo, issue.GetNumber(), comment); err != nil {
+			res.Status = "failed"
+			res.Error = err.Error()
+			report.Failed++
+		} else {
+			res.Status = "closed"
+			report.Closed++
+		}
+		report.Results = append(report.Results, res)
+	}
+
+	return report, nil
+}
+
+// closeIssue closes an issue, commenting on it first when comment is set
+func (g *GitHub) closeIssue(ctx context.Context, owner, repo string, number int, comment string) error {
+	if comment != "" {
+		if _, _, err := g.client.Issues.CreateComment(ctx, owner, repo, number, &github.IssueComment{Body: &comment}); err != nil {
+			return fmt.Errorf("failed to comment: %w", err)
+		}
+	}
+
+	state := "closed"
+	if _, _, err := g.client.Issues.Edit(ctx, owner, repo, number, &github.IssueRequest{State: &state}); err != nil {
+		return fmt.Errorf("failed to close: %w", err)
+	}
+	return nil
+}
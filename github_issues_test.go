@@ -345,3 +345,117 @@ func TestHandleIssuesOperation_Close(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "closed", *responseIssue.State)
 }
+
+// serveStaleIssueSearch answers issue searches with issues 1 and 2
+func serveStaleIssueSearch(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/search/issues", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "repo:test-owner/test-repo is:issue is:open label:stale updated:<2024-01-01", r.URL.Query().Get("q"))
+		assert.Equal(t, "10", r.URL.Query().Get("per_page"))
+
+		err := json.NewEncoder(w).Encode(&github.IssuesSearchResult{
+			Total: github.Int(2),
+			Issues: []*github.Issue{
+				{Number: github.Int(1), Title: github.String("Old bug")},
+				{Number: github.Int(2), Title: github.String("Old idea")},
+			},
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func TestHandleIssuesOperation_BulkCloseDryRun(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+	serveStaleIssueSearch(t, mux)
+
+	mux.HandleFunc("/repos/test-owner/test-repo/issues/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("dry run must not modify issues, got %s %s", r.Method, r.URL.Path)
+	})
+
+	result := callGitHubHandler(t, gh.handleIssuesOperation, GitHubIssuesToolName, map[string]interface{}{
+		"operation": "bulk_close",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+		"query":     "label:stale updated:<2024-01-01",
+		"max_items": 10,
+		"dry_run":   true,
+	})
+	require.False(t, result.IsError, result.Content)
+
+	var report bulkCloseReport
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &report))
+	assert.True(t, report.DryRun)
+	assert.Equal(t, 2, report.Matched)
+	assert.Equal(t, 0, report.Closed)
+	require.Len(t, report.Results, 2)
+	assert.Equal(t, "would_close", report.Results[0].Status)
+	assert.Equal(t, "would_close", report.Results[1].Status)
+}
+
+func TestHandleIssuesOperation_BulkCloseWithComment(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+	serveStaleIssueSearch(t, mux)
+
+	var commented []string
+	mux.HandleFunc("/repos/test-owner/test-repo/issues/1/comments", func(w http.ResponseWriter, r *http.Request) {
+		var comment github.IssueComment
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&comment))
+		assert.Equal(t, "Closing as stale", comment.GetBody())
+		commented = append(commented, "1")
+		assert.NoError(t, json.NewEncoder(w).Encode(&comment))
+	})
+	mux.HandleFunc("/repos/test-owner/test-repo/issues/2/comments", func(w http.ResponseWriter, r *http.Request) {
+		commented = append(commented, "2")
+		assert.NoError(t, json.NewEncoder(w).Encode(&github.IssueComment{}))
+	})
+	mux.HandleFunc("/repos/test-owner/test-repo/issues/1", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PATCH", r.Method)
+		assert.NoError(t, json.NewEncoder(w).Encode(&github.Issue{Number: github.Int(1), State: github.String("closed")}))
+	})
+	mux.HandleFunc("/repos/test-owner/test-repo/issues/2", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message": "Resource not accessible by integration"}`))
+	})
+
+	result := callGitHubHandler(t, gh.handleIssuesOperation, GitHubIssuesToolName, map[string]interface{}{
+		"operation": "bulk_close",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+		"query":     "label:stale updated:<2024-01-01",
+		"body":      "Closing as stale",
+		"max_items": 10,
+	})
+	require.False(t, result.IsError, result.Content)
+
+	var report bulkCloseReport
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &report))
+	assert.Equal(t, []string{"1", "2"}, commented)
+	assert.Equal(t, 1, report.Closed)
+	assert.Equal(t, 1, report.Failed)
+	require.Len(t, report.Results, 2)
+	assert.Equal(t, "closed", report.Results[0].Status)
+	assert.Equal(t, "failed", report.Results[1].Status)
+	assert.Contains(t, report.Results[1].Error, "Resource not accessible by integration")
+}
+
+func TestHandleIssuesOperation_BulkCloseRequiresQuery(t *testing.T) {
+	gh, _, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	result := callGitHubHandler(t, gh.handleIssuesOperation, GitHubIssuesToolName, map[string]interface{}{
+		"operation": "bulk_close",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+	})
+	assert.True(t, result.IsError)
+}
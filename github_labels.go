@@ -0,0 +1,237 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/shaharia-lab/goai"
+)
+
+// labelRequiredFields are the fields each label operation requires
+var labelRequiredFields = requiredFields{
+	"list":              {"owner", "repo"},
+	"create":            {"owner", "repo", "name", "color"},
+	"update":            {"owner", "repo", "name"},
+	"delete":            {"owner", "repo", "name"},
+	"add_to_issue":      {"owner", "repo", "name"},
+	"remove_from_issue": {"owner", "repo", "name"},
+}
+
+// labelColorPattern matches the six hex digit colors GitHub accepts for labels
+var labelColorPattern = regexp.MustCompile(`^[0-9a-fA-F]{6}$`)
+
+// labelSummary is the label information reported by the labels tool
+type labelSummary struct {
+	Name        string `json:"name"`
+	Color       string `json:"color"`
+	Description string `json:"description,omitempty"`
+}
+
+// GetLabelTool returns a tool for managing the labels of GitHub repositories and applying them to issues
+func (g *GitHub) GetLabelTool() goai.Tool {
+	return goai.Tool{
+		Name:        GitHubLabelsToolName,
+		Description: "Manages GitHub labels - list, create, update, delete, add to and remove from issues and pull requests",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"operation": {
+					"type": "string",
+					"enum": ["list", "create", "update", "delete", "add_to_issue", "remove_from_issue"],
+					"description": "Label operation to perform"
+				},
+				"owner": {
+					"type": "string",
+					"description": "Repository owner"
+				},
+				"repo": {
+					"type": "string",
+					"description": "Repository name"
+				},
+				"name": {
+					"type": "string",
+					"description": "Label name"
+				},
+				"new_name": {
+					"type": "string",
+					"description": "New name of the label for update"
+				},
+				"color": {
+					"type": "string",
+					"description": "Six hex digit label color without the leading #, such as d73a4a"
+				},
+				"description": {
+					"type": "string",
+					"description": "Short description of the label"
+				},
+				"issue_number": {
+					"type": "integer",
+					"description": "Issue or pull request number for add_to_issue and remove_from_issue"
+				}
+			},
+			"required": ["operation", "owner", "repo"]
+		}`),
+		Handler: g.handleLabelOperation,
+	}
+}
+
+func (g *GitHub) handleLabelOperation(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
+	ctx, span := goai.StartSpan(ctx, fmt.Sprintf("%s.Handler", params.Name))
+	defer span.End()
+
+	g.logger.WithFields(map[string]interface{}{
+		"tool":          params.Name,
+		"tool_argument": string(params.Arguments),
+	}).Info("handling label operation")
+
+	var input struct {
+		Operation   string  `json:"operation"`
+		Owner       string  `json:"owner"`
+		Repo        string  `json:"repo"`
+		Name        string  `json:"name"`
+		NewName     string  `json:"new_name"`
+		Color       string  `json:"color"`
+		Description *string `json:"description"`
+		IssueNumber int     `json:"issue_number"`
+	}
+
+	if err := json.Unmarshal(params.Arguments, &input); err != nil {
+		return goai.CallToolResult{}, fmt.Errorf("failed to unmarshal input: %w", err)
+	}
+
+	if err := labelRequiredFields.validate(input.Operation, map[string]string{
+		"owner": input.Owner,
+		"repo":  input.Repo,
+		"name":  input.Name,
+		"color": input.Color,
+	}); err != nil {
+		return returnErrorOutput(err), nil
+	}
+	if input.Color != "" && !labelColorPattern.MatchString(input.Color) {
+		return returnErrorOutput(fmt.Errorf("invalid color %q: must be six hex digits without a leading #, such as d73a4a", input.Color)), nil
+	}
+	if (input.Operation == "add_to_issue" || input.Operation == "remove_from_issue") && input.IssueNumber <= 0 {
+		return returnErrorOutput(fmt.Errorf("issue_number is required for operation '%s'", input.Operation)), nil
+	}
+
+	var result interface{}
+	var err error
+
+	switch input.Operation {
+	case "list":
+		var labels []*github.Label
+		labels, err = paginate(listPageSize(0), listResultCap(0), func(opts github.ListOptions) ([]*github.Label, *github.Response, error) {
+			return g.client.Issues.ListLabels(ctx, input.Owner, input.Repo, &opts)
+		})
+		result = newLabelSummaries(labels)
+	case "create":
+		var label *github.Label
+		label, _, err = g.client.Issues.CreateLabel(ctx, input.Owner, input.Repo, &github.Label{
+			Name:        github.String(input.Name),
+			Color:       github.String(strings.ToLower(input.Color)),
+			Description: input.Description,
+		})
+		if isAlreadyExists(err) {
+			return returnErrorOutput(fmt.Errorf("label %s already exists in %s/%s", input.Name, input.Owner, input.Repo)), nil
+		}
+		if err == nil {
+			result = newLabelSummary(label)
+		}
+	case "update":
+		edit := &github.Label{Description: input.Description}
+		if input.NewName != "" {
+			edit.Name = github.String(input.NewName)
+		}
+		if input.Color != "" {
+			edit.Color = github.String(strings.ToLower(input.Color))
+		}
+
+		var label *github.Label
+		label, _, err = g.client.Issues.EditLabel(ctx, input.Owner, input.Repo, input.Name, edit)
+		if isNotFound(err) {
+			return returnErrorOutput(fmt.Errorf("label %s does not exist in %s/%s", input.Name, input.Owner, input.Repo)), nil
+		}
+		if isAlreadyExists(err) {
+			return returnErrorOutput(fmt.Errorf("label %s already exists in %s/%s", input.NewName, input.Owner, input.Repo)), nil
+		}
+		if err == nil {
+			result = newLabelSummary(label)
+		}
+	case "delete":
+		_, err = g.client.Issues.DeleteLabel(ctx, input.Owner, input.Repo, input.Name)
+		if isNotFound(err) {
+			return returnErrorOutput(fmt.Errorf("label %s does not exist in %s/%s", input.Name, input.Owner, input.Repo)), nil
+		}
+		if err == nil {
+			result = map[string]interface{}{"name": input.Name, "status": "deleted"}
+		}
+	case "add_to_issue":
+		// GitHub creates labels that do not exist yet when adding them to an issue
+		var labels []*github.Label
+		labels, _, err = g.client.Issues.AddLabelsToIssue(ctx, input.Owner, input.Repo, input.IssueNumber, []string{input.Name})
+		if err == nil {
+			result = newLabelSummaries(labels)
+		}
+	case "remove_from_issue":
+		_, err = g.client.Issues.RemoveLabelForIssue(ctx, input.Owner, input.Repo, input.IssueNumber, input.Name)
+		if isNotFound(err) {
+			return returnErrorOutput(fmt.Errorf("label %s is not on issue #%d of %s/%s", input.Name, input.IssueNumber, input.Owner, input.Repo)), nil
+		}
+		if err == nil {
+			result = map[string]interface{}{"name": input.Name, "issue_number": input.IssueNumber, "status": "removed"}
+		}
+	default:
+		return returnErrorOutput(fmt.Errorf("unsupported operation: %s", input.Operation)), nil
+	}
+
+	if err != nil {
+		g.logger.WithFields(map[string]interface{}{
+			"tool":             params.Name,
+			goai.ErrorLogField: err,
+			"operation":        input.Operation,
+		}).Error("GitHub label operation failed")
+
+		if isUnprocessable(err) {
+			return returnErrorOutput(errors.New(describeGitHubError(err))), nil
+		}
+		return returnErrorOutput(fmt.Errorf("github label %s error: %w", input.Operation, err)), nil
+	}
+
+	m, err := marshalResult(result)
+	if err != nil {
+		return returnErrorOutput(err), nil
+	}
+	g.logger.WithFields(map[string]interface{}{
+		"tool":          params.Name,
+		"operation":     input.Operation,
+		"result_length": len(m),
+	}).Info("GitHub label operation completed successfully")
+
+	return goai.CallToolResult{
+		Content: []goai.ToolResultContent{{
+			Type: "json",
+			Text: m,
+		}},
+	}, nil
+}
+
+func newLabelSummary(label *github.Label) labelSummary {
+	return labelSummary{
+		Name:        label.GetName(),
+		Color:       label.GetColor(),
+		Description: label.GetDescription(),
+	}
+}
+
+func newLabelSummaries(labels []*github.Label) []labelSummary {
+	summaries := make([]labelSummary, 0, len(labels))
+	for _, label := range labels {
+		summaries = append(summaries, newLabelSummary(label))
+	}
+	return summaries
+}
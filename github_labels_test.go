@@ -0,0 +1,292 @@
+package mcptools
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetLabelTool(t *testing.T) {
+	gh := &GitHub{client: github.NewClient(nil), logger: &MockLogger{}}
+
+	tool := gh.GetLabelTool()
+
+	assert.Equal(t, GitHubLabelsToolName, tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.NotNil(t, tool.Handler)
+
+	var schema map[string]interface{}
+	require.NoError(t, json.Unmarshal(tool.InputSchema, &schema))
+	assert.Equal(t, []interface{}{"operation", "owner", "repo"}, schema["required"])
+}
+
+func TestHandleLabelOperation_List(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/labels", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.NoError(t, json.NewEncoder(w).Encode([]*github.Label{
+			{Name: github.String("bug"), Color: github.String("d73a4a"), Description: github.String("Something isn't working")},
+			{Name: github.String("triage"), Color: github.String("ededed")},
+		}))
+	})
+
+	result := callGitHubHandler(t, gh.handleLabelOperation, GitHubLabelsToolName, map[string]interface{}{
+		"operation": "list",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+	})
+	require.False(t, result.IsError, result.Content)
+
+	var labels []labelSummary
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &labels))
+	assert.Equal(t, []labelSummary{
+		{Name: "bug", Color: "d73a4a", Description: "Something isn't working"},
+		{Name: "triage", Color: "ededed"},
+	}, labels)
+}
+
+func TestHandleLabelOperation_Create(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/labels", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, map[string]string{"name": "needs-info", "color": "fbca04", "description": "Waiting for the reporter"}, body)
+
+		w.WriteHeader(http.StatusCreated)
+		assert.NoError(t, json.NewEncoder(w).Encode(body))
+	})
+
+	result := callGitHubHandler(t, gh.handleLabelOperation, GitHubLabelsToolName, map[string]interface{}{
+		"operation":   "create",
+		"owner":       "test-owner",
+		"repo":        "test-repo",
+		"name":        "needs-info",
+		"color":       "FBCA04",
+		"description": "Waiting for the reporter",
+	})
+	require.False(t, result.IsError, result.Content)
+	assert.JSONEq(t, `{"name": "needs-info", "color": "fbca04", "description": "Waiting for the reporter"}`, result.Content[0].Text)
+}
+
+func TestHandleLabelOperation_CreateAlreadyExists(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/labels", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"message": "Validation Failed", "errors": [{"resource": "Label", "code": "already_exists", "field": "name"}]}`))
+	})
+
+	result := callGitHubHandler(t, gh.handleLabelOperation, GitHubLabelsToolName, map[string]interface{}{
+		"operation": "create",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+		"name":      "bug",
+		"color":     "d73a4a",
+	})
+	assert.True(t, result.IsError)
+	assert.Equal(t, "label bug already exists in test-owner/test-repo", result.Content[0].Text)
+}
+
+func TestHandleLabelOperation_Update(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/labels/bug", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PATCH", r.Method)
+
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, map[string]string{"name": "type: bug", "color": "b60205"}, body)
+
+		assert.NoError(t, json.NewEncoder(w).Encode(body))
+	})
+
+	result := callGitHubHandler(t, gh.handleLabelOperation, GitHubLabelsToolName, map[string]interface{}{
+		"operation": "update",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+		"name":      "bug",
+		"new_name":  "type: bug",
+		"color":     "b60205",
+	})
+	require.False(t, result.IsError, result.Content)
+	assert.JSONEq(t, `{"name": "type: bug", "color": "b60205"}`, result.Content[0].Text)
+}
+
+func TestHandleLabelOperation_Delete(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		isError  bool
+		expected string
+	}{
+		{name: "deleted", status: http.StatusNoContent, expected: `{"name": "stale", "status": "deleted"}`},
+		{name: "missing label", status: http.StatusNotFound, isError: true, expected: "label stale does not exist in test-owner/test-repo"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gh, server, cleanup := setupGitHubTest(t)
+			gh.logger = newPermissiveLogger()
+			defer cleanup()
+
+			mux := http.NewServeMux()
+			server.Config.Handler = mux
+
+			mux.HandleFunc("/repos/test-owner/test-repo/labels/stale", func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "DELETE", r.Method)
+				w.WriteHeader(tt.status)
+			})
+
+			result := callGitHubHandler(t, gh.handleLabelOperation, GitHubLabelsToolName, map[string]interface{}{
+				"operation": "delete",
+				"owner":     "test-owner",
+				"repo":      "test-repo",
+				"name":      "stale",
+			})
+			assert.Equal(t, tt.isError, result.IsError)
+			if tt.isError {
+				assert.Equal(t, tt.expected, result.Content[0].Text)
+			} else {
+				assert.JSONEq(t, tt.expected, result.Content[0].Text)
+			}
+		})
+	}
+}
+
+func TestHandleLabelOperation_Issue(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/issues/7/labels", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+
+		var body []string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, []string{"triage"}, body)
+
+		assert.NoError(t, json.NewEncoder(w).Encode([]*github.Label{
+			{Name: github.String("bug"), Color: github.String("d73a4a")},
+			{Name: github.String("triage"), Color: github.String("ededed")},
+		}))
+	})
+	mux.HandleFunc("/repos/test-owner/test-repo/issues/7/labels/triage", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "DELETE", r.Method)
+		assert.NoError(t, json.NewEncoder(w).Encode([]*github.Label{}))
+	})
+	mux.HandleFunc("/repos/test-owner/test-repo/issues/7/labels/missing", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	result := callGitHubHandler(t, gh.handleLabelOperation, GitHubLabelsToolName, map[string]interface{}{
+		"operation":    "add_to_issue",
+		"owner":        "test-owner",
+		"repo":         "test-repo",
+		"name":         "triage",
+		"issue_number": 7,
+	})
+	require.False(t, result.IsError, result.Content)
+	assert.JSONEq(t, `[{"name": "bug", "color": "d73a4a"}, {"name": "triage", "color": "ededed"}]`, result.Content[0].Text)
+
+	result = callGitHubHandler(t, gh.handleLabelOperation, GitHubLabelsToolName, map[string]interface{}{
+		"operation":    "remove_from_issue",
+		"owner":        "test-owner",
+		"repo":         "test-repo",
+		"name":         "triage",
+		"issue_number": 7,
+	})
+	require.False(t, result.IsError, result.Content)
+	assert.JSONEq(t, `{"name": "triage", "issue_number": 7, "status": "removed"}`, result.Content[0].Text)
+
+	result = callGitHubHandler(t, gh.handleLabelOperation, GitHubLabelsToolName, map[string]interface{}{
+		"operation":    "remove_from_issue",
+		"owner":        "test-owner",
+		"repo":         "test-repo",
+		"name":         "missing",
+		"issue_number": 7,
+	})
+	assert.True(t, result.IsError)
+	assert.Equal(t, "label missing is not on issue #7 of test-owner/test-repo", result.Content[0].Text)
+}
+
+func TestHandleLabelOperation_InvalidInput(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    map[string]interface{}
+		expected string
+	}{
+		{
+			name:     "create without color",
+			input:    map[string]interface{}{"operation": "create", "owner": "o", "repo": "r", "name": "bug"},
+			expected: "color is required for operation 'create'",
+		},
+		{
+			name:     "color with a hash",
+			input:    map[string]interface{}{"operation": "create", "owner": "o", "repo": "r", "name": "bug", "color": "#d73a4a"},
+			expected: `invalid color "#d73a4a": must be six hex digits without a leading #, such as d73a4a`,
+		},
+		{
+			name:     "color with non hex digits",
+			input:    map[string]interface{}{"operation": "update", "owner": "o", "repo": "r", "name": "bug", "color": "red123"},
+			expected: `invalid color "red123": must be six hex digits without a leading #, such as d73a4a`,
+		},
+		{
+			name:     "delete without name",
+			input:    map[string]interface{}{"operation": "delete", "owner": "o", "repo": "r"},
+			expected: "name is required for operation 'delete'",
+		},
+		{
+			name:     "add to issue without issue number",
+			input:    map[string]interface{}{"operation": "add_to_issue", "owner": "o", "repo": "r", "name": "bug"},
+			expected: "issue_number is required for operation 'add_to_issue'",
+		},
+		{
+			name:     "unsupported operation",
+			input:    map[string]interface{}{"operation": "rename", "owner": "o", "repo": "r"},
+			expected: "unsupported operation: rename",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gh, _, cleanup := setupGitHubTest(t)
+			gh.logger = newPermissiveLogger()
+			defer cleanup()
+
+			result := callGitHubHandler(t, gh.handleLabelOperation, GitHubLabelsToolName, tt.input)
+			assert.True(t, result.IsError)
+			assert.Equal(t, tt.expected, result.Content[0].Text)
+		})
+	}
+}
@@ -0,0 +1,281 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/shaharia-lab/goai"
+)
+
+// milestoneRequiredFields are the fields each milestone operation requires
+var milestoneRequiredFields = requiredFields{
+	"list":   {"owner", "repo"},
+	"create": {"owner", "repo", "title"},
+	"update": {"owner", "repo"},
+	"close":  {"owner", "repo"},
+	"delete": {"owner", "repo"},
+}
+
+// milestoneSummary is the milestone information reported by the milestones tool
+type milestoneSummary struct {
+	Number       int    `json:"number"`
+	Title        string `json:"title"`
+	Description  string `json:"description,omitempty"`
+	State        string `json:"state"`
+	DueOn        string `json:"due_on,omitempty"`
+	OpenIssues   int    `json:"open_issues"`
+	ClosedIssues int    `json:"closed_issues"`
+	HTMLURL      string `json:"html_url,omitempty"`
+}
+
+// GetMilestoneTool returns a tool for managing the milestones of GitHub repositories
+func (g *GitHub) GetMilestoneTool() goai.Tool {
+	return goai.Tool{
+		Name:        GitHubMilestonesToolName,
+		Description: "Manages GitHub milestones - create, list, update, close, delete",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"operation": {
+					"type": "string",
+					"enum": ["create", "list", "update", "close", "delete"],
+					"description": "Milestone operation to perform"
+				},
+				"owner": {
+					"type": "string",
+					"description": "Repository owner"
+				},
+				"repo": {
+					"type": "string",
+					"description": "Repository name"
+				},
+				"number": {
+					"type": "integer",
+					"description": "Milestone number for update, close and delete"
+				},
+				"title": {
+					"type": "string",
+					"description": "Milestone title"
+				},
+				"description": {
+					"type": "string",
+					"description": "Milestone description"
+				},
+				"due_on": {
+					"type": "string",
+					"description": "Due date as an ISO date such as 2024-06-30, or an RFC 3339 timestamp"
+				},
+				"state": {
+					"type": "string",
+					"enum": ["open", "closed", "all"],
+					"description": "State of the milestone for create and update, or the state to filter by for list (default open; all only for list)"
+				},
+				"sort": {
+					"type": "string",
+					"enum": ["due_on", "completeness"],
+					"description": "Sort order for list (default due_on)"
+				},
+				"direction": {
+					"type": "string",
+					"enum": ["asc", "desc"],
+					"description": "Sort direction for list (default asc)"
+				}
+			},
+			"required": ["operation", "owner", "repo"]
+		}`),
+		Handler: g.handleMilestoneOperation,
+	}
+}
+
+func (g *GitHub) handleMilestoneOperation(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
+	ctx, span := goai.StartSpan(ctx, fmt.Sprintf("%s.Handler", params.Name))
+	defer span.End()
+
+	g.logger.WithFields(map[string]interface{}{
+		"tool":          params.Name,
+		"tool_argument": string(params.Arguments),
+	}).Info("handling milestone operation")
+
+	var input struct {
+		Operation   string  `json:"operation"`
+		Owner       string  `json:"owner"`
+		Repo        string  `json:"repo"`
+		Number      int     `json:"number"`
+		Title       string  `json:"title"`
+		Description *string `json:"description"`
+		DueOn       string  `json:"due_on"`
+		State       string  `json:"state"`
+		Sort        string  `json:"sort"`
+		Direction   string  `json:"direction"`
+	}
+
+	if err := json.Unmarshal(params.Arguments, &input); err != nil {
+		return goai.CallToolResult{}, fmt.Errorf("failed to unmarshal input: %w", err)
+	}
+
+	if err := milestoneRequiredFields.validate(input.Operation, map[string]string{
+		"owner": input.Owner,
+		"repo":  input.Repo,
+		"title": input.Title,
+	}); err != nil {
+		return returnErrorOutput(err), nil
+	}
+	if err := validateMilestoneInput(input.Operation, input.Number, input.State, input.Sort, input.Direction); err != nil {
+		return returnErrorOutput(err), nil
+	}
+
+	var dueOn *github.Timestamp
+	if input.DueOn != "" {
+		due, err := parseDueOn(input.DueOn)
+		if err != nil {
+			return returnErrorOutput(err), nil
+		}
+		dueOn = &github.Timestamp{Time: due}
+	}
+
+	var result interface{}
+	var err error
+
+	switch input.Operation {
+	case "list":
+		opts := &github.MilestoneListOptions{State: input.State, Sort: input.Sort, Direction: input.Direction}
+		if opts.Sort == "" {
+			opts.Sort = "due_on"
+		}
+
+		var milestones []*github.Milestone
+		milestones, err = paginate(listPageSize(0), listResultCap(0), func(listOpts github.ListOptions) ([]*github.Milestone, *github.Response, error) {
+			opts.ListOptions = listOpts
+			return g.client.Issues.ListMilestones(ctx, input.Owner, input.Repo, opts)
+		})
+		summaries := make([]milestoneSummary, 0, len(milestones))
+		for _, milestone := range milestones {
+			summaries = append(summaries, newMilestoneSummary(milestone))
+		}
+		result = summaries
+	case "create":
+		milestone := &github.Milestone{Title: github.String(input.Title), Description: input.Description, DueOn: dueOn}
+		if input.State != "" {
+			milestone.State = github.String(input.State)
+		}
+
+		var created *github.Milestone
+		created, _, err = g.client.Issues.CreateMilestone(ctx, input.Owner, input.Repo, milestone)
+		if isAlreadyExists(err) {
+			return returnErrorOutput(fmt.Errorf("milestone %s already exists in %s/%s", input.Title, input.Owner, input.Repo)), nil
+		}
+		if err == nil {
+			result = newMilestoneSummary(created)
+		}
+	case "update", "close":
+		edit := &github.Milestone{Description: input.Description, DueOn: dueOn}
+		if input.Title != "" {
+			edit.Title = github.String(input.Title)
+		}
+		if input.State != "" {
+			edit.State = github.String(input.State)
+		}
+		if input.Operation == "close" {
+			edit.State = github.String("closed")
+		}
+
+		var edited *github.Milestone
+		edited, _, err = g.client.Issues.EditMilestone(ctx, input.Owner, input.Repo, input.Number, edit)
+		if err == nil {
+			result = newMilestoneSummary(edited)
+		}
+	case "delete":
+		_, err = g.client.Issues.DeleteMilestone(ctx, input.Owner, input.Repo, input.Number)
+		if err == nil {
+			result = map[string]interface{}{"number": input.Number, "status": "deleted"}
+		}
+	default:
+		return returnErrorOutput(fmt.Errorf("unsupported operation: %s", input.Operation)), nil
+	}
+
+	if err != nil {
+		g.logger.WithFields(map[string]interface{}{
+			"tool":             params.Name,
+			goai.ErrorLogField: err,
+			"operation":        input.Operation,
+		}).Error("GitHub milestone operation failed")
+
+		if isNotFound(err) && input.Number > 0 {
+			return returnErrorOutput(fmt.Errorf("milestone #%d does not exist in %s/%s", input.Number, input.Owner, input.Repo)), nil
+		}
+		if isUnprocessable(err) {
+			return returnErrorOutput(errors.New(describeGitHubError(err))), nil
+		}
+		return returnErrorOutput(fmt.Errorf("github milestone %s error: %w", input.Operation, err)), nil
+	}
+
+	m, err := marshalResult(result)
+	if err != nil {
+		return returnErrorOutput(err), nil
+	}
+	g.logger.WithFields(map[string]interface{}{
+		"tool":          params.Name,
+		"operation":     input.Operation,
+		"result_length": len(m),
+	}).Info("GitHub milestone operation completed successfully")
+
+	return goai.CallToolResult{
+		Content: []goai.ToolResultContent{{
+			Type: "json",
+			Text: m,
+		}},
+	}, nil
+}
+
+// validateMilestoneInput checks the milestone number and the enum inputs of an operation, which
+// GitHub would otherwise ignore or reject with a less specific error
+func validateMilestoneInput(operation string, number int, state, sort, direction string) error {
+	if (operation == "update" || operation == "close" || operation == "delete") && number <= 0 {
+		return fmt.Errorf("number is required for operation '%s'", operation)
+	}
+
+	switch {
+	case operation == "list" && state != "" && state != "open" && state != "closed" && state != "all":
+		return fmt.Errorf("unsupported state %q: must be one of open, closed or all", state)
+	case operation != "list" && state != "" && state != "open" && state != "closed":
+		return fmt.Errorf("unsupported state %q: must be open or closed", state)
+	case sort != "" && sort != "due_on" && sort != "completeness":
+		return fmt.Errorf("unsupported sort %q: must be due_on or completeness", sort)
+	case direction != "" && direction != "asc" && direction != "desc":
+		return fmt.Errorf("unsupported direction %q: must be asc or desc", direction)
+	}
+	return nil
+}
+
+// parseDueOn parses a milestone due date given as an ISO date, taken as midnight UTC, or as an
+// RFC 3339 timestamp
+func parseDueOn(value string) (time.Time, error) {
+	if due, err := time.Parse(time.DateOnly, value); err == nil {
+		return due, nil
+	}
+	due, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid due_on %q: must be an ISO date such as 2024-06-30 or an RFC 3339 timestamp", value)
+	}
+	return due.UTC(), nil
+}
+
+func newMilestoneSummary(milestone *github.Milestone) milestoneSummary {
+	summary := milestoneSummary{
+		Number:       milestone.GetNumber(),
+		Title:        milestone.GetTitle(),
+		Description:  milestone.GetDescription(),
+		State:        milestone.GetState(),
+		OpenIssues:   milestone.GetOpenIssues(),
+		ClosedIssues: milestone.GetClosedIssues(),
+		HTMLURL:      milestone.GetHTMLURL(),
+	}
+	if milestone.DueOn != nil {
+		summary.DueOn = milestone.GetDueOn().UTC().Format(time.RFC3339)
+	}
+	return summary
+}
@@ -0,0 +1,262 @@
+package mcptools
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetMilestoneTool(t *testing.T) {
+	gh := &GitHub{client: github.NewClient(nil), logger: &MockLogger{}}
+
+	tool := gh.GetMilestoneTool()
+
+	assert.Equal(t, GitHubMilestonesToolName, tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.NotNil(t, tool.Handler)
+
+	var schema map[string]interface{}
+	require.NoError(t, json.Unmarshal(tool.InputSchema, &schema))
+	assert.Equal(t, []interface{}{"operation", "owner", "repo"}, schema["required"])
+}
+
+func TestParseDueOn(t *testing.T) {
+	due, err := parseDueOn("2024-06-30")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC), due)
+
+	due, err = parseDueOn("2024-06-30T17:00:00+02:00")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 6, 30, 15, 0, 0, 0, time.UTC), due)
+
+	_, err = parseDueOn("next friday")
+	assert.EqualError(t, err, `invalid due_on "next friday": must be an ISO date such as 2024-06-30 or an RFC 3339 timestamp`)
+	_, err = parseDueOn("2024-02-30")
+	assert.Error(t, err)
+}
+
+func TestHandleMilestoneOperation_List(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/milestones", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "all", r.URL.Query().Get("state"))
+		assert.Equal(t, "due_on", r.URL.Query().Get("sort"))
+		assert.Equal(t, "desc", r.URL.Query().Get("direction"))
+
+		assert.NoError(t, json.NewEncoder(w).Encode([]*github.Milestone{
+			{
+				Number:       github.Int(2),
+				Title:        github.String("v1.1"),
+				State:        github.String("open"),
+				DueOn:        &github.Timestamp{Time: time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC)},
+				OpenIssues:   github.Int(3),
+				ClosedIssues: github.Int(1),
+			},
+			{Number: github.Int(1), Title: github.String("v1.0"), State: github.String("closed"), ClosedIssues: github.Int(12)},
+		}))
+	})
+
+	result := callGitHubHandler(t, gh.handleMilestoneOperation, GitHubMilestonesToolName, map[string]interface{}{
+		"operation": "list",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+		"state":     "all",
+		"direction": "desc",
+	})
+	require.False(t, result.IsError, result.Content)
+
+	var milestones []milestoneSummary
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &milestones))
+	assert.Equal(t, []milestoneSummary{
+		{Number: 2, Title: "v1.1", State: "open", DueOn: "2024-09-01T00:00:00Z", OpenIssues: 3, ClosedIssues: 1},
+		{Number: 1, Title: "v1.0", State: "closed", ClosedIssues: 12},
+	}, milestones)
+}
+
+func TestHandleMilestoneOperation_Create(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/milestones", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, map[string]string{"title": "v2.0", "description": "Next major", "due_on": "2024-12-31T00:00:00Z"}, body)
+
+		w.WriteHeader(http.StatusCreated)
+		assert.NoError(t, json.NewEncoder(w).Encode(&github.Milestone{
+			Number:      github.Int(3),
+			Title:       github.String("v2.0"),
+			Description: github.String("Next major"),
+			State:       github.String("open"),
+			DueOn:       &github.Timestamp{Time: time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)},
+		}))
+	})
+
+	result := callGitHubHandler(t, gh.handleMilestoneOperation, GitHubMilestonesToolName, map[string]interface{}{
+		"operation":   "create",
+		"owner":       "test-owner",
+		"repo":        "test-repo",
+		"title":       "v2.0",
+		"description": "Next major",
+		"due_on":      "2024-12-31",
+	})
+	require.False(t, result.IsError, result.Content)
+	assert.JSONEq(t, `{"number": 3, "title": "v2.0", "description": "Next major", "state": "open", "due_on": "2024-12-31T00:00:00Z", "open_issues": 0, "closed_issues": 0}`, result.Content[0].Text)
+}
+
+func TestHandleMilestoneOperation_Close(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/milestones/3", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PATCH", r.Method)
+
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, map[string]string{"state": "closed"}, body)
+
+		assert.NoError(t, json.NewEncoder(w).Encode(&github.Milestone{Number: github.Int(3), Title: github.String("v2.0"), State: github.String("closed")}))
+	})
+
+	result := callGitHubHandler(t, gh.handleMilestoneOperation, GitHubMilestonesToolName, map[string]interface{}{
+		"operation": "close",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+		"number":    3,
+	})
+	require.False(t, result.IsError, result.Content)
+	assert.JSONEq(t, `{"number": 3, "title": "v2.0", "state": "closed", "open_issues": 0, "closed_issues": 0}`, result.Content[0].Text)
+}
+
+func TestHandleMilestoneOperation_Update(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/milestones/3", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PATCH", r.Method)
+
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, map[string]string{"title": "v2.0.0", "due_on": "2025-01-15T00:00:00Z"}, body)
+
+		assert.NoError(t, json.NewEncoder(w).Encode(&github.Milestone{Number: github.Int(3), Title: github.String("v2.0.0"), State: github.String("open")}))
+	})
+
+	result := callGitHubHandler(t, gh.handleMilestoneOperation, GitHubMilestonesToolName, map[string]interface{}{
+		"operation": "update",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+		"number":    3,
+		"title":     "v2.0.0",
+		"due_on":    "2025-01-15",
+	})
+	require.False(t, result.IsError, result.Content)
+}
+
+func TestHandleMilestoneOperation_Delete(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/milestones/3", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "DELETE", r.Method)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	result := callGitHubHandler(t, gh.handleMilestoneOperation, GitHubMilestonesToolName, map[string]interface{}{
+		"operation": "delete",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+		"number":    3,
+	})
+	require.False(t, result.IsError, result.Content)
+	assert.JSONEq(t, `{"number": 3, "status": "deleted"}`, result.Content[0].Text)
+
+	result = callGitHubHandler(t, gh.handleMilestoneOperation, GitHubMilestonesToolName, map[string]interface{}{
+		"operation": "delete",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+		"number":    4,
+	})
+	assert.True(t, result.IsError)
+	assert.Equal(t, "milestone #4 does not exist in test-owner/test-repo", result.Content[0].Text)
+}
+
+func TestHandleMilestoneOperation_InvalidInput(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    map[string]interface{}
+		expected string
+	}{
+		{
+			name:     "create without title",
+			input:    map[string]interface{}{"operation": "create", "owner": "o", "repo": "r"},
+			expected: "title is required for operation 'create'",
+		},
+		{
+			name:     "bad due date",
+			input:    map[string]interface{}{"operation": "create", "owner": "o", "repo": "r", "title": "v1", "due_on": "31/12/2024"},
+			expected: `invalid due_on "31/12/2024": must be an ISO date such as 2024-06-30 or an RFC 3339 timestamp`,
+		},
+		{
+			name:     "close without number",
+			input:    map[string]interface{}{"operation": "close", "owner": "o", "repo": "r"},
+			expected: "number is required for operation 'close'",
+		},
+		{
+			name:     "all state outside of list",
+			input:    map[string]interface{}{"operation": "update", "owner": "o", "repo": "r", "number": 1, "state": "all"},
+			expected: `unsupported state "all": must be open or closed`,
+		},
+		{
+			name:     "unsupported sort",
+			input:    map[string]interface{}{"operation": "list", "owner": "o", "repo": "r", "sort": "title"},
+			expected: `unsupported sort "title": must be due_on or completeness`,
+		},
+		{
+			name:     "unsupported operation",
+			input:    map[string]interface{}{"operation": "reopen", "owner": "o", "repo": "r"},
+			expected: "unsupported operation: reopen",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gh, _, cleanup := setupGitHubTest(t)
+			gh.logger = newPermissiveLogger()
+			defer cleanup()
+
+			result := callGitHubHandler(t, gh.handleMilestoneOperation, GitHubMilestonesToolName, tt.input)
+			assert.True(t, result.IsError)
+			assert.Equal(t, tt.expected, result.Content[0].Text)
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/google/go-github/v60/github"
 	"github.com/shaharia-lab/goai"
@@ -19,7 +20,7 @@ func (g *GitHub) GetPullRequestsTool() goai.Tool {
 			"properties": {
 				"operation": {
 					"type": "string",
-					"enum": ["create", "get", "list", "update", "merge", "review", "list_files"],
+					"enum": ["create", "get", "list", "update", "merge", "review", "list_files", "create_review", "list_reviews", "create_review_comment"],
 					"description": "Pull request operation to perform"
 				},
 				"owner": {
@@ -58,6 +59,41 @@ func (g *GitHub) GetPullRequestsTool() goai.Tool {
 					"type": "string",
 					"enum": ["APPROVE", "REQUEST_CHANGES", "COMMENT"],
 					"description": "Review event type"
+				},
+				"draft": {
+					"type": "boolean",
+					"description": "Open the pull request as a draft"
+				},
+				"merge_method": {
+					"type": "string",
+					"enum": ["merge", "squash", "rebase"],
+					"description": "Merge method (defaults to the repository's default)"
+				},
+				"commit_title": {
+					"type": "string",
+					"description": "Title of the merge commit; body is used as the commit message"
+				},
+				"event": {
+					"type": "string",
+					"enum": ["APPROVE", "REQUEST_CHANGES", "COMMENT"],
+					"description": "Review event for create_review; body is the review text"
+				},
+				"path": {
+					"type": "string",
+					"description": "File the inline comment of create_review_comment is attached to"
+				},
+				"line": {
+					"type": "integer",
+					"description": "Line of the diff the inline comment of create_review_comment is attached to"
+				},
+				"side": {
+					"type": "string",
+					"enum": ["LEFT", "RIGHT"],
+					"description": "Side of the diff the line refers to for create_review_comment (default RIGHT)"
+				},
+				"commit_id": {
+					"type": "string",
+					"description": "SHA of the commit the inline comment of create_review_comment refers to"
 				}
 			},
 			"required": ["operation", "owner", "repo"]
@@ -86,12 +122,27 @@ func (g *GitHub) handlePullRequestsOperation(ctx context.Context, params goai.Ca
 		Base          string `json:"base"`
 		ReviewComment string `json:"review_comment"`
 		ReviewEvent   string `json:"review_event"`
+		Draft         bool   `json:"draft"`
+		MergeMethod   string `json:"merge_method"`
+		CommitTitle   string `json:"commit_title"`
+		Event         string `json:"event"`
+		Path          string `json:"path"`
+		Line          int    `json:"line"`
+		Side          string `json:"side"`
+		CommitID      string `json:"commit_id"`
 	}
 
 	if err := json.Unmarshal(params.Arguments, &input); err != nil {
 		return goai.CallToolResult{}, fmt.Errorf("failed to unmarshal input: %w", err)
 	}
 
+	switch input.Operation {
+	case "create_review", "list_reviews", "create_review_comment":
+		if input.Number <= 0 {
+			return returnErrorOutput(fmt.Errorf("number is required for operation '%s'", input.Operation)), nil
+		}
+	}
+
 	var result interface{}
 	var err error
 
@@ -102,6 +153,7 @@ func (g *GitHub) handlePullRequestsOperation(ctx context.Context, params goai.Ca
 			Body:  &input.Body,
 			Head:  &input.Head,
 			Base:  &input.Base,
+			Draft: &input.Draft,
 		})
 	case "get":
 		result, _, err = g.client.PullRequests.Get(ctx, input.Owner, input.Repo, input.Number)
@@ -113,7 +165,15 @@ func (g *GitHub) handlePullRequestsOperation(ctx context.Context, params goai.Ca
 			Body:  &input.Body,
 		})
 	case "merge":
-		result, _, err = g.client.PullRequests.Merge(ctx, input.Owner, input.Repo, input.Number, input.Body, &github.PullRequestOptions{})
+		switch input.MergeMethod {
+		case "", "merge", "squash", "rebase":
+		default:
+			return returnErrorOutput(fmt.Errorf("unsupported merge_method: %s", input.MergeMethod)), nil
+		}
+		result, _, err = g.client.PullRequests.Merge(ctx, input.Owner, input.Repo, input.Number, input.Body, &github.PullRequestOptions{
+			CommitTitle: input.CommitTitle,
+			MergeMethod: input.MergeMethod,
+		})
 	case "review":
 		result, _, err = g.client.PullRequests.CreateReview(ctx, input.Owner, input.Repo, input.Number, &github.PullRequestReviewRequest{
 			Body:  &input.ReviewComment,
@@ -121,15 +181,71 @@ func (g *GitHub) handlePullRequestsOperation(ctx context.Context, params goai.Ca
 		})
 	case "list_files":
 		result, _, err = g.client.PullRequests.ListFiles(ctx, input.Owner, input.Repo, input.Number, &github.ListOptions{})
+	case "create_review":
+		if !reviewEvents[input.Event] {
+			return returnErrorOutput(fmt.Errorf("unsupported event %q: must be one of APPROVE, REQUEST_CHANGES or COMMENT", input.Event)), nil
+		}
+		if input.Event != "APPROVE" && input.Body == "" {
+			return returnErrorOutput(fmt.Errorf("create_review with event %s requires body", input.Event)), nil
+		}
+		var review *github.PullRequestReview
+		review, _, err = g.client.PullRequests.CreateReview(ctx, input.Owner, input.Repo, input.Number, &github.PullRequestReviewRequest{
+			Body:  &input.Body,
+			Event: &input.Event,
+		})
+		if err == nil {
+			result = newReviewSummary(review)
+		}
+	case "list_reviews":
+		var reviews []*github.PullRequestReview
+		reviews, err = paginate(listPageSize(0), listResultCap(0), func(opts github.ListOptions) ([]*github.PullRequestReview, *github.Response, error) {
+			return g.client.PullRequests.ListReviews(ctx, input.Owner, input.Repo, input.Number, &opts)
+		})
+		summaries := make([]reviewSummary, 0, len(reviews))
+		for _, review := range reviews {
+			summaries = append(summaries, newReviewSummary(review))
+		}
+		result = summaries
+	case "create_review_comment":
+		// GitHub rejects inline comments with an unhelpful 422 when any of these is missing
+		if input.Path == "" || input.Line <= 0 || input.CommitID == "" || input.Body == "" {
+			return returnErrorOutput(fmt.Errorf("create_review_comment requires path, line, commit_id and body")), nil
+		}
+		comment := &github.PullRequestComment{
+			Body:     &input.Body,
+			Path:     &input.Path,
+			Line:     &input.Line,
+			CommitID: &input.CommitID,
+		}
+		if input.Side != "" {
+			comment.Side = &input.Side
+		}
+		var created *github.PullRequestComment
+		created, _, err = g.client.PullRequests.CreateComment(ctx, input.Owner, input.Repo, input.Number, comment)
+		if err == nil {
+			result = reviewCommentSummary{
+				ID:       created.GetID(),
+				Path:     created.GetPath(),
+				Line:     created.GetLine(),
+				CommitID: created.GetCommitID(),
+				HTMLURL:  created.GetHTMLURL(),
+			}
+		}
 	default:
 		return returnErrorOutput(fmt.Errorf("unsupported operation: %s", input.Operation)), nil
 	}
 
 	if err != nil {
+		if isUnprocessable(err) {
+			return returnErrorOutput(fmt.Errorf("github pull request %s rejected: %s", input.Operation, describeGitHubError(err))), nil
+		}
 		return returnErrorOutput(fmt.Errorf("github pull request %s error: %w", input.Operation, err)), nil
 	}
 
-	m := mustMarshal(result)
+	m, err := marshalResult(result)
+	if err != nil {
+		return returnErrorOutput(err), nil
+	}
 
 	g.logger.WithFields(map[string]interface{}{
 		"tool":          GitHubPullRequestsToolName,
@@ -144,3 +260,45 @@ func (g *GitHub) handlePullRequestsOperation(ctx context.Context, params goai.Ca
 		}},
 	}, nil
 }
+
+// reviewEvents are the events a pull request review can be submitted with
+var reviewEvents = map[string]bool{
+	"APPROVE":         true,
+	"REQUEST_CHANGES": true,
+	"COMMENT":         true,
+}
+
+// reviewSummary is the pull request review information reported by the review operations
+type reviewSummary struct {
+	ID          int64  `json:"id"`
+	State       string `json:"state"`
+	User        string `json:"user,omitempty"`
+	Body        string `json:"body,omitempty"`
+	CommitID    string `json:"commit_id,omitempty"`
+	HTMLURL     string `json:"html_url,omitempty"`
+	SubmittedAt string `json:"submitted_at,omitempty"`
+}
+
+// reviewCommentSummary is the inline comment created by create_review_comment
+type reviewCommentSummary struct {
+	ID       int64  `json:"id"`
+	Path     string `json:"path"`
+	Line     int    `json:"line"`
+	CommitID string `json:"commit_id"`
+	HTMLURL  string `json:"html_url,omitempty"`
+}
+
+func newReviewSummary(review *github.PullRequestReview) reviewSummary {
+	summary := reviewSummary{
+		ID:       review.GetID(),
+		State:    review.GetState(),
+		User:     review.GetUser().GetLogin(),
+		Body:     review.GetBody(),
+		CommitID: review.GetCommitID(),
+		HTMLURL:  review.GetHTMLURL(),
+	}
+	if review.SubmittedAt != nil {
+		summary.SubmittedAt = review.GetSubmittedAt().UTC().Format(time.RFC3339)
+	}
+	return summary
+}
@@ -54,6 +54,9 @@ func TestGetPullRequestsTool(t *testing.T) {
 	assert.Contains(t, enum, "merge")
 	assert.Contains(t, enum, "review")
 	assert.Contains(t, enum, "list_files")
+	assert.Contains(t, enum, "create_review")
+	assert.Contains(t, enum, "list_reviews")
+	assert.Contains(t, enum, "create_review_comment")
 }
 
 func TestHandlePullRequestsOperation_Create(t *testing.T) {
@@ -361,3 +364,251 @@ func TestHandlePullRequestsOperation_ListFiles(t *testing.T) {
 	assert.Equal(t, "file1.go", *files[0].Filename)
 	assert.Equal(t, "modified", *files[0].Status)
 }
+
+func TestHandlePullRequestsOperation_CreateDraft(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/pulls", func(w http.ResponseWriter, r *http.Request) {
+		var pr github.NewPullRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&pr))
+		assert.True(t, pr.GetDraft())
+
+		assert.NoError(t, json.NewEncoder(w).Encode(&github.PullRequest{Number: github.Int(3), Draft: github.Bool(true)}))
+	})
+
+	result := callGitHubHandler(t, gh.handlePullRequestsOperation, GitHubPullRequestsToolName, map[string]interface{}{
+		"operation": "create",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+		"title":     "WIP",
+		"head":      "feature",
+		"base":      "main",
+		"draft":     true,
+	})
+	require.False(t, result.IsError, result.Content)
+
+	var pr github.PullRequest
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &pr))
+	assert.True(t, pr.GetDraft())
+}
+
+func TestHandlePullRequestsOperation_CreateSameBranch(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/pulls", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"message": "Validation Failed", "errors": [{"resource": "PullRequest", "code": "custom", "message": "No commits between main and main"}]}`))
+	})
+
+	result := callGitHubHandler(t, gh.handlePullRequestsOperation, GitHubPullRequestsToolName, map[string]interface{}{
+		"operation": "create",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+		"title":     "Same branch",
+		"head":      "main",
+		"base":      "main",
+	})
+
+	assert.True(t, result.IsError)
+	assert.Equal(t, "github pull request create rejected: Validation Failed: No commits between main and main", result.Content[0].Text)
+}
+
+func TestHandlePullRequestsOperation_MergeMethod(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/pulls/1/merge", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "squash", body["merge_method"])
+		assert.Equal(t, "Add feature (#1)", body["commit_title"])
+
+		assert.NoError(t, json.NewEncoder(w).Encode(&github.PullRequestMergeResult{
+			SHA:    github.String("6dcb09b5b57875f334f61aebed695e2e4193db5e"),
+			Merged: github.Bool(true),
+		}))
+	})
+
+	result := callGitHubHandler(t, gh.handlePullRequestsOperation, GitHubPullRequestsToolName, map[string]interface{}{
+		"operation":    "merge",
+		"owner":        "test-owner",
+		"repo":         "test-repo",
+		"number":       1,
+		"merge_method": "squash",
+		"commit_title": "Add feature (#1)",
+	})
+	require.False(t, result.IsError, result.Content)
+
+	var merged github.PullRequestMergeResult
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &merged))
+	assert.Equal(t, "6dcb09b5b57875f334f61aebed695e2e4193db5e", merged.GetSHA())
+
+	result = callGitHubHandler(t, gh.handlePullRequestsOperation, GitHubPullRequestsToolName, map[string]interface{}{
+		"operation":    "merge",
+		"owner":        "test-owner",
+		"repo":         "test-repo",
+		"number":       1,
+		"merge_method": "octopus",
+	})
+	assert.True(t, result.IsError)
+}
+
+func TestHandlePullRequestsOperation_CreateReview(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/pulls/7/reviews", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+
+		var body github.PullRequestReviewRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "REQUEST_CHANGES", body.GetEvent())
+		assert.Equal(t, "Please add tests", body.GetBody())
+
+		assert.NoError(t, json.NewEncoder(w).Encode(&github.PullRequestReview{
+			ID:    github.Int64(99),
+			State: github.String("CHANGES_REQUESTED"),
+			Body:  github.String("Please add tests"),
+			User:  &github.User{Login: github.String("reviewer")},
+		}))
+	})
+
+	result := callGitHubHandler(t, gh.handlePullRequestsOperation, GitHubPullRequestsToolName, map[string]interface{}{
+		"operation": "create_review",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+		"number":    7,
+		"event":     "REQUEST_CHANGES",
+		"body":      "Please add tests",
+	})
+	require.False(t, result.IsError, result.Content)
+	assert.JSONEq(t, `{"id": 99, "state": "CHANGES_REQUESTED", "user": "reviewer", "body": "Please add tests"}`, result.Content[0].Text)
+}
+
+func TestHandlePullRequestsOperation_ListReviews(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/pulls/7/reviews", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.NoError(t, json.NewEncoder(w).Encode([]*github.PullRequestReview{
+			{ID: github.Int64(1), State: github.String("COMMENTED"), User: &github.User{Login: github.String("alice")}},
+			{ID: github.Int64(2), State: github.String("APPROVED"), User: &github.User{Login: github.String("bob")}},
+		}))
+	})
+
+	result := callGitHubHandler(t, gh.handlePullRequestsOperation, GitHubPullRequestsToolName, map[string]interface{}{
+		"operation": "list_reviews",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+		"number":    7,
+	})
+	require.False(t, result.IsError, result.Content)
+
+	var reviews []reviewSummary
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &reviews))
+	assert.Equal(t, []reviewSummary{
+		{ID: 1, State: "COMMENTED", User: "alice"},
+		{ID: 2, State: "APPROVED", User: "bob"},
+	}, reviews)
+}
+
+func TestHandlePullRequestsOperation_CreateReviewComment(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/pulls/7/comments", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+
+		var body github.PullRequestComment
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "main.go", body.GetPath())
+		assert.Equal(t, 12, body.GetLine())
+		assert.Equal(t, "abc123", body.GetCommitID())
+		assert.Equal(t, "Handle this error", body.GetBody())
+
+		w.WriteHeader(http.StatusCreated)
+		body.ID = github.Int64(5)
+		assert.NoError(t, json.NewEncoder(w).Encode(&body))
+	})
+
+	result := callGitHubHandler(t, gh.handlePullRequestsOperation, GitHubPullRequestsToolName, map[string]interface{}{
+		"operation": "create_review_comment",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+		"number":    7,
+		"path":      "main.go",
+		"line":      12,
+		"commit_id": "abc123",
+		"body":      "Handle this error",
+	})
+	require.False(t, result.IsError, result.Content)
+	assert.JSONEq(t, `{"id": 5, "path": "main.go", "line": 12, "commit_id": "abc123"}`, result.Content[0].Text)
+}
+
+func TestHandlePullRequestsOperation_ReviewInvalidInput(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    map[string]interface{}
+		expected string
+	}{
+		{
+			name:     "missing number",
+			input:    map[string]interface{}{"operation": "list_reviews", "owner": "o", "repo": "r"},
+			expected: "number is required for operation 'list_reviews'",
+		},
+		{
+			name:     "unsupported event",
+			input:    map[string]interface{}{"operation": "create_review", "owner": "o", "repo": "r", "number": 1, "event": "LGTM"},
+			expected: `unsupported event "LGTM": must be one of APPROVE, REQUEST_CHANGES or COMMENT`,
+		},
+		{
+			name:     "comment review without body",
+			input:    map[string]interface{}{"operation": "create_review", "owner": "o", "repo": "r", "number": 1, "event": "COMMENT"},
+			expected: "create_review with event COMMENT requires body",
+		},
+		{
+			name:     "inline comment without commit",
+			input:    map[string]interface{}{"operation": "create_review_comment", "owner": "o", "repo": "r", "number": 1, "path": "main.go", "line": 3, "body": "nit"},
+			expected: "create_review_comment requires path, line, commit_id and body",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gh, _, cleanup := setupGitHubTest(t)
+			gh.logger = newPermissiveLogger()
+			defer cleanup()
+
+			result := callGitHubHandler(t, gh.handlePullRequestsOperation, GitHubPullRequestsToolName, tt.input)
+			assert.True(t, result.IsError)
+			assert.Equal(t, tt.expected, result.Content[0].Text)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/shaharia-lab/goai"
+)
+
+// rateLimitBudget is the budget of a GitHub API rate limit
+type rateLimitBudget struct {
+	Limit     int    `json:"limit"`
+	Remaining int    `json:"remaining"`
+	Reset     string `json:"reset"`
+}
+
+// rateLimitStatus is the API budget reported by the rate limit tool
+type rateLimitStatus struct {
+	Core    *rateLimitBudget `json:"core"`
+	Search  *rateLimitBudget `json:"search"`
+	GraphQL *rateLimitBudget `json:"graphql"`
+}
+
+// GetRateLimitTool returns a read-only tool reporting the remaining GitHub API budget. Querying
+// the rate limits does not count against them.
+func (g *GitHub) GetRateLimitTool() goai.Tool {
+	return goai.Tool{
+		Name:        GitHubRateLimitToolName,
+		Description: "Reports the remaining GitHub API budget and reset times of the core, search and GraphQL rate limits",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {}
+		}`),
+		Handler: g.handleRateLimit,
+	}
+}
+
+func (g *GitHub) handleRateLimit(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
+	ctx, span := goai.StartSpan(ctx, fmt.Sprintf("%s.Handler", params.Name))
+	defer span.End()
+
+	g.logger.WithFields(map[string]interface{}{
+		"tool": params.Name,
+	}).Info("handling rate limit request")
+
+	limits, _, err := g.client.RateLimit.Get(ctx)
+	if err != nil {
+		g.logger.WithFields(map[string]interface{}{
+			"tool":             params.Name,
+			goai.ErrorLogField: err,
+		}).Error("GitHub rate limit request failed")
+
+		return returnErrorOutput(fmt.Errorf("github rate limit error: %w", err)), nil
+	}
+
+	status := rateLimitStatus{
+		Core:    newRateLimitBudget(limits.GetCore()),
+		Search:  newRateLimitBudget(limits.GetSearch()),
+		GraphQL: newRateLimitBudget(limits.GetGraphQL()),
+	}
+
+	m, err := marshalResult(status)
+	if err != nil {
+		return returnErrorOutput(err), nil
+	}
+	g.logger.WithFields(map[string]interface{}{
+		"tool":          params.Name,
+		"result_length": len(m),
+	}).Info("GitHub rate limit request completed successfully")
+
+	return goai.CallToolResult{
+		Content: []goai.ToolResultContent{{
+			Type: "json",
+			Text: m,
+		}},
+	}, nil
+}
+
+// newRateLimitBudget converts a go-github rate, which is nil when GitHub does not report that
+// limit, to its budget
+func newRateLimitBudget(rate *github.Rate) *rateLimitBudget {
+	if rate == nil {
+		return nil
+	}
+	return &rateLimitBudget{
+		Limit:     rate.Limit,
+		Remaining: rate.Remaining,
+		Reset:     rate.Reset.UTC().Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,65 @@
+package mcptools
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRateLimitTool(t *testing.T) {
+	gh := &GitHub{client: github.NewClient(nil), logger: &MockLogger{}}
+
+	tool := gh.GetRateLimitTool()
+
+	assert.Equal(t, GitHubRateLimitToolName, tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.NotNil(t, tool.Handler)
+}
+
+func TestHandleRateLimit(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/rate_limit", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		_, _ = w.Write([]byte(`{"resources": {
+			"core": {"limit": 5000, "remaining": 4210, "reset": 1717243200},
+			"search": {"limit": 30, "remaining": 30, "reset": 1717239660},
+			"graphql": {"limit": 5000, "remaining": 0, "reset": 1717243200}
+		}}`))
+	})
+
+	result := callGitHubHandler(t, gh.handleRateLimit, GitHubRateLimitToolName, map[string]interface{}{})
+	require.False(t, result.IsError, result.Content)
+	assert.JSONEq(t, `{
+		"core": {"limit": 5000, "remaining": 4210, "reset": "2024-06-01T12:00:00Z"},
+		"search": {"limit": 30, "remaining": 30, "reset": "2024-06-01T11:01:00Z"},
+		"graphql": {"limit": 5000, "remaining": 0, "reset": "2024-06-01T12:00:00Z"}
+	}`, result.Content[0].Text)
+}
+
+func TestHandleRateLimit_Error(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/rate_limit", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"message": "Bad credentials"}`))
+	})
+
+	result := callGitHubHandler(t, gh.handleRateLimit, GitHubRateLimitToolName, map[string]interface{}{})
+	assert.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].Text, "github rate limit error:")
+	assert.Contains(t, result.Content[0].Text, "Bad credentials")
+}
@@ -0,0 +1,262 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/shaharia-lab/goai"
+)
+
+// releaseAsset is the asset information reported for a release
+type releaseAsset struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Size        int    `json:"size"`
+	DownloadURL string `json:"download_url"`
+}
+
+// releaseSummary is the release information reported by the releases tool
+type releaseSummary struct {
+	ID         int64          `json:"id"`
+	TagName    string         `json:"tag_name"`
+	Name       string         `json:"name"`
+	HTMLURL    string         `json:"html_url"`
+	Draft      bool           `json:"draft"`
+	Prerelease bool           `json:"prerelease"`
+	Assets     []releaseAsset `json:"assets"`
+}
+
+// GetReleaseTool returns a tool for managing GitHub releases and their assets
+func (g *GitHub) GetReleaseTool() goai.Tool {
+	return goai.Tool{
+		Name:        GitHubReleasesToolName,
+		Description: "Manages GitHub releases - create, get, list, delete, upload assets",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"operation": {
+					"type": "string",
+					"enum": ["create", "get", "list", "delete", "upload_asset"],
+					"description": "Release operation to perform"
+				},
+				"owner": {
+					"type": "string",
+					"description": "Repository owner"
+				},
+				"repo": {
+					"type": "string",
+					"description": "Repository name"
+				},
+				"release_id": {
+					"type": "integer",
+					"description": "Release ID for get, delete and upload_asset"
+				},
+				"tag_name": {
+					"type": "string",
+					"description": "Tag of the release to create, or to get when release_id is not set"
+				},
+				"target_commitish": {
+					"type": "string",
+					"description": "Branch or commit SHA the tag is created from"
+				},
+				"name": {
+					"type": "string",
+					"description": "Release name, or the asset name for upload_asset (defaults to the file name)"
+				},
+				"body": {
+					"type": "string",
+					"description": "Release notes"
+				},
+				"draft": {
+					"type": "boolean",
+					"description": "Create the release as a draft"
+				},
+				"prerelease": {
+					"type": "boolean",
+					"description": "Mark the release as a prerelease"
+				},
+				"file_path": {
+					"type": "string",
+					"description": "Local path of the file to upload as a release asset"
+				},
+				"per_page": {
+					"type": "integer",
+					"description": "Number of releases to list (default 30, max 100)"
+				}
+			},
+			"required": ["operation", "owner", "repo"]
+		}`),
+		Handler: g.handleReleaseOperation,
+	}
+}
+
+func (g *GitHub) handleReleaseOperation(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
+	ctx, span := goai.StartSpan(ctx, fmt.Sprintf("%s.Handler", params.Name))
+	defer span.End()
+
+	g.logger.WithFields(map[string]interface{}{
+		"tool":          params.Name,
+		"tool_argument": string(params.Arguments),
+	}).Info("handling release operation")
+
+	var input struct {
+		Operation       string `json:"operation"`
+		Owner           string `json:"owner"`
+		Repo            string `json:"repo"`
+		ReleaseID       int64  `json:"release_id"`
+		TagName         string `json:"tag_name"`
+		TargetCommitish string `json:"target_commitish"`
+		Name            string `json:"name"`
+		Body            string `json:"body"`
+		Draft           bool   `json:"draft"`
+		Prerelease      bool   `json:"prerelease"`
+		FilePath        string `json:"file_path"`
+		PerPage         int    `json:"per_page"`
+	}
+
+	if err := json.Unmarshal(params.Arguments, &input); err != nil {
+		return goai.CallToolResult{}, fmt.Errorf("failed to unmarshal input: %w", err)
+	}
+
+	var result interface{}
+	var err error
+
+	switch input.Operation {
+	case "create":
+		if input.TagName == "" {
+			return returnErrorOutput(fmt.Errorf("tag_name is required for create")), nil
+		}
+		var release *github.RepositoryRelease
+		release, _, err = g.client.Repositories.CreateRelease(ctx, input.Owner, input.Repo, &github.RepositoryRelease{
+			TagName:         &input.TagName,
+			TargetCommitish: optionalString(input.TargetCommitish),
+			Name:            optionalString(input.Name),
+			Body:            optionalString(input.Body),
+			Draft:           &input.Draft,
+			Prerelease:      &input.Prerelease,
+		})
+		if err == nil {
+			result = newReleaseSummary(release)
+		}
+
+	case "get":
+		var release *github.RepositoryRelease
+		switch {
+		case input.ReleaseID != 0:
+			release, _, err = g.client.Repositories.GetRelease(ctx, input.Owner, input.Repo, input.ReleaseID)
+		case input.TagName != "":
+			release, _, err = g.client.Repositories.GetReleaseByTag(ctx, input.Owner, input.Repo, input.TagName)
+		default:
+			return returnErrorOutput(fmt.Errorf("release_id or tag_name is required for get")), nil
+		}
+		if err == nil {
+			result = newReleaseSummary(release)
+		}
+
+	case "list":
+		var releases []*github.RepositoryRelease
+		releases, _, err = g.client.Repositories.ListReleases(ctx, input.Owner, input.Repo, &github.ListOptions{PerPage: input.PerPage})
+		if err == nil {
+			summaries := make([]releaseSummary, 0, len(releases))
+			for _, release := range releases {
+				summaries = append(summaries, newReleaseSummary(release))
+			}
+			result = summaries
+		}
+
+	case "delete":
+		if input.ReleaseID == 0 {
+			return returnErrorOutput(fmt.Errorf("release_id is required for delete")), nil
+		}
+		_, err = g.client.Repositories.DeleteRelease(ctx, input.Owner, input.Repo, input.ReleaseID)
+		result = map[string]interface{}{"release_id": input.ReleaseID, "deleted": err == nil}
+
+	case "upload_asset":
+		if input.ReleaseID == 0 || input.FilePath == "" {
+			return returnErrorOutput(fmt.Errorf("release_id and file_path are required for upload_asset")), nil
+		}
+		result, err = g.uploadReleaseAsset(ctx, input.Owner, input.Repo, input.ReleaseID, input.FilePath, input.Name)
+
+	default:
+		return returnErrorOutput(fmt.Errorf("unsupported operation: %s", input.Operation)), nil
+	}
+
+	if err != nil {
+		g.logger.WithFields(map[string]interface{}{
+			"tool":             params.Name,
+			goai.ErrorLogField: err,
+			"operation":        input.Operation,
+		}).Error("GitHub release operation failed")
+
+		return returnErrorOutput(fmt.Errorf("github release %s error: %w", input.Operation, err)), nil
+	}
+
+	m, err := marshalResult(result)
+	if err != nil {
+		return returnErrorOutput(err), nil
+	}
+	g.logger.WithFields(map[string]interface{}{
+		"tool":          params.Name,
+		"operation":     input.Operation,
+		"result_length": len(m),
+	}).Info("GitHub release operation completed successfully")
+
+	return goai.CallToolResult{
+		Content: []goai.ToolResultContent{{
+			Type: "json",
+			Text: m,
+		}},
+	}, nil
+}
+
+// uploadReleaseAsset uploads the file at filePath to a release. The open file is passed to
+// the client as the request body, so it is streamed instead of read into memory.
+func (g *GitHub) uploadReleaseAsset(ctx context.Context, owner, repo string, releaseID int64, filePath, name string) (*releaseAsset, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open asset: %w", err)
+	}
+	defer file.Close()
+
+	if name == "" {
+		name = filepath.Base(filePath)
+	}
+
+	asset, _, err := g.client.Repositories.UploadReleaseAsset(ctx, owner, repo, releaseID, &github.UploadOptions{Name: name}, file)
+	if err != nil {
+		return nil, err
+	}
+
+	return &releaseAsset{
+		ID:          asset.GetID(),
+		Name:        asset.GetName(),
+		Size:        asset.GetSize(),
+		DownloadURL: asset.GetBrowserDownloadURL(),
+	}, nil
+}
+
+// newReleaseSummary extracts the reported release fields, including asset download URLs
+func newReleaseSummary(release *github.RepositoryRelease) releaseSummary {
+	summary := releaseSummary{
+		ID:         release.GetID(),
+		TagName:    release.GetTagName(),
+		Name:       release.GetName(),
+		HTMLURL:    release.GetHTMLURL(),
+		Draft:      release.GetDraft(),
+		Prerelease: release.GetPrerelease(),
+		Assets:     make([]releaseAsset, 0, len(release.Assets)),
+	}
+	for _, asset := range release.Assets {
+		summary.Assets = append(summary.Assets, releaseAsset{
+			ID:          asset.GetID(),
+			Name:        asset.GetName(),
+			Size:        asset.GetSize(),
+			DownloadURL: asset.GetBrowserDownloadURL(),
+		})
+	}
+	return summary
+}
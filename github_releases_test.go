@@ -0,0 +1,187 @@
+package mcptools
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetReleaseTool(t *testing.T) {
+	gh := &GitHub{
+		client: github.NewClient(nil),
+		logger: &MockLogger{},
+	}
+
+	tool := gh.GetReleaseTool()
+
+	assert.Equal(t, GitHubReleasesToolName, tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.NotNil(t, tool.Handler)
+}
+
+func TestHandleReleaseOperation_Create(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/releases", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+
+		var release github.RepositoryRelease
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&release))
+		assert.Equal(t, "v1.2.0", release.GetTagName())
+		assert.Equal(t, "main", release.GetTargetCommitish())
+		assert.Equal(t, "Release 1.2.0", release.GetName())
+		assert.True(t, release.GetDraft())
+		assert.False(t, release.GetPrerelease())
+
+		release.ID = github.Int64(42)
+		release.HTMLURL = github.String("https://github.com/test-owner/test-repo/releases/tag/v1.2.0")
+		assert.NoError(t, json.NewEncoder(w).Encode(&release))
+	})
+
+	result := callGitHubHandler(t, gh.handleReleaseOperation, GitHubReleasesToolName, map[string]interface{}{
+		"operation":        "create",
+		"owner":            "test-owner",
+		"repo":             "test-repo",
+		"tag_name":         "v1.2.0",
+		"target_commitish": "main",
+		"name":             "Release 1.2.0",
+		"body":             "Notes",
+		"draft":            true,
+	})
+	require.False(t, result.IsError, result.Content)
+
+	var release releaseSummary
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &release))
+	assert.Equal(t, int64(42), release.ID)
+	assert.Equal(t, "https://github.com/test-owner/test-repo/releases/tag/v1.2.0", release.HTMLURL)
+}
+
+func TestHandleReleaseOperation_GetByTag(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/releases/tags/v1.0.0", func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, json.NewEncoder(w).Encode(&github.RepositoryRelease{
+			ID:      github.Int64(7),
+			TagName: github.String("v1.0.0"),
+			Assets: []*github.ReleaseAsset{{
+				ID:                 github.Int64(1),
+				Name:               github.String("tool.tar.gz"),
+				BrowserDownloadURL: github.String("https://github.com/test-owner/test-repo/releases/download/v1.0.0/tool.tar.gz"),
+			}},
+		}))
+	})
+
+	result := callGitHubHandler(t, gh.handleReleaseOperation, GitHubReleasesToolName, map[string]interface{}{
+		"operation": "get",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+		"tag_name":  "v1.0.0",
+	})
+	require.False(t, result.IsError, result.Content)
+
+	var release releaseSummary
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &release))
+	require.Len(t, release.Assets, 1)
+	assert.Equal(t, "https://github.com/test-owner/test-repo/releases/download/v1.0.0/tool.tar.gz", release.Assets[0].DownloadURL)
+}
+
+func TestHandleReleaseOperation_UploadAsset(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	gh.client.UploadURL = gh.client.BaseURL
+	defer cleanup()
+
+	content := []byte("binary artifact contents")
+	filePath := filepath.Join(t.TempDir(), "tool-linux-amd64.tar.gz")
+	require.NoError(t, os.WriteFile(filePath, content, 0644))
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/releases/42/assets", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "tool-linux-amd64.tar.gz", r.URL.Query().Get("name"))
+		assert.Equal(t, int64(len(content)), r.ContentLength)
+
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, content, body)
+
+		assert.NoError(t, json.NewEncoder(w).Encode(&github.ReleaseAsset{
+			ID:                 github.Int64(9),
+			Name:               github.String("tool-linux-amd64.tar.gz"),
+			Size:               github.Int(len(content)),
+			BrowserDownloadURL: github.String("https://github.com/test-owner/test-repo/releases/download/v1.2.0/tool-linux-amd64.tar.gz"),
+		}))
+	})
+
+	result := callGitHubHandler(t, gh.handleReleaseOperation, GitHubReleasesToolName, map[string]interface{}{
+		"operation":  "upload_asset",
+		"owner":      "test-owner",
+		"repo":       "test-repo",
+		"release_id": 42,
+		"file_path":  filePath,
+	})
+	require.False(t, result.IsError, result.Content)
+
+	var asset releaseAsset
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &asset))
+	assert.Equal(t, int64(9), asset.ID)
+	assert.Equal(t, "https://github.com/test-owner/test-repo/releases/download/v1.2.0/tool-linux-amd64.tar.gz", asset.DownloadURL)
+}
+
+func TestHandleReleaseOperation_UploadMissingFile(t *testing.T) {
+	gh, _, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	result := callGitHubHandler(t, gh.handleReleaseOperation, GitHubReleasesToolName, map[string]interface{}{
+		"operation":  "upload_asset",
+		"owner":      "test-owner",
+		"repo":       "test-repo",
+		"release_id": 42,
+		"file_path":  filepath.Join(t.TempDir(), "missing.tar.gz"),
+	})
+	assert.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].Text, "failed to open asset")
+}
+
+func TestHandleReleaseOperation_Delete(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/releases/42", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "DELETE", r.Method)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	result := callGitHubHandler(t, gh.handleReleaseOperation, GitHubReleasesToolName, map[string]interface{}{
+		"operation":  "delete",
+		"owner":      "test-owner",
+		"repo":       "test-repo",
+		"release_id": 42,
+	})
+	require.False(t, result.IsError, result.Content)
+	assert.JSONEq(t, `{"release_id": 42, "deleted": true}`, result.Content[0].Text)
+}
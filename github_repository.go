@@ -3,7 +3,12 @@ package mcptools
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
 
 	"github.com/google/go-github/v60/github"
 	"github.com/shaharia-lab/goai"
@@ -13,13 +18,13 @@ import (
 func (g *GitHub) GetRepositoryTool() goai.Tool {
 	return goai.Tool{
 		Name:        GitHubRepositoryToolName,
-		Description: "Manages GitHub repositories - create, delete, update, fork",
+		Description: "Manages GitHub repositories - create, list, delete, update, fork",
 		InputSchema: json.RawMessage(`{
 			"type": "object",
 			"properties": {
 				"operation": {
 					"type": "string",
-					"enum": ["create", "delete", "update", "fork", "list_branches", "create_branch", "protect_branch"],
+					"enum": ["create", "delete", "update", "fork", "list_branches", "create_branch", "delete_branch", "rename_branch", "protect_branch", "protect_default_branch", "clone_url", "list_forks", "get_settings", "update_merge_settings", "list_topics", "set_topics", "archive", "unarchive", "compare", "create_from_template", "list"],
 					"description": "Repository operation to perform"
 				},
 				"owner": {
@@ -45,6 +50,115 @@ func (g *GitHub) GetRepositoryTool() goai.Tool {
 				"source_branch": {
 					"type": "string",
 					"description": "Source branch for new branch creation"
+				},
+				"new_name": {
+					"type": "string",
+					"description": "New name of the branch for rename_branch"
+				},
+				"required_approving_review_count": {
+					"type": "integer",
+					"description": "Number of approving reviews required by branch protection"
+				},
+				"dismiss_stale_reviews": {
+					"type": "boolean",
+					"description": "Dismiss approving reviews when new commits are pushed"
+				},
+				"required_status_checks": {
+					"type": "array",
+					"items": {"type": "string"},
+					"description": "Status check contexts that must pass before merging. protect_default_branch requires none unless they are listed here"
+				},
+				"allow_force_pushes": {
+					"type": "boolean",
+					"description": "Allow force pushes to the protected branch"
+				},
+				"topics": {
+					"type": "array",
+					"items": {"type": "string"},
+					"description": "Topics that replace all topics of the repository for set_topics: lowercase letters, numbers and hyphens, at most 50 characters each"
+				},
+				"dry_run": {
+					"type": "boolean",
+					"description": "Validate a mutating operation and report what it would change without calling GitHub"
+				},
+				"require_code_owner_reviews": {
+					"type": "boolean",
+					"description": "Require an approving review from a code owner of the changed files"
+				},
+				"enforce_admins": {
+					"type": "boolean",
+					"description": "Apply branch protection to repository administrators too"
+				},
+				"sort": {
+					"type": "string",
+					"description": "Sort order for list operations (for forks: newest, oldest, stargazers, watchers; for list: created, updated, pushed, full_name)"
+				},
+				"type": {
+					"type": "string",
+					"enum": ["all", "public", "private", "forks", "sources"],
+					"description": "Repositories returned by list (default all)"
+				},
+				"per_page": {
+					"type": "integer",
+					"description": "Page size used when paginating list operations (max 100)"
+				},
+				"max_results": {
+					"type": "integer",
+					"description": "Maximum number of results returned by list operations (default 100)"
+				},
+				"allow_merge_commit": {
+					"type": "boolean",
+					"description": "Allow merge commits when merging pull requests"
+				},
+				"allow_squash_merge": {
+					"type": "boolean",
+					"description": "Allow squash merging pull requests"
+				},
+				"allow_rebase_merge": {
+					"type": "boolean",
+					"description": "Allow rebase merging pull requests"
+				},
+				"allow_auto_merge": {
+					"type": "boolean",
+					"description": "Allow auto-merge on pull requests"
+				},
+				"delete_branch_on_merge": {
+					"type": "boolean",
+					"description": "Delete head branches automatically after pull requests are merged"
+				},
+				"squash_merge_commit_title": {
+					"type": "string",
+					"enum": ["PR_TITLE", "COMMIT_OR_PR_TITLE"],
+					"description": "Default title of squash merge commits"
+				},
+				"squash_merge_commit_message": {
+					"type": "string",
+					"enum": ["PR_BODY", "COMMIT_MESSAGES", "BLANK"],
+					"description": "Default message of squash merge commits"
+				},
+				"base": {
+					"type": "string",
+					"description": "Base ref (branch, tag or SHA) for compare"
+				},
+				"head": {
+					"type": "string",
+					"description": "Head ref (branch, tag or SHA) for compare"
+				},
+				"files_only": {
+					"type": "boolean",
+					"description": "Omit the patch of each changed file from compare to keep large comparisons small"
+				},
+				"template_owner": {
+					"type": "string",
+					"description": "Owner of the template repository for create_from_template"
+				},
+				"template_repo": {
+					"type": "string",
+					"description": "Name of the template repository for create_from_template"
+				},
+				"include_all_branches": {
+					"type": "boolean",
+					"description": "Copy all branches of the template for create_from_template, not just the default branch"
 				}
 			},
 			"required": ["operation"]
@@ -53,19 +167,77 @@ func (g *GitHub) GetRepositoryTool() goai.Tool {
 	}
 }
 
+// repositoryOperationInput is the input of the repository tool
+type repositoryOperationInput struct {
+	Operation          string   `json:"operation"`
+	Owner              string   `json:"owner"`
+	Repo               string   `json:"repo"`
+	Description        string   `json:"description"`
+	Private            bool     `json:"private"`
+	Branch             string   `json:"branch"`
+	SourceBranch       string   `json:"source_branch"`
+	NewName            string   `json:"new_name"`
+	Sort               string   `json:"sort"`
+	RepoType           string   `json:"type"`
+	PerPage            int      `json:"per_page"`
+	MaxResults         int      `json:"max_results"`
+	Topics             []string `json:"topics"`
+	DryRun             bool     `json:"dry_run"`
+	Base               string   `json:"base"`
+	Head               string   `json:"head"`
+	FilesOnly          bool     `json:"files_only"`
+	TemplateOwner      string   `json:"template_owner"`
+	TemplateRepo       string   `json:"template_repo"`
+	IncludeAllBranches bool     `json:"include_all_branches"`
+	branchProtectionSettings
+	mergeSettingsUpdate
+}
+
+// repositoryRequiredFields are the fields each repository operation requires
+var repositoryRequiredFields = requiredFields{
+	"create":                 {"repo"},
+	"delete":                 {"owner", "repo"},
+	"update":                 {"owner", "repo"},
+	"fork":                   {"owner", "repo"},
+	"list_branches":          {"owner", "repo"},
+	"create_branch":          {"owner", "repo", "branch", "source_branch"},
+	"delete_branch":          {"owner", "repo", "branch"},
+	"rename_branch":          {"owner", "repo", "branch", "new_name"},
+	"protect_branch":         {"owner", "repo", "branch"},
+	"protect_default_branch": {"owner", "repo"},
+	"clone_url":              {"owner", "repo"},
+	"list_forks":             {"owner", "repo"},
+	"get_settings":           {"owner", "repo"},
+	"update_merge_settings":  {"owner", "repo"},
+	"list_topics":            {"owner", "repo"},
+	"set_topics":             {"owner", "repo"},
+	"archive":                {"owner", "repo"},
+	"unarchive":              {"owner", "repo"},
+	"compare":                {"owner", "repo", "base", "head"},
+	"create_from_template":   {"template_owner", "template_repo", "owner", "repo"},
+	"list":                   {"owner"},
+}
+
+// fieldValues returns the string fields of the input checked by repositoryRequiredFields
+func (input repositoryOperationInput) fieldValues() map[string]string {
+	return map[string]string{
+		"owner":          input.Owner,
+		"repo":           input.Repo,
+		"branch":         input.Branch,
+		"source_branch":  input.SourceBranch,
+		"new_name":       input.NewName,
+		"base":           input.Base,
+		"head":           input.Head,
+		"template_owner": input.TemplateOwner,
+		"template_repo":  input.TemplateRepo,
+	}
+}
+
 func (g *GitHub) handleRepositoryOperation(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
 	ctx, span := goai.StartSpan(ctx, fmt.Sprintf("%s.Handler", params.Name))
 	defer span.End()
 
-	var input struct {
-		Operation    string `json:"operation"`
-		Owner        string `json:"owner"`
-		Repo         string `json:"repo"`
-		Description  string `json:"description"`
-		Private      bool   `json:"private"`
-		Branch       string `json:"branch"`
-		SourceBranch string `json:"source_branch"`
-	}
+	var input repositoryOperationInput
 
 	g.logger.WithFields(map[string]interface{}{
 		"tool":      params.Name,
@@ -76,6 +248,28 @@ func (g *GitHub) handleRepositoryOperation(ctx context.Context, params goai.Call
 		return goai.CallToolResult{}, fmt.Errorf("failed to unmarshal input: %w", err)
 	}
 
+	if err := repositoryRequiredFields.validate(input.Operation, input.fieldValues()); err != nil {
+		return returnErrorOutput(err), nil
+	}
+
+	if (input.DryRun || g.config.DryRun) && mutatingRepositoryOperations[input.Operation] {
+		plan, err := planRepositoryOperation(input)
+		if err != nil {
+			return returnErrorOutput(err), nil
+		}
+		text, err := marshalResult(plan)
+		if err != nil {
+			return returnErrorOutput(err), nil
+		}
+
+		return goai.CallToolResult{
+			Content: []goai.ToolResultContent{{
+				Type: "json",
+				Text: text,
+			}},
+		}, nil
+	}
+
 	var result interface{}
 	var err error
 
@@ -99,29 +293,79 @@ func (g *GitHub) handleRepositoryOperation(ctx context.Context, params goai.Call
 	case "fork":
 		result, _, err = g.client.Repositories.CreateFork(ctx, input.Owner, input.Repo, &github.RepositoryCreateForkOptions{})
 	case "list_branches":
-		result, _, err = g.client.Repositories.ListBranches(ctx, input.Owner, input.Repo, &github.BranchListOptions{})
+		result, err = paginate(listPageSize(input.PerPage), listResultCap(input.MaxResults), func(opts github.ListOptions) ([]*github.Branch, *github.Response, error) {
+			return g.client.Repositories.ListBranches(ctx, input.Owner, input.Repo, &github.BranchListOptions{ListOptions: opts})
+		})
 	case "create_branch":
 		// Get the source branch's SHA
-		ref, _, err := g.client.Git.GetRef(ctx, input.Owner, input.Repo, "refs/heads/"+input.SourceBranch)
+		var ref *github.Reference
+		ref, _, err = g.client.Git.GetRef(ctx, input.Owner, input.Repo, "refs/heads/"+input.SourceBranch)
 		if err != nil {
-			return goai.CallToolResult{}, err
+			break
 		}
-		result, _, _ = g.client.Git.CreateRef(ctx, input.Owner, input.Repo, &github.Reference{
+		result, _, err = g.client.Git.CreateRef(ctx, input.Owner, input.Repo, &github.Reference{
 			Ref: github.String("refs/heads/" + input.Branch),
 			Object: &github.GitObject{
 				SHA: ref.Object.SHA,
 			},
 		})
+	case "delete_branch":
+		result, err = g.deleteBranch(ctx, input.Owner, input.Repo, input.Branch)
+		if isNotFound(err) {
+			return returnErrorOutput(fmt.Errorf("branch %s does not exist in %s/%s", input.Branch, input.Owner, input.Repo)), nil
+		}
+	case "rename_branch":
+		result, err = g.renameBranch(ctx, input.Owner, input.Repo, input.Branch, input.NewName)
+		if isNotFound(err) {
+			return returnErrorOutput(fmt.Errorf("branch %s does not exist in %s/%s", input.Branch, input.Owner, input.Repo)), nil
+		}
 	case "protect_branch":
-		result, _, err = g.client.Repositories.UpdateBranchProtection(ctx, input.Owner, input.Repo, input.Branch,
-			&github.ProtectionRequest{
-				RequiredStatusChecks: &github.RequiredStatusChecks{
-					Strict: true,
-				},
-				RequiredPullRequestReviews: &github.PullRequestReviewsEnforcementRequest{
-					RequiredApprovingReviewCount: 1,
-				},
-			})
+		req := branchProtectionPreset()
+		input.branchProtectionSettings.applyTo(req)
+		result, _, err = g.client.Repositories.UpdateBranchProtection(ctx, input.Owner, input.Repo, input.Branch, req)
+	case "protect_default_branch":
+		result, err = g.protectDefaultBranch(ctx, input.Owner, input.Repo, input.branchProtectionSettings)
+	case "clone_url":
+		result, err = g.resolveCloneURL(ctx, input.Owner, input.Repo)
+	case "list_forks":
+		result, err = g.listForks(ctx, input.Owner, input.Repo, input.Sort, input.PerPage, input.MaxResults)
+	case "get_settings":
+		result, err = g.getRepositorySettings(ctx, input.Owner, input.Repo)
+	case "update_merge_settings":
+		if input.mergeSettingsUpdate.empty() {
+			return returnErrorOutput(fmt.Errorf("update_merge_settings requires at least one merge setting")), nil
+		}
+		result, err = g.updateMergeSettings(ctx, input.Owner, input.Repo, input.mergeSettingsUpdate)
+	case "archive":
+		result, err = g.setArchived(ctx, input.Owner, input.Repo, true)
+	case "unarchive":
+		result, err = g.setArchived(ctx, input.Owner, input.Repo, false)
+	case "list_topics":
+		var topics []string
+		topics, _, err = g.client.Repositories.ListAllTopics(ctx, input.Owner, input.Repo)
+		result = repositoryTopics{Topics: nonNilTopics(topics)}
+	case "set_topics":
+		if input.Topics == nil {
+			return returnErrorOutput(fmt.Errorf("set_topics requires topics; pass an empty list to remove all topics")), nil
+		}
+		if err := validateTopics(input.Topics); err != nil {
+			return returnErrorOutput(err), nil
+		}
+		var topics []string
+		topics, _, err = g.client.Repositories.ReplaceAllTopics(ctx, input.Owner, input.Repo, input.Topics)
+		result = repositoryTopics{Topics: nonNilTopics(topics)}
+	case "create_from_template":
+		result, err = g.createFromTemplate(ctx, input)
+	case "list":
+		if input.RepoType != "" && !repositoryListTypes[input.RepoType] {
+			return returnErrorOutput(fmt.Errorf("unsupported type %q: must be one of all, public, private, forks or sources", input.RepoType)), nil
+		}
+		result, err = g.listRepositories(ctx, input.Owner, input.RepoType, input.Sort, input.PerPage, input.MaxResults)
+	case "compare":
+		result, err = g.compareCommits(ctx, input.Owner, input.Repo, input.Base, input.Head, input.FilesOnly)
+		if isNotFound(err) {
+			return returnErrorOutput(fmt.Errorf("cannot compare %s...%s in %s/%s: a ref does not exist or the refs share no common history", input.Base, input.Head, input.Owner, input.Repo)), nil
+		}
 	default:
 		return returnErrorOutput(fmt.Errorf("unsupported operation: %s", input.Operation)), nil
 	}
@@ -136,7 +380,10 @@ func (g *GitHub) handleRepositoryOperation(ctx context.Context, params goai.Call
 		return returnErrorOutput(fmt.Errorf("github repository %s error: %w", input.Operation, err)), nil
 	}
 
-	m := mustMarshal(result)
+	m, err := marshalResult(result)
+	if err != nil {
+		return returnErrorOutput(err), nil
+	}
 	g.logger.WithFields(map[string]interface{}{
 		"tool":          params.Name,
 		"operation":     input.Operation,
@@ -150,3 +397,810 @@ func (g *GitHub) handleRepositoryOperation(ctx context.Context, params goai.Call
 		}},
 	}, nil
 }
+
+// branchProtectionSettings are the caller-overridable parts of a branch protection request
+type branchProtectionSettings struct {
+	RequiredApprovingReviewCount *int     `json:"required_approving_review_count"`
+	DismissStaleReviews          *bool    `json:"dismiss_stale_reviews"`
+	RequiredStatusChecks         []string `json:"required_status_checks"`
+	AllowForcePushes             *bool    `json:"allow_force_pushes"`
+	RequireCodeOwnerReviews      *bool    `json:"require_code_owner_reviews"`
+	EnforceAdmins                *bool    `json:"enforce_admins"`
+}
+
+// applyTo overrides the fields of req with the settings provided by the caller
+func (s branchProtectionSettings) applyTo(req *github.ProtectionRequest) {
+	if s.RequiredApprovingReviewCount != nil {
+		req.RequiredPullRequestReviews.RequiredApprovingReviewCount = *s.RequiredApprovingReviewCount
+	}
+	if s.DismissStaleReviews != nil {
+		req.RequiredPullRequestReviews.DismissStaleReviews = *s.DismissStaleReviews
+	}
+	if s.RequiredStatusChecks != nil {
+		contexts := s.RequiredStatusChecks
+		if req.RequiredStatusChecks != nil {
+			req.RequiredStatusChecks.Contexts = &contexts
+		} else if len(contexts) > 0 {
+			req.RequiredStatusChecks = &github.RequiredStatusChecks{Strict: true, Contexts: &contexts}
+		}
+	}
+	if s.AllowForcePushes != nil {
+		req.AllowForcePushes = s.AllowForcePushes
+	}
+	if s.RequireCodeOwnerReviews != nil {
+		req.RequiredPullRequestReviews.RequireCodeOwnerReviews = *s.RequireCodeOwnerReviews
+	}
+	if s.EnforceAdmins != nil {
+		req.EnforceAdmins = *s.EnforceAdmins
+	}
+}
+
+// branchProtectionPreset is the policy applied by protect_branch before the caller's
+// settings: up-to-date status checks and pull requests with one approving review.
+func branchProtectionPreset() *github.ProtectionRequest {
+	return &github.ProtectionRequest{
+		RequiredStatusChecks: &github.RequiredStatusChecks{
+			Strict: true,
+		},
+		RequiredPullRequestReviews: &github.PullRequestReviewsEnforcementRequest{
+			RequiredApprovingReviewCount: 1,
+		},
+	}
+}
+
+// defaultBranchProtectionPreset is the opinionated policy applied by protect_default_branch:
+// pull requests with one approving review, stale review dismissal and no force pushes. The
+// preset requires no status checks, since an empty list would protect nothing; they are only
+// required, up to date, when the caller names them in required_status_checks.
+func defaultBranchProtectionPreset() *github.ProtectionRequest {
+	return &github.ProtectionRequest{
+		RequiredPullRequestReviews: &github.PullRequestReviewsEnforcementRequest{
+			DismissStaleReviews:          true,
+			RequiredApprovingReviewCount: 1,
+		},
+		AllowForcePushes: github.Bool(false),
+	}
+}
+
+// protectDefaultBranch resolves the repository's default branch and applies the protection preset to it
+func (g *GitHub) protectDefaultBranch(ctx context.Context, owner, repo string, settings branchProtectionSettings) (interface{}, error) {
+	repository, _, err := g.client.Repositories.Get(ctx, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	branch := repository.GetDefaultBranch()
+	if branch == "" {
+		return nil, fmt.Errorf("repository %s/%s has no default branch", owner, repo)
+	}
+
+	req := defaultBranchProtectionPreset()
+	settings.applyTo(req)
+
+	protection, _, err := g.client.Repositories.UpdateBranchProtection(ctx, owner, repo, branch, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"branch":     branch,
+		"protection": protection,
+	}, nil
+}
+
+// branchChange is the outcome of delete_branch and rename_branch
+type branchChange struct {
+	Ref     string `json:"ref"`
+	Branch  string `json:"branch"`
+	NewName string `json:"new_name,omitempty"`
+	Status  string `json:"status"`
+}
+
+// deleteBranch deletes a branch. The default branch is refused up front with an explanation,
+// since GitHub rejects its deletion with a bare 422.
+func (g *GitHub) deleteBranch(ctx context.Context, owner, repo, branch string) (*branchChange, error) {
+	repository, _, err := g.client.Repositories.Get(ctx, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	if repository.GetDefaultBranch() == branch {
+		return nil, fmt.Errorf("cannot delete %s: it is the default branch of %s/%s; change the default branch first", branch, owner, repo)
+	}
+
+	ref := "refs/heads/" + branch
+	if _, err := g.client.Git.DeleteRef(ctx, owner, repo, ref); err != nil {
+		return nil, err
+	}
+	return &branchChange{Ref: ref, Branch: branch, Status: "deleted"}, nil
+}
+
+// renameBranch renames a branch. GitHub retargets the pull requests and branch protection
+// of the branch, and renaming the default branch requires administrator permissions.
+func (g *GitHub) renameBranch(ctx context.Context, owner, repo, branch, newName string) (*branchChange, error) {
+	renamed, _, err := g.client.Repositories.RenameBranch(ctx, owner, repo, branch, newName)
+	if err != nil {
+		return nil, err
+	}
+	return &branchChange{Ref: "refs/heads/" + renamed.GetName(), Branch: branch, NewName: renamed.GetName(), Status: "renamed"}, nil
+}
+
+// resolveCloneURL returns a clone URL for the repository that can be used directly by git,
+// embedding the configured token for https so private repositories can be cloned
+func (g *GitHub) resolveCloneURL(ctx context.Context, owner, repo string) (interface{}, error) {
+	repository, _, err := g.client.Repositories.Get(ctx, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	if g.config.CloneProtocol == "ssh" {
+		return map[string]string{"protocol": "ssh", "clone_url": repository.GetSSHURL()}, nil
+	}
+
+	cloneURL := repository.GetCloneURL()
+	if g.config.Token != "" {
+		parsed, err := url.Parse(cloneURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid clone URL: %w", err)
+		}
+		parsed.User = url.UserPassword("x-access-token", g.config.Token)
+		cloneURL = parsed.String()
+	}
+
+	g.logger.WithFields(map[string]interface{}{
+		"owner":     owner,
+		"repo":      repo,
+		"clone_url": redactURLCredentials(cloneURL),
+	}).Debug("Resolved clone URL")
+
+	return map[string]string{"protocol": "https", "clone_url": cloneURL}, nil
+}
+
+// redactURLCredentials masks the password component of a URL so it can be logged safely
+func redactURLCredentials(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.User == nil {
+		return rawURL
+	}
+	if _, hasPassword := parsed.User.Password(); hasPassword {
+		parsed.User = url.UserPassword(parsed.User.Username(), "REDACTED")
+	}
+	return parsed.String()
+}
+
+// defaultMaxListResults caps list operations when the caller does not provide max_results
+const defaultMaxListResults = 100
+
+// listPageSize returns a valid GitHub page size for perPage, defaulting to the maximum of 100
+func listPageSize(perPage int) int {
+	if perPage <= 0 || perPage > 100 {
+		return 100
+	}
+	return perPage
+}
+
+// listResultCap returns the number of results a list operation may return
+func listResultCap(maxResults int) int {
+	if maxResults <= 0 {
+		return defaultMaxListResults
+	}
+	return maxResults
+}
+
+// forkSummary is the fork metadata reported by list_forks
+type forkSummary struct {
+	FullName      string            `json:"full_name"`
+	Owner         string            `json:"owner"`
+	Stars         int               `json:"stars"`
+	Forks         int               `json:"forks"`
+	DefaultBranch string            `json:"default_branch"`
+	PushedAt      *github.Timestamp `json:"pushed_at,omitempty"`
+	HTMLURL       string            `json:"html_url"`
+}
+
+// listForks pages through the forks of a repository up to maxResults
+func (g *GitHub) listForks(ctx context.Context, owner, repo, sort string, perPage, maxResults int) ([]forkSummary, error) {
+	forks, err := paginate(listPageSize(perPage), listResultCap(maxResults), func(opts github.ListOptions) ([]*github.Repository, *github.Response, error) {
+		return g.client.Repositories.ListForks(ctx, owner, repo, &github.RepositoryListForksOptions{
+			Sort:        sort,
+			ListOptions: opts,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make([]forkSummary, 0, len(forks))
+	for _, fork := range forks {
+		summaries = append(summaries, forkSummary{
+			FullName:      fork.GetFullName(),
+			Owner:         fork.GetOwner().GetLogin(),
+			Stars:         fork.GetStargazersCount(),
+			Forks:         fork.GetForksCount(),
+			DefaultBranch: fork.GetDefaultBranch(),
+			PushedAt:      fork.PushedAt,
+			HTMLURL:       fork.GetHTMLURL(),
+		})
+	}
+	return summaries, nil
+}
+
+// repositoryListTypes are the type filters accepted by the list operation
+var repositoryListTypes = map[string]bool{
+	"all":     true,
+	"public":  true,
+	"private": true,
+	"forks":   true,
+	"sources": true,
+}
+
+// repositorySummary is the repository information reported by list
+type repositorySummary struct {
+	Name          string `json:"name"`
+	FullName      string `json:"full_name"`
+	Description   string `json:"description,omitempty"`
+	Visibility    string `json:"visibility"`
+	DefaultBranch string `json:"default_branch"`
+	Fork          bool   `json:"fork"`
+	Archived      bool   `json:"archived"`
+}
+
+// listRepositories pages through the repositories of an organization or user up to maxResults.
+// Organizations filter by repoType on GitHub's side; the user endpoint only distinguishes owned
+// and member repositories, so the repositories of users are filtered here instead.
+func (g *GitHub) listRepositories(ctx context.Context, owner, repoType, sort string, perPage, maxResults int) ([]repositorySummary, error) {
+	account, _, err := g.client.Users.Get(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+	isOrg := account.GetType() == "Organization"
+	// The repositories of a user listed through /users/{user}/repos are only the public ones,
+	// so the authenticated user's own are listed through /user/repos
+	authenticated := !isOrg && g.isAuthenticatedUser(ctx, account.GetLogin())
+
+	repositories, err := paginate(listPageSize(perPage), listResultCap(maxResults), func(opts github.ListOptions) ([]*github.Repository, *github.Response, error) {
+		if isOrg {
+			return g.client.Repositories.ListByOrg(ctx, owner, &github.RepositoryListByOrgOptions{Type: repoType, Sort: sort, ListOptions: opts})
+		}
+
+		var page []*github.Repository
+		var resp *github.Response
+		var err error
+		if authenticated {
+			page, resp, err = g.client.Repositories.ListByAuthenticatedUser(ctx, &github.RepositoryListByAuthenticatedUserOptions{
+				Visibility:  repositoryListVisibility(repoType),
+				Affiliation: "owner",
+				Sort:        sort,
+				ListOptions: opts,
+			})
+		} else {
+			page, resp, err = g.client.Repositories.ListByUser(ctx, owner, &github.RepositoryListByUserOptions{Type: "owner", Sort: sort, ListOptions: opts})
+		}
+		if err != nil {
+			return nil, resp, err
+		}
+		filtered := page[:0]
+		for _, repository := range page {
+			if matchesRepositoryType(repository, repoType) {
+				filtered = append(filtered, repository)
+			}
+		}
+		return filtered, resp, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make([]repositorySummary, 0, len(repositories))
+	for _, repository := range repositories {
+		summaries = append(summaries, repositorySummary{
+			Name:          repository.GetName(),
+			FullName:      repository.GetFullName(),
+			Description:   repository.GetDescription(),
+			Visibility:    repositoryVisibility(repository),
+			DefaultBranch: repository.GetDefaultBranch(),
+			Fork:          repository.GetFork(),
+			Archived:      repository.GetArchived(),
+		})
+	}
+	return summaries, nil
+}
+
+// isAuthenticatedUser reports whether login is the user the configured token authenticates as
+func (g *GitHub) isAuthenticatedUser(ctx context.Context, login string) bool {
+	user, _, err := g.client.Users.Get(ctx, "")
+	return err == nil && strings.EqualFold(user.GetLogin(), login)
+}
+
+// repositoryListVisibility returns the visibility filter of /user/repos for the repoType of list
+func repositoryListVisibility(repoType string) string {
+	if repoType == "public" || repoType == "private" {
+		return repoType
+	}
+	return "all"
+}
+
+// matchesRepositoryType reports whether repository passes the repoType filter of list
+func matchesRepositoryType(repository *github.Repository, repoType string) bool {
+	switch repoType {
+	case "public":
+		return !repository.GetPrivate()
+	case "private":
+		return repository.GetPrivate()
+	case "forks":
+		return repository.GetFork()
+	case "sources":
+		return !repository.GetFork()
+	}
+	return true
+}
+
+// repositoryVisibility returns the visibility of repository, derived from its private flag
+// when GitHub does not report one
+func repositoryVisibility(repository *github.Repository) string {
+	if visibility := repository.GetVisibility(); visibility != "" {
+		return visibility
+	}
+	if repository.GetPrivate() {
+		return "private"
+	}
+	return "public"
+}
+
+// repositorySettings is a normalized view of a repository's configuration. Every field is
+// always present and lists are sorted, so the settings of two repositories can be diffed directly.
+type repositorySettings struct {
+	FullName                string                    `json:"full_name"`
+	DefaultBranch           string                    `json:"default_branch"`
+	Visibility              string                    `json:"visibility"`
+	Archived                bool                      `json:"archived"`
+	Features                repositoryFeatures        `json:"features"`
+	Merge                   repositoryMergeSettings   `json:"merge"`
+	DefaultBranchProtection *normalizedProtectionRule `json:"default_branch_protection"`
+}
+
+// repositoryFeatures are the optional features enabled on a repository
+type repositoryFeatures struct {
+	Issues      bool `json:"issues"`
+	Projects    bool `json:"projects"`
+	Wiki        bool `json:"wiki"`
+	Discussions bool `json:"discussions"`
+	Pages       bool `json:"pages"`
+}
+
+// repositoryMergeSettings are the pull request merge options of a repository
+type repositoryMergeSettings struct {
+	AllowMergeCommit    bool `json:"allow_merge_commit"`
+	AllowSquashMerge    bool `json:"allow_squash_merge"`
+	AllowRebaseMerge    bool `json:"allow_rebase_merge"`
+	AllowAutoMerge      bool `json:"allow_auto_merge"`
+	AllowUpdateBranch   bool `json:"allow_update_branch"`
+	DeleteBranchOnMerge bool `json:"delete_branch_on_merge"`
+}
+
+// normalizedProtectionRule is the branch protection of the default branch
+type normalizedProtectionRule struct {
+	RequiredApprovingReviewCount int      `json:"required_approving_review_count"`
+	DismissStaleReviews          bool     `json:"dismiss_stale_reviews"`
+	RequireCodeOwnerReviews      bool     `json:"require_code_owner_reviews"`
+	RequiredStatusChecks         []string `json:"required_status_checks"`
+	StrictStatusChecks           bool     `json:"strict_status_checks"`
+	EnforceAdmins                bool     `json:"enforce_admins"`
+	RequiredLinearHistory        bool     `json:"required_linear_history"`
+	AllowForcePushes             bool     `json:"allow_force_pushes"`
+	AllowDeletions               bool     `json:"allow_deletions"`
+}
+
+// getRepositorySettings fetches the repository and the protection of its default branch and
+// normalizes them. An unprotected default branch is reported as a null protection.
+func (g *GitHub) getRepositorySettings(ctx context.Context, owner, repo string) (*repositorySettings, error) {
+	repository, _, err := g.client.Repositories.Get(ctx, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := &repositorySettings{
+		FullName:      repository.GetFullName(),
+		DefaultBranch: repository.GetDefaultBranch(),
+		Visibility:    repositoryVisibility(repository),
+		Archived:      repository.GetArchived(),
+		Features: repositoryFeatures{
+			Issues:      repository.GetHasIssues(),
+			Projects:    repository.GetHasProjects(),
+			Wiki:        repository.GetHasWiki(),
+			Discussions: repository.GetHasDiscussions(),
+			Pages:       repository.GetHasPages(),
+		},
+		Merge: repositoryMergeSettings{
+			AllowMergeCommit:    repository.GetAllowMergeCommit(),
+			AllowSquashMerge:    repository.GetAllowSquashMerge(),
+			AllowRebaseMerge:    repository.GetAllowRebaseMerge(),
+			AllowAutoMerge:      repository.GetAllowAutoMerge(),
+			AllowUpdateBranch:   repository.GetAllowUpdateBranch(),
+			DeleteBranchOnMerge: repository.GetDeleteBranchOnMerge(),
+		},
+	}
+	if settings.DefaultBranch == "" {
+		return settings, nil
+	}
+
+	protection, _, err := g.client.Repositories.GetBranchProtection(ctx, owner, repo, settings.DefaultBranch)
+	if err != nil {
+		if isNotFound(err) || errors.Is(err, github.ErrBranchNotProtected) {
+			return settings, nil
+		}
+		return nil, err
+	}
+	settings.DefaultBranchProtection = normalizeProtection(protection)
+
+	return settings, nil
+}
+
+// normalizeProtection flattens a branch protection into comparable values
+func normalizeProtection(protection *github.Protection) *normalizedProtectionRule {
+	rule := &normalizedProtectionRule{RequiredStatusChecks: []string{}}
+
+	if reviews := protection.GetRequiredPullRequestReviews(); reviews != nil {
+		rule.RequiredApprovingReviewCount = reviews.RequiredApprovingReviewCount
+		rule.DismissStaleReviews = reviews.DismissStaleReviews
+		rule.RequireCodeOwnerReviews = reviews.RequireCodeOwnerReviews
+	}
+	if checks := protection.GetRequiredStatusChecks(); checks != nil {
+		rule.StrictStatusChecks = checks.Strict
+		if checks.Contexts != nil {
+			rule.RequiredStatusChecks = append(rule.RequiredStatusChecks, *checks.Contexts...)
+		}
+		for _, check := range checks.GetChecks() {
+			rule.RequiredStatusChecks = append(rule.RequiredStatusChecks, check.Context)
+		}
+		sort.Strings(rule.RequiredStatusChecks)
+		rule.RequiredStatusChecks = dedupeSorted(rule.RequiredStatusChecks)
+	}
+	if enforceAdmins := protection.GetEnforceAdmins(); enforceAdmins != nil {
+		rule.EnforceAdmins = enforceAdmins.Enabled
+	}
+	if linearHistory := protection.GetRequireLinearHistory(); linearHistory != nil {
+		rule.RequiredLinearHistory = linearHistory.Enabled
+	}
+	if forcePushes := protection.GetAllowForcePushes(); forcePushes != nil {
+		rule.AllowForcePushes = forcePushes.Enabled
+	}
+	if deletions := protection.GetAllowDeletions(); deletions != nil {
+		rule.AllowDeletions = deletions.Enabled
+	}
+
+	return rule
+}
+
+// dedupeSorted removes adjacent duplicates from a sorted slice
+func dedupeSorted(values []string) []string {
+	deduped := values[:0]
+	for i, v := range values {
+		if i == 0 || v != values[i-1] {
+			deduped = append(deduped, v)
+		}
+	}
+	return deduped
+}
+
+// mergeSettingsUpdate holds the merge options to change; nil fields are left untouched
+type mergeSettingsUpdate struct {
+	AllowMergeCommit         *bool   `json:"allow_merge_commit"`
+	AllowSquashMerge         *bool   `json:"allow_squash_merge"`
+	AllowRebaseMerge         *bool   `json:"allow_rebase_merge"`
+	AllowAutoMerge           *bool   `json:"allow_auto_merge"`
+	DeleteBranchOnMerge      *bool   `json:"delete_branch_on_merge"`
+	SquashMergeCommitTitle   *string `json:"squash_merge_commit_title"`
+	SquashMergeCommitMessage *string `json:"squash_merge_commit_message"`
+}
+
+// empty reports whether no merge setting was provided
+func (u mergeSettingsUpdate) empty() bool {
+	return u == mergeSettingsUpdate{}
+}
+
+// mergeSettingsResult are the merge settings of a repository after an update
+type mergeSettingsResult struct {
+	repositoryMergeSettings
+	SquashMergeCommitTitle   string `json:"squash_merge_commit_title"`
+	SquashMergeCommitMessage string `json:"squash_merge_commit_message"`
+}
+
+// updateMergeSettings edits only the provided merge options of a repository and returns the
+// resulting merge settings
+func (g *GitHub) updateMergeSettings(ctx context.Context, owner, repo string, update mergeSettingsUpdate) (*mergeSettingsResult, error) {
+	repository, _, err := g.client.Repositories.Edit(ctx, owner, repo, &github.Repository{
+		AllowMergeCommit:         update.AllowMergeCommit,
+		AllowSquashMerge:         update.AllowSquashMerge,
+		AllowRebaseMerge:         update.AllowRebaseMerge,
+		AllowAutoMerge:           update.AllowAutoMerge,
+		DeleteBranchOnMerge:      update.DeleteBranchOnMerge,
+		SquashMergeCommitTitle:   update.SquashMergeCommitTitle,
+		SquashMergeCommitMessage: update.SquashMergeCommitMessage,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &mergeSettingsResult{
+		repositoryMergeSettings: repositoryMergeSettings{
+			AllowMergeCommit:    repository.GetAllowMergeCommit(),
+			AllowSquashMerge:    repository.GetAllowSquashMerge(),
+			AllowRebaseMerge:    repository.GetAllowRebaseMerge(),
+			AllowAutoMerge:      repository.GetAllowAutoMerge(),
+			AllowUpdateBranch:   repository.GetAllowUpdateBranch(),
+			DeleteBranchOnMerge: repository.GetDeleteBranchOnMerge(),
+		},
+		SquashMergeCommitTitle:   repository.GetSquashMergeCommitTitle(),
+		SquashMergeCommitMessage: repository.GetSquashMergeCommitMessage(),
+	}, nil
+}
+
+// maxTopics and maxTopicLength are GitHub's limits on repository topics
+const (
+	maxTopics      = 20
+	maxTopicLength = 50
+)
+
+// topicPattern matches a valid topic: lowercase letters, numbers and hyphens, not starting
+// with a hyphen
+var topicPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// repositoryTopics are the topics of a repository reported by list_topics and set_topics
+type repositoryTopics struct {
+	Topics []string `json:"topics"`
+}
+
+// validateTopics checks topics against GitHub's rules before they replace a repository's topics
+func validateTopics(topics []string) error {
+	if len(topics) > maxTopics {
+		return fmt.Errorf("a repository can have at most %d topics, got %d", maxTopics, len(topics))
+	}
+	for _, topic := range topics {
+		if len(topic) > maxTopicLength {
+			return fmt.Errorf("invalid topic %q: must be at most %d characters", topic, maxTopicLength)
+		}
+		if !topicPattern.MatchString(topic) {
+			return fmt.Errorf("invalid topic %q: must contain only lowercase letters, numbers and hyphens, and start with a letter or number", topic)
+		}
+	}
+	return nil
+}
+
+// nonNilTopics returns topics, or an empty list when the repository has none
+func nonNilTopics(topics []string) []string {
+	if topics == nil {
+		return []string{}
+	}
+	return topics
+}
+
+// archiveState is the archived state of a repository reported by archive and unarchive
+type archiveState struct {
+	Repository string `json:"repository"`
+	Archived   bool   `json:"archived"`
+	Message    string `json:"message,omitempty"`
+}
+
+// setArchived archives or unarchives a repository. Archived is the only field sent, since
+// GitHub rejects any other edit of an archived repository. Archiving an already archived
+// repository, which GitHub rejects with a 403, is reported as a no-op.
+func (g *GitHub) setArchived(ctx context.Context, owner, repo string, archived bool) (*archiveState, error) {
+	repository, _, err := g.client.Repositories.Edit(ctx, owner, repo, &github.Repository{
+		Archived: github.Bool(archived),
+	})
+	if isForbidden(err) {
+		current, _, getErr := g.client.Repositories.Get(ctx, owner, repo)
+		if getErr == nil && current.GetArchived() == archived {
+			message := "repository is already archived"
+			if !archived {
+				message = "repository is not archived"
+			}
+			return &archiveState{
+				Repository: current.GetFullName(),
+				Archived:   archived,
+				Message:    message,
+			}, nil
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &archiveState{
+		Repository: repository.GetFullName(),
+		Archived:   repository.GetArchived(),
+	}, nil
+}
+
+// templateRepository is the repository created by create_from_template
+type templateRepository struct {
+	FullName string `json:"full_name"`
+	HTMLURL  string `json:"html_url"`
+	CloneURL string `json:"clone_url"`
+	SSHURL   string `json:"ssh_url"`
+}
+
+// templateRepoRequest builds the create_from_template request for input
+func templateRepoRequest(input repositoryOperationInput) *github.TemplateRepoRequest {
+	return &github.TemplateRepoRequest{
+		Name:               github.String(input.Repo),
+		Owner:              github.String(input.Owner),
+		Description:        github.String(input.Description),
+		Private:            github.Bool(input.Private),
+		IncludeAllBranches: github.Bool(input.IncludeAllBranches),
+	}
+}
+
+// createFromTemplate generates a repository from a template repository. GitHub answers 422
+// when the source is not marked as a template, which is reported specifically since it is
+// fixed in the settings of the template rather than in the request.
+func (g *GitHub) createFromTemplate(ctx context.Context, input repositoryOperationInput) (*templateRepository, error) {
+	repository, _, err := g.client.Repositories.CreateFromTemplate(ctx, input.TemplateOwner, input.TemplateRepo, templateRepoRequest(input))
+	if isUnprocessable(err) {
+		template, _, getErr := g.client.Repositories.Get(ctx, input.TemplateOwner, input.TemplateRepo)
+		if getErr == nil && !template.GetIsTemplate() {
+			return nil, fmt.Errorf("%s/%s is not a template repository: enable \"Template repository\" in its settings", input.TemplateOwner, input.TemplateRepo)
+		}
+		return nil, errors.New(describeGitHubError(err))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &templateRepository{
+		FullName: repository.GetFullName(),
+		HTMLURL:  repository.GetHTMLURL(),
+		CloneURL: repository.GetCloneURL(),
+		SSHURL:   repository.GetSSHURL(),
+	}, nil
+}
+
+// mutatingRepositoryOperations are the repository operations that change a repository and
+// are only simulated in dry run mode
+var mutatingRepositoryOperations = map[string]bool{
+	"create":                 true,
+	"delete":                 true,
+	"update":                 true,
+	"fork":                   true,
+	"create_branch":          true,
+	"delete_branch":          true,
+	"rename_branch":          true,
+	"protect_branch":         true,
+	"protect_default_branch": true,
+	"update_merge_settings":  true,
+	"set_topics":             true,
+	"archive":                true,
+	"unarchive":              true,
+	"create_from_template":   true,
+}
+
+// repositoryDryRun describes the change a mutating repository operation would have made
+type repositoryDryRun struct {
+	DryRun     bool        `json:"dry_run"`
+	Operation  string      `json:"operation"`
+	Repository string      `json:"repository"`
+	Message    string      `json:"message"`
+	Request    interface{} `json:"request,omitempty"`
+}
+
+// planRepositoryOperation validates the input of a mutating operation and returns the request
+// it would send to GitHub, without calling the API. Required fields are checked beforehand
+// against repositoryRequiredFields.
+func planRepositoryOperation(input repositoryOperationInput) (*repositoryDryRun, error) {
+	var request interface{}
+	switch input.Operation {
+	case "create":
+		request = &github.Repository{Name: &input.Repo, Description: &input.Description, Private: &input.Private}
+	case "update":
+		request = &github.Repository{Description: &input.Description, Private: &input.Private}
+	case "create_branch":
+		request = map[string]string{"ref": "refs/heads/" + input.Branch, "source_branch": input.SourceBranch}
+	case "delete_branch":
+		request = map[string]string{"ref": "refs/heads/" + input.Branch}
+	case "rename_branch":
+		request = map[string]string{"branch": input.Branch, "new_name": input.NewName}
+	case "protect_branch":
+		req := branchProtectionPreset()
+		input.branchProtectionSettings.applyTo(req)
+		request = req
+	case "protect_default_branch":
+		req := defaultBranchProtectionPreset()
+		input.branchProtectionSettings.applyTo(req)
+		request = req
+	case "update_merge_settings":
+		if input.mergeSettingsUpdate.empty() {
+			return nil, fmt.Errorf("update_merge_settings requires at least one merge setting")
+		}
+		request = input.mergeSettingsUpdate
+	case "set_topics":
+		if input.Topics == nil {
+			return nil, fmt.Errorf("set_topics requires topics; pass an empty list to remove all topics")
+		}
+		if err := validateTopics(input.Topics); err != nil {
+			return nil, err
+		}
+		request = repositoryTopics{Topics: input.Topics}
+	case "archive", "unarchive":
+		request = map[string]bool{"archived": input.Operation == "archive"}
+	case "create_from_template":
+		request = templateRepoRequest(input)
+	}
+
+	repository := input.Repo
+	if input.Owner != "" {
+		repository = input.Owner + "/" + input.Repo
+	}
+	return &repositoryDryRun{
+		DryRun:     true,
+		Operation:  input.Operation,
+		Repository: repository,
+		Message:    fmt.Sprintf("dry run: %s of %s was simulated and no changes were made", input.Operation, repository),
+		Request:    request,
+	}, nil
+}
+
+// comparisonCommit is a commit listed by compare
+type comparisonCommit struct {
+	SHA     string `json:"sha"`
+	Author  string `json:"author"`
+	Message string `json:"message"`
+}
+
+// comparisonFile is a file changed between the refs of compare
+type comparisonFile struct {
+	Filename         string `json:"filename"`
+	Status           string `json:"status"`
+	PreviousFilename string `json:"previous_filename,omitempty"`
+	Additions        int    `json:"additions"`
+	Deletions        int    `json:"deletions"`
+	Patch            string `json:"patch,omitempty"`
+}
+
+// commitComparison is the result of compare
+type commitComparison struct {
+	Status       string             `json:"status"`
+	AheadBy      int                `json:"ahead_by"`
+	BehindBy     int                `json:"behind_by"`
+	TotalCommits int                `json:"total_commits"`
+	Commits      []comparisonCommit `json:"commits"`
+	Files        []comparisonFile   `json:"files"`
+}
+
+// compareCommits compares base with head, reporting how far head is ahead of and behind base,
+// its commits and the changed files. filesOnly omits the patches of the files.
+func (g *GitHub) compareCommits(ctx context.Context, owner, repo, base, head string, filesOnly bool) (*commitComparison, error) {
+	comparison, _, err := g.client.Repositories.CompareCommits(ctx, owner, repo, base, head, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &commitComparison{
+		Status:       comparison.GetStatus(),
+		AheadBy:      comparison.GetAheadBy(),
+		BehindBy:     comparison.GetBehindBy(),
+		TotalCommits: comparison.GetTotalCommits(),
+		Commits:      make([]comparisonCommit, 0, len(comparison.Commits)),
+		Files:        make([]comparisonFile, 0, len(comparison.Files)),
+	}
+	for _, commit := range comparison.Commits {
+		result.Commits = append(result.Commits, comparisonCommit{
+			SHA:     commit.GetSHA(),
+			Author:  commit.GetCommit().GetAuthor().GetName(),
+			Message: commit.GetCommit().GetMessage(),
+		})
+	}
+	for _, file := range comparison.Files {
+		f := comparisonFile{
+			Filename:         file.GetFilename(),
+			Status:           file.GetStatus(),
+			PreviousFilename: file.GetPreviousFilename(),
+			Additions:        file.GetAdditions(),
+			Deletions:        file.GetDeletions(),
+		}
+		if !filesOnly {
+			f.Patch = file.GetPatch()
+		}
+		result.Files = append(result.Files, f)
+	}
+	return result, nil
+}
@@ -0,0 +1,114 @@
+package mcptools
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/shaharia-lab/goai"
+)
+
+// DefaultGitHubMaxAttempts is how many times a GitHub API request rejected by a rate limit is
+// attempted in total when GitHubConfig.MaxAttempts is not set
+const DefaultGitHubMaxAttempts = 3
+
+// defaultRetryAfter is the wait before retrying a request rejected by the secondary rate
+// limit when GitHub does not send a Retry-After header
+const defaultRetryAfter = time.Minute
+
+// rateLimitTransport is an http.RoundTripper that retries GitHub API requests rejected by the
+// primary or secondary rate limit. It waits until the rate limit resets, or for the Retry-After
+// delay, before each retry and gives up when the request context is done.
+type rateLimitTransport struct {
+	base        http.RoundTripper
+	maxAttempts int
+	logger      goai.Logger
+}
+
+// newRateLimitTransport wraps base, or http.DefaultTransport when nil, so that requests are
+// attempted up to maxAttempts times, or DefaultGitHubMaxAttempts when maxAttempts is not positive
+func newRateLimitTransport(base http.RoundTripper, maxAttempts int, logger goai.Logger) *rateLimitTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultGitHubMaxAttempts
+	}
+	return &rateLimitTransport{base: base, maxAttempts: maxAttempts, logger: logger}
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	for attempt := 1; ; attempt++ {
+		resp, err := t.base.RoundTrip(req)
+		if err != nil {
+			return resp, err
+		}
+
+		wait, limited := rateLimitWait(resp)
+		// Requests whose body cannot be replayed, e.g. uploads, are not retried
+		canReplay := req.Body == nil || req.GetBody != nil
+		if !limited || attempt >= t.maxAttempts || !canReplay {
+			return resp, nil
+		}
+
+		t.logger.WithFields(map[string]interface{}{
+			"method":      req.Method,
+			"path":        req.URL.Path,
+			"attempt":     attempt,
+			"retry_after": wait.String(),
+		}).Warn("GitHub API request hit the rate limit, retrying")
+
+		_ = resp.Body.Close()
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		req = req.Clone(ctx)
+		if req.GetBody != nil {
+			if req.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// rateLimitWait reports whether resp is a rejection by GitHub's primary or secondary rate
+// limit and how long to wait before retrying the request. Secondary rate limits come back as
+// 403 or as 429 Too Many Requests.
+func rateLimitWait(resp *http.Response) (time.Duration, bool) {
+	if resp.StatusCode == http.StatusTooManyRequests {
+		// CheckResponse only reports rate limit errors for 403, so the headers are read directly
+		if seconds, err := strconv.ParseInt(resp.Header.Get("Retry-After"), 10, 64); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second, true
+		}
+		return defaultRetryAfter, true
+	}
+	if resp.StatusCode != http.StatusForbidden {
+		return 0, false
+	}
+
+	// CheckResponse restores the body it reads, so the response can still be returned
+	err := github.CheckResponse(resp)
+
+	var rateErr *github.RateLimitError
+	if errors.As(err, &rateErr) {
+		return max(time.Until(rateErr.Rate.Reset.Time), 0), true
+	}
+
+	var abuseErr *github.AbuseRateLimitError
+	if errors.As(err, &abuseErr) {
+		if abuseErr.RetryAfter != nil {
+			return *abuseErr.RetryAfter, true
+		}
+		return defaultRetryAfter, true
+	}
+
+	return 0, false
+}
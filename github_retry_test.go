@@ -0,0 +1,130 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withRateLimitRetry replaces the client of gh with one retrying rate limited requests
+func withRateLimitRetry(gh *GitHub, maxAttempts int) {
+	baseURL := gh.client.BaseURL
+	gh.client = github.NewClient(&http.Client{Transport: newRateLimitTransport(nil, maxAttempts, gh.logger)})
+	gh.client.BaseURL = baseURL
+}
+
+// primaryRateLimited writes GitHub's primary rate limit rejection, resetting at reset
+func primaryRateLimited(w http.ResponseWriter, reset time.Time) {
+	w.Header().Set("X-RateLimit-Limit", "5000")
+	w.Header().Set("X-RateLimit-Remaining", "0")
+	w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+	w.WriteHeader(http.StatusForbidden)
+	_, _ = w.Write([]byte(`{"message": "API rate limit exceeded"}`))
+}
+
+func TestRateLimitTransport_RetriesPrimaryRateLimit(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	withRateLimitRetry(gh, 0)
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	var bodies []string
+	mux.HandleFunc("/repos/o/r/issues", func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		bodies = append(bodies, string(body))
+
+		if len(bodies) == 1 {
+			primaryRateLimited(w, time.Now().Add(-time.Second))
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		assert.NoError(t, json.NewEncoder(w).Encode(&github.Issue{Number: github.Int(7)}))
+	})
+
+	issue, _, err := gh.client.Issues.Create(context.Background(), "o", "r", &github.IssueRequest{Title: github.String("flaky")})
+	require.NoError(t, err)
+	assert.Equal(t, 7, issue.GetNumber())
+	require.Len(t, bodies, 2)
+	assert.Equal(t, bodies[0], bodies[1], "the request body is sent again on retry")
+}
+
+func TestRateLimitTransport_MaxAttempts(t *testing.T) {
+	tests := []struct {
+		name          string
+		maxAttempts   int
+		status        int
+		expectedCalls int
+	}{
+		{name: "default attempts", maxAttempts: 0, status: http.StatusForbidden, expectedCalls: DefaultGitHubMaxAttempts},
+		{name: "retries disabled", maxAttempts: 1, status: http.StatusForbidden, expectedCalls: 1},
+		{name: "too many requests", maxAttempts: 0, status: http.StatusTooManyRequests, expectedCalls: DefaultGitHubMaxAttempts},
+		{name: "other errors are not retried", maxAttempts: 5, status: http.StatusNotFound, expectedCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gh, server, cleanup := setupGitHubTest(t)
+			gh.logger = newPermissiveLogger()
+			withRateLimitRetry(gh, tt.maxAttempts)
+			defer cleanup()
+
+			mux := http.NewServeMux()
+			server.Config.Handler = mux
+
+			calls := 0
+			mux.HandleFunc("/repos/o/r", func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				switch tt.status {
+				case http.StatusForbidden:
+					secondaryRateLimited(w)
+					return
+				case http.StatusTooManyRequests:
+					w.Header().Set("Retry-After", "0")
+				}
+				w.WriteHeader(tt.status)
+			})
+
+			_, _, err := gh.client.Repositories.Get(context.Background(), "o", "r")
+			assert.Error(t, err)
+			assert.Equal(t, tt.expectedCalls, calls)
+		})
+	}
+}
+
+func TestRateLimitTransport_ContextCancelledDuringWait(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	withRateLimitRetry(gh, 0)
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	calls := 0
+	mux.HandleFunc("/repos/o/r", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		primaryRateLimited(w, time.Now().Add(time.Hour))
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, _, err := gh.client.Repositories.Get(ctx, "o", "r")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 5*time.Second)
+	assert.Equal(t, 1, calls)
+}
@@ -13,13 +13,13 @@ import (
 func (g *GitHub) GetSearchTool() goai.Tool {
 	return goai.Tool{
 		Name:        GitHubSearchToolName,
-		Description: "Performs GitHub search operations across repositories, code, issues, and users",
+		Description: "Performs GitHub search operations across repositories, code, issues, commits, and users",
 		InputSchema: json.RawMessage(`{
 			"type": "object",
 			"properties": {
 				"operation": {
 					"type": "string",
-					"enum": ["repositories", "code", "issues", "users"],
+					"enum": ["repositories", "code", "issues", "commits", "users"],
 					"description": "Search type to perform"
 				},
 				"query": {
@@ -32,13 +32,17 @@ func (g *GitHub) GetSearchTool() goai.Tool {
 				},
 				"sort": {
 					"type": "string",
-					"enum": ["stars", "forks", "updated", "best-match"],
+					"enum": ["stars", "forks", "updated", "best-match", "author-date", "committer-date"],
 					"description": "Sort order for results"
 				},
 				"order": {
 					"type": "string",
 					"enum": ["asc", "desc"],
 					"description": "Sort direction"
+				},
+				"per_page": {
+					"type": "integer",
+					"description": "Number of results to return (default 30, max 100)"
 				}
 			},
 			"required": ["operation", "query"]
@@ -57,6 +61,7 @@ func (g *GitHub) handleSearchOperation(ctx context.Context, params goai.CallTool
 		Language  string `json:"language"`
 		Sort      string `json:"sort"`
 		Order     string `json:"order"`
+		PerPage   int    `json:"per_page"`
 	}
 
 	g.logger.WithFields(map[string]interface{}{
@@ -71,9 +76,14 @@ func (g *GitHub) handleSearchOperation(ctx context.Context, params goai.CallTool
 	var result interface{}
 	var err error
 
+	if input.PerPage > maxSearchPerPage {
+		input.PerPage = maxSearchPerPage
+	}
+
 	searchOpts := &github.SearchOptions{
-		Sort:  input.Sort,
-		Order: input.Order,
+		Sort:        input.Sort,
+		Order:       input.Order,
+		ListOptions: github.ListOptions{PerPage: input.PerPage},
 	}
 
 	g.logger.WithFields(map[string]interface{}{
@@ -97,6 +107,8 @@ func (g *GitHub) handleSearchOperation(ctx context.Context, params goai.CallTool
 		result, _, err = g.client.Search.Code(ctx, input.Query, searchOpts)
 	case "issues":
 		result, _, err = g.client.Search.Issues(ctx, input.Query, searchOpts)
+	case "commits":
+		result, _, err = g.client.Search.Commits(ctx, input.Query, searchOpts)
 	case "users":
 		result, _, err = g.client.Search.Users(ctx, input.Query, searchOpts)
 	default:
@@ -112,7 +124,10 @@ func (g *GitHub) handleSearchOperation(ctx context.Context, params goai.CallTool
 		return returnErrorOutput(err), nil
 	}
 
-	m := mustMarshal(result)
+	m, err := marshalResult(result)
+	if err != nil {
+		return returnErrorOutput(err), nil
+	}
 	g.logger.WithFields(map[string]interface{}{
 		"tool":          GitHubSearchToolName,
 		"operation":     input.Operation,
@@ -126,3 +141,6 @@ func (g *GitHub) handleSearchOperation(ctx context.Context, params goai.CallTool
 		}},
 	}, nil
 }
+
+// maxSearchPerPage is the largest page size accepted by the GitHub search API
+const maxSearchPerPage = 100
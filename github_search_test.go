@@ -212,3 +212,83 @@ func TestHandleSearchOperation(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleSearchOperation_CommitsPerPageCap(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/search/commits", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "fix repo:test-owner/test-repo", r.URL.Query().Get("q"))
+		assert.Equal(t, "100", r.URL.Query().Get("per_page"))
+
+		err := json.NewEncoder(w).Encode(&github.CommitsSearchResult{
+			Total:   github.Int(250),
+			Commits: []*github.CommitResult{{SHA: github.String("abc123")}},
+		})
+		assert.NoError(t, err)
+	})
+
+	result := callGitHubHandler(t, gh.handleSearchOperation, GitHubSearchToolName, map[string]interface{}{
+		"operation": "commits",
+		"query":     "fix repo:test-owner/test-repo",
+		"per_page":  500,
+	})
+	assert.False(t, result.IsError, result.Content)
+
+	var found github.CommitsSearchResult
+	assert.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &found))
+	assert.Equal(t, 250, found.GetTotal())
+	assert.Equal(t, "abc123", found.Commits[0].GetSHA())
+}
+
+// secondaryRateLimited writes GitHub's secondary rate limit rejection
+func secondaryRateLimited(w http.ResponseWriter) {
+	w.Header().Set("Retry-After", "0")
+	w.WriteHeader(http.StatusForbidden)
+	_, _ = w.Write([]byte(`{"message": "You have exceeded a secondary rate limit.", "documentation_url": "https://docs.github.com/rest/overview/rate-limits-for-the-rest-api#about-secondary-rate-limits"}`))
+}
+
+func TestHandleSearchOperation_SecondaryRateLimitRetry(t *testing.T) {
+	tests := []struct {
+		name          string
+		failures      int
+		expectedCalls int
+		expectedError bool
+	}{
+		{name: "retried after Retry-After", failures: 2, expectedCalls: 3},
+		{name: "gives up after the last attempt", failures: 3, expectedCalls: 3, expectedError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gh, server, cleanup := setupGitHubTest(t)
+			gh.logger = newPermissiveLogger()
+			withRateLimitRetry(gh, DefaultGitHubMaxAttempts)
+			defer cleanup()
+
+			mux := http.NewServeMux()
+			server.Config.Handler = mux
+
+			calls := 0
+			mux.HandleFunc("/search/code", func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				if calls <= tt.failures {
+					secondaryRateLimited(w)
+					return
+				}
+				assert.NoError(t, json.NewEncoder(w).Encode(&github.CodeSearchResult{Total: github.Int(1)}))
+			})
+
+			result := callGitHubHandler(t, gh.handleSearchOperation, GitHubSearchToolName, map[string]interface{}{
+				"operation": "code",
+				"query":     "goai.Tool",
+			})
+			assert.Equal(t, tt.expectedCalls, calls)
+			assert.Equal(t, tt.expectedError, result.IsError)
+		})
+	}
+}
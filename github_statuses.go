@@ -0,0 +1,249 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/shaharia-lab/goai"
+)
+
+// commitStatusStates are the states a commit status can be set to
+var commitStatusStates = map[string]bool{
+	"pending": true,
+	"success": true,
+	"failure": true,
+	"error":   true,
+}
+
+// commitStatus is a single status reported for a commit
+type commitStatus struct {
+	Context     string `json:"context"`
+	State       string `json:"state"`
+	Description string `json:"description,omitempty"`
+	TargetURL   string `json:"target_url,omitempty"`
+}
+
+// checkRunSummary is a check run reported for a commit
+type checkRunSummary struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Conclusion string `json:"conclusion,omitempty"`
+}
+
+// combinedCommitStatus is the combined status of a commit. GitHub reports a commit without any
+// statuses as pending, so TotalCount tells an unreported commit apart from a running one.
+type combinedCommitStatus struct {
+	SHA        string            `json:"sha"`
+	State      string            `json:"state"`
+	TotalCount int               `json:"total_count"`
+	Statuses   []commitStatus    `json:"statuses"`
+	CheckRuns  []checkRunSummary `json:"check_runs,omitempty"`
+}
+
+// GetStatusTool returns a tool for reporting and reading the statuses of commits
+func (g *GitHub) GetStatusTool() goai.Tool {
+	return goai.Tool{
+		Name:        GitHubStatusesToolName,
+		Description: "Manages GitHub commit statuses - set a status, list the combined status and check runs of a commit",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"operation": {
+					"type": "string",
+					"enum": ["set", "list"],
+					"description": "Status operation to perform"
+				},
+				"owner": {
+					"type": "string",
+					"description": "Repository owner"
+				},
+				"repo": {
+					"type": "string",
+					"description": "Repository name"
+				},
+				"sha": {
+					"type": "string",
+					"description": "Commit SHA, or for list any ref such as a branch name"
+				},
+				"state": {
+					"type": "string",
+					"enum": ["pending", "success", "failure", "error"],
+					"description": "State of the status for set"
+				},
+				"context": {
+					"type": "string",
+					"description": "Label distinguishing this status from those of other systems (default \"default\")"
+				},
+				"description": {
+					"type": "string",
+					"description": "Short description of the status"
+				},
+				"target_url": {
+					"type": "string",
+					"description": "URL with the details of the status, such as the CI build"
+				},
+				"include_checks": {
+					"type": "boolean",
+					"description": "Also list the check runs of the commit for list"
+				}
+			},
+			"required": ["operation", "owner", "repo", "sha"]
+		}`),
+		Handler: g.handleStatusOperation,
+	}
+}
+
+func (g *GitHub) handleStatusOperation(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
+	ctx, span := goai.StartSpan(ctx, fmt.Sprintf("%s.Handler", params.Name))
+	defer span.End()
+
+	g.logger.WithFields(map[string]interface{}{
+		"tool":          params.Name,
+		"tool_argument": string(params.Arguments),
+	}).Info("handling status operation")
+
+	var input struct {
+		Operation     string `json:"operation"`
+		Owner         string `json:"owner"`
+		Repo          string `json:"repo"`
+		SHA           string `json:"sha"`
+		State         string `json:"state"`
+		Context       string `json:"context"`
+		Description   string `json:"description"`
+		TargetURL     string `json:"target_url"`
+		IncludeChecks bool   `json:"include_checks"`
+	}
+
+	if err := json.Unmarshal(params.Arguments, &input); err != nil {
+		return goai.CallToolResult{}, fmt.Errorf("failed to unmarshal input: %w", err)
+	}
+
+	if input.SHA == "" {
+		return returnErrorOutput(fmt.Errorf("sha is required for %s", input.Operation)), nil
+	}
+
+	var result interface{}
+	var err error
+
+	switch input.Operation {
+	case "set":
+		if !commitStatusStates[input.State] {
+			return returnErrorOutput(fmt.Errorf("unsupported state %q: must be one of pending, success, failure or error", input.State)), nil
+		}
+
+		status := &github.RepoStatus{State: github.String(input.State)}
+		if input.Context != "" {
+			status.Context = github.String(input.Context)
+		}
+		if input.Description != "" {
+			status.Description = github.String(input.Description)
+		}
+		if input.TargetURL != "" {
+			status.TargetURL = github.String(input.TargetURL)
+		}
+
+		var created *github.RepoStatus
+		created, _, err = g.client.Repositories.CreateStatus(ctx, input.Owner, input.Repo, input.SHA, status)
+		if err == nil {
+			result = newCommitStatus(created)
+		}
+	case "list":
+		result, err = g.combinedStatus(ctx, input.Owner, input.Repo, input.SHA, input.IncludeChecks)
+	default:
+		return returnErrorOutput(fmt.Errorf("unsupported operation: %s", input.Operation)), nil
+	}
+
+	if err != nil {
+		g.logger.WithFields(map[string]interface{}{
+			"tool":             params.Name,
+			goai.ErrorLogField: err,
+			"operation":        input.Operation,
+		}).Error("GitHub status operation failed")
+
+		if isUnprocessable(err) {
+			return returnErrorOutput(fmt.Errorf("github status %s rejected: %s", input.Operation, describeGitHubError(err))), nil
+		}
+		return returnErrorOutput(fmt.Errorf("github status %s error: %w", input.Operation, err)), nil
+	}
+
+	m, err := marshalResult(result)
+	if err != nil {
+		return returnErrorOutput(err), nil
+	}
+	g.logger.WithFields(map[string]interface{}{
+		"tool":          params.Name,
+		"operation":     input.Operation,
+		"result_length": len(m),
+	}).Info("GitHub status operation completed successfully")
+
+	return goai.CallToolResult{
+		Content: []goai.ToolResultContent{{
+			Type: "json",
+			Text: m,
+		}},
+	}, nil
+}
+
+// combinedStatus returns the combined status of ref with all of its statuses and, when
+// includeChecks is set, its check runs
+func (g *GitHub) combinedStatus(ctx context.Context, owner, repo, ref string, includeChecks bool) (*combinedCommitStatus, error) {
+	var combined *github.CombinedStatus
+	statuses, err := paginate(listPageSize(0), listResultCap(0), func(opts github.ListOptions) ([]*github.RepoStatus, *github.Response, error) {
+		page, resp, err := g.client.Repositories.GetCombinedStatus(ctx, owner, repo, ref, &opts)
+		if err != nil {
+			return nil, resp, err
+		}
+		if combined == nil {
+			combined = page
+		}
+		return page.Statuses, resp, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &combinedCommitStatus{
+		SHA:        combined.GetSHA(),
+		State:      combined.GetState(),
+		TotalCount: combined.GetTotalCount(),
+		Statuses:   make([]commitStatus, 0, len(statuses)),
+	}
+	for _, status := range statuses {
+		result.Statuses = append(result.Statuses, newCommitStatus(status))
+	}
+
+	if includeChecks {
+		runs, err := paginate(listPageSize(0), listResultCap(0), func(opts github.ListOptions) ([]*github.CheckRun, *github.Response, error) {
+			page, resp, err := g.client.Checks.ListCheckRunsForRef(ctx, owner, repo, ref, &github.ListCheckRunsOptions{ListOptions: opts})
+			if err != nil {
+				return nil, resp, err
+			}
+			return page.CheckRuns, resp, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		result.CheckRuns = make([]checkRunSummary, 0, len(runs))
+		for _, run := range runs {
+			result.CheckRuns = append(result.CheckRuns, checkRunSummary{
+				Name:       run.GetName(),
+				Status:     run.GetStatus(),
+				Conclusion: run.GetConclusion(),
+			})
+		}
+	}
+
+	return result, nil
+}
+
+func newCommitStatus(status *github.RepoStatus) commitStatus {
+	return commitStatus{
+		Context:     status.GetContext(),
+		State:       status.GetState(),
+		Description: status.GetDescription(),
+		TargetURL:   status.GetTargetURL(),
+	}
+}
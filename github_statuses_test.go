@@ -0,0 +1,161 @@
+package mcptools
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetStatusTool(t *testing.T) {
+	gh := &GitHub{client: github.NewClient(nil), logger: &MockLogger{}}
+
+	tool := gh.GetStatusTool()
+
+	assert.Equal(t, GitHubStatusesToolName, tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.NotNil(t, tool.Handler)
+
+	var schema map[string]interface{}
+	require.NoError(t, json.Unmarshal(tool.InputSchema, &schema))
+	assert.Equal(t, []interface{}{"operation", "owner", "repo", "sha"}, schema["required"])
+}
+
+func TestHandleStatusOperation_Set(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/statuses/abc123", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, map[string]string{
+			"state":       "success",
+			"context":     "ci/build",
+			"description": "Build passed",
+			"target_url":  "https://ci.example.com/builds/1",
+		}, body)
+
+		w.WriteHeader(http.StatusCreated)
+		assert.NoError(t, json.NewEncoder(w).Encode(&github.RepoStatus{
+			State:       github.String("success"),
+			Context:     github.String("ci/build"),
+			Description: github.String("Build passed"),
+			TargetURL:   github.String("https://ci.example.com/builds/1"),
+		}))
+	})
+
+	result := callGitHubHandler(t, gh.handleStatusOperation, GitHubStatusesToolName, map[string]interface{}{
+		"operation":   "set",
+		"owner":       "test-owner",
+		"repo":        "test-repo",
+		"sha":         "abc123",
+		"state":       "success",
+		"context":     "ci/build",
+		"description": "Build passed",
+		"target_url":  "https://ci.example.com/builds/1",
+	})
+	require.False(t, result.IsError, result.Content)
+	assert.JSONEq(t, `{"context": "ci/build", "state": "success", "description": "Build passed", "target_url": "https://ci.example.com/builds/1"}`, result.Content[0].Text)
+}
+
+func TestHandleStatusOperation_List(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/commits/main/status", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.NoError(t, json.NewEncoder(w).Encode(&github.CombinedStatus{
+			SHA:        github.String("abc123"),
+			State:      github.String("failure"),
+			TotalCount: github.Int(2),
+			Statuses: []*github.RepoStatus{
+				{Context: github.String("ci/build"), State: github.String("success")},
+				{Context: github.String("ci/lint"), State: github.String("failure")},
+			},
+		}))
+	})
+	mux.HandleFunc("/repos/test-owner/test-repo/commits/main/check-runs", func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, json.NewEncoder(w).Encode(&github.ListCheckRunsResults{
+			Total: github.Int(1),
+			CheckRuns: []*github.CheckRun{
+				{Name: github.String("tests"), Status: github.String("completed"), Conclusion: github.String("success")},
+			},
+		}))
+	})
+
+	result := callGitHubHandler(t, gh.handleStatusOperation, GitHubStatusesToolName, map[string]interface{}{
+		"operation":      "list",
+		"owner":          "test-owner",
+		"repo":           "test-repo",
+		"sha":            "main",
+		"include_checks": true,
+	})
+	require.False(t, result.IsError, result.Content)
+
+	var status combinedCommitStatus
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &status))
+	assert.Equal(t, combinedCommitStatus{
+		SHA:        "abc123",
+		State:      "failure",
+		TotalCount: 2,
+		Statuses: []commitStatus{
+			{Context: "ci/build", State: "success"},
+			{Context: "ci/lint", State: "failure"},
+		},
+		CheckRuns: []checkRunSummary{{Name: "tests", Status: "completed", Conclusion: "success"}},
+	}, status)
+}
+
+func TestHandleStatusOperation_InvalidInput(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    map[string]interface{}
+		expected string
+	}{
+		{
+			name:     "missing sha",
+			input:    map[string]interface{}{"operation": "list", "owner": "o", "repo": "r"},
+			expected: "sha is required for list",
+		},
+		{
+			name:     "unsupported state",
+			input:    map[string]interface{}{"operation": "set", "owner": "o", "repo": "r", "sha": "abc", "state": "passed"},
+			expected: `unsupported state "passed": must be one of pending, success, failure or error`,
+		},
+		{
+			name:     "missing state",
+			input:    map[string]interface{}{"operation": "set", "owner": "o", "repo": "r", "sha": "abc"},
+			expected: `unsupported state "": must be one of pending, success, failure or error`,
+		},
+		{
+			name:     "unsupported operation",
+			input:    map[string]interface{}{"operation": "clear", "owner": "o", "repo": "r", "sha": "abc"},
+			expected: "unsupported operation: clear",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gh, _, cleanup := setupGitHubTest(t)
+			gh.logger = newPermissiveLogger()
+			defer cleanup()
+
+			result := callGitHubHandler(t, gh.handleStatusOperation, GitHubStatusesToolName, tt.input)
+			assert.True(t, result.IsError)
+			assert.Equal(t, tt.expected, result.Content[0].Text)
+		})
+	}
+}
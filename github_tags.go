@@ -0,0 +1,226 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/shaharia-lab/goai"
+)
+
+// tagRef is a tag reference reported by the tags tool. Type is "commit" for lightweight tags
+// and "tag" for annotated ones, whose SHA is the tag object rather than the tagged commit.
+type tagRef struct {
+	Name string `json:"name"`
+	SHA  string `json:"sha"`
+	Type string `json:"type"`
+}
+
+// GetTagTool returns a tool for managing the lightweight and annotated tags of GitHub repositories
+func (g *GitHub) GetTagTool() goai.Tool {
+	return goai.Tool{
+		Name:        GitHubTagsToolName,
+		Description: "Manages GitHub repository tags - list, create lightweight or annotated tags, delete",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"operation": {
+					"type": "string",
+					"enum": ["list", "create_lightweight", "create_annotated", "delete"],
+					"description": "Tag operation to perform"
+				},
+				"owner": {
+					"type": "string",
+					"description": "Repository owner"
+				},
+				"repo": {
+					"type": "string",
+					"description": "Repository name"
+				},
+				"tag": {
+					"type": "string",
+					"description": "Tag name, without the refs/tags/ prefix"
+				},
+				"object": {
+					"type": "string",
+					"description": "SHA of the object the tag points to"
+				},
+				"object_type": {
+					"type": "string",
+					"enum": ["commit", "tree", "blob"],
+					"description": "Type of the tagged object for create_annotated (default commit)"
+				},
+				"message": {
+					"type": "string",
+					"description": "Tag message for create_annotated"
+				},
+				"tagger_name": {
+					"type": "string",
+					"description": "Name of the tagger for create_annotated"
+				},
+				"tagger_email": {
+					"type": "string",
+					"description": "Email of the tagger for create_annotated"
+				},
+				"per_page": {
+					"type": "integer",
+					"description": "Page size used when listing tags (max 100)"
+				},
+				"max_results": {
+					"type": "integer",
+					"description": "Maximum number of tags listed (default 100)"
+				}
+			},
+			"required": ["operation", "owner", "repo"]
+		}`),
+		Handler: g.handleTagOperation,
+	}
+}
+
+func (g *GitHub) handleTagOperation(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
+	ctx, span := goai.StartSpan(ctx, fmt.Sprintf("%s.Handler", params.Name))
+	defer span.End()
+
+	g.logger.WithFields(map[string]interface{}{
+		"tool":          params.Name,
+		"tool_argument": string(params.Arguments),
+	}).Info("handling tag operation")
+
+	var input struct {
+		Operation   string `json:"operation"`
+		Owner       string `json:"owner"`
+		Repo        string `json:"repo"`
+		Tag         string `json:"tag"`
+		Object      string `json:"object"`
+		ObjectType  string `json:"object_type"`
+		Message     string `json:"message"`
+		TaggerName  string `json:"tagger_name"`
+		TaggerEmail string `json:"tagger_email"`
+		PerPage     int    `json:"per_page"`
+		MaxResults  int    `json:"max_results"`
+	}
+
+	if err := json.Unmarshal(params.Arguments, &input); err != nil {
+		return goai.CallToolResult{}, fmt.Errorf("failed to unmarshal input: %w", err)
+	}
+
+	input.Tag = strings.TrimPrefix(input.Tag, "refs/tags/")
+	if input.Operation != "list" && input.Tag == "" {
+		return returnErrorOutput(fmt.Errorf("tag is required for %s", input.Operation)), nil
+	}
+
+	var result interface{}
+	var err error
+
+	switch input.Operation {
+	case "list":
+		result, err = g.listTags(ctx, input.Owner, input.Repo, input.PerPage, input.MaxResults)
+	case "create_lightweight":
+		if input.Object == "" {
+			return returnErrorOutput(fmt.Errorf("object is required for create_lightweight")), nil
+		}
+		result, err = g.createTagRef(ctx, input.Owner, input.Repo, input.Tag, input.Object)
+	case "create_annotated":
+		if input.Object == "" || input.Message == "" || input.TaggerName == "" || input.TaggerEmail == "" {
+			return returnErrorOutput(fmt.Errorf("create_annotated requires object, message, tagger_name and tagger_email")), nil
+		}
+		if input.ObjectType == "" {
+			input.ObjectType = "commit"
+		}
+
+		// An annotated tag is a tag object plus a ref pointing at it; without the ref the
+		// tag object is unreachable and does not show up as a tag
+		var tag *github.Tag
+		tag, _, err = g.client.Git.CreateTag(ctx, input.Owner, input.Repo, &github.Tag{
+			Tag:     github.String(input.Tag),
+			Message: github.String(input.Message),
+			Object:  &github.GitObject{Type: github.String(input.ObjectType), SHA: github.String(input.Object)},
+			Tagger:  &github.CommitAuthor{Name: github.String(input.TaggerName), Email: github.String(input.TaggerEmail)},
+		})
+		if err != nil {
+			break
+		}
+		result, err = g.createTagRef(ctx, input.Owner, input.Repo, input.Tag, tag.GetSHA())
+	case "delete":
+		_, err = g.client.Git.DeleteRef(ctx, input.Owner, input.Repo, "tags/"+input.Tag)
+		// GitHub answers 422 rather than 404 when deleting a ref that does not exist
+		if isNotFound(err) || isUnprocessable(err) {
+			return returnErrorOutput(fmt.Errorf("tag %s does not exist in %s/%s", input.Tag, input.Owner, input.Repo)), nil
+		}
+		if err == nil {
+			result = map[string]interface{}{"tag": input.Tag, "status": "deleted"}
+		}
+	default:
+		return returnErrorOutput(fmt.Errorf("unsupported operation: %s", input.Operation)), nil
+	}
+
+	if err != nil {
+		g.logger.WithFields(map[string]interface{}{
+			"tool":             params.Name,
+			goai.ErrorLogField: err,
+			"operation":        input.Operation,
+		}).Error("GitHub tag operation failed")
+
+		if isUnprocessable(err) {
+			// Surface validation errors, such as a tag that already exists, exactly as GitHub reports them
+			return returnErrorOutput(errors.New(describeGitHubError(err))), nil
+		}
+		return returnErrorOutput(fmt.Errorf("github tag %s error: %w", input.Operation, err)), nil
+	}
+
+	m, err := marshalResult(result)
+	if err != nil {
+		return returnErrorOutput(err), nil
+	}
+	g.logger.WithFields(map[string]interface{}{
+		"tool":          params.Name,
+		"operation":     input.Operation,
+		"result_length": len(m),
+	}).Info("GitHub tag operation completed successfully")
+
+	return goai.CallToolResult{
+		Content: []goai.ToolResultContent{{
+			Type: "json",
+			Text: m,
+		}},
+	}, nil
+}
+
+// listTags pages through the tag refs of a repository up to maxResults
+func (g *GitHub) listTags(ctx context.Context, owner, repo string, perPage, maxResults int) ([]tagRef, error) {
+	refs, err := paginate(listPageSize(perPage), listResultCap(maxResults), func(opts github.ListOptions) ([]*github.Reference, *github.Response, error) {
+		return g.client.Git.ListMatchingRefs(ctx, owner, repo, &github.ReferenceListOptions{Ref: "tags", ListOptions: opts})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make([]tagRef, 0, len(refs))
+	for _, ref := range refs {
+		tags = append(tags, newTagRef(ref))
+	}
+	return tags, nil
+}
+
+// createTagRef creates the refs/tags/<tag> reference pointing at sha
+func (g *GitHub) createTagRef(ctx context.Context, owner, repo, tag, sha string) (tagRef, error) {
+	ref, _, err := g.client.Git.CreateRef(ctx, owner, repo, &github.Reference{
+		Ref:    github.String("refs/tags/" + tag),
+		Object: &github.GitObject{SHA: github.String(sha)},
+	})
+	if err != nil {
+		return tagRef{}, err
+	}
+	return newTagRef(ref), nil
+}
+
+func newTagRef(ref *github.Reference) tagRef {
+	return tagRef{
+		Name: strings.TrimPrefix(ref.GetRef(), "refs/tags/"),
+		SHA:  ref.GetObject().GetSHA(),
+		Type: ref.GetObject().GetType(),
+	}
+}
@@ -0,0 +1,252 @@
+package mcptools
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTagTool(t *testing.T) {
+	gh := &GitHub{client: github.NewClient(nil), logger: &MockLogger{}}
+
+	tool := gh.GetTagTool()
+
+	assert.Equal(t, GitHubTagsToolName, tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.NotNil(t, tool.Handler)
+
+	var schema map[string]interface{}
+	require.NoError(t, json.Unmarshal(tool.InputSchema, &schema))
+	assert.Equal(t, []interface{}{"operation", "owner", "repo"}, schema["required"])
+}
+
+func TestHandleTagOperation_List(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/git/matching-refs/tags", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+
+		var refs []*github.Reference
+		switch r.URL.Query().Get("page") {
+		case "", "1":
+			w.Header().Set("Link", `<`+server.URL+`/repos/test-owner/test-repo/git/matching-refs/tags?page=2>; rel="next"`)
+			refs = []*github.Reference{{Ref: github.String("refs/tags/v1.0.0"), Object: &github.GitObject{SHA: github.String("aaa"), Type: github.String("commit")}}}
+		case "2":
+			refs = []*github.Reference{{Ref: github.String("refs/tags/v1.1.0"), Object: &github.GitObject{SHA: github.String("bbb"), Type: github.String("tag")}}}
+		}
+		assert.NoError(t, json.NewEncoder(w).Encode(refs))
+	})
+
+	result := callGitHubHandler(t, gh.handleTagOperation, GitHubTagsToolName, map[string]interface{}{
+		"operation": "list",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+	})
+	require.False(t, result.IsError, result.Content)
+
+	var tags []tagRef
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &tags))
+	assert.Equal(t, []tagRef{
+		{Name: "v1.0.0", SHA: "aaa", Type: "commit"},
+		{Name: "v1.1.0", SHA: "bbb", Type: "tag"},
+	}, tags)
+}
+
+func TestHandleTagOperation_CreateLightweight(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/git/refs", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, map[string]string{"ref": "refs/tags/v2.0.0", "sha": "abc123"}, body)
+
+		w.WriteHeader(http.StatusCreated)
+		assert.NoError(t, json.NewEncoder(w).Encode(&github.Reference{
+			Ref:    github.String("refs/tags/v2.0.0"),
+			Object: &github.GitObject{SHA: github.String("abc123"), Type: github.String("commit")},
+		}))
+	})
+
+	result := callGitHubHandler(t, gh.handleTagOperation, GitHubTagsToolName, map[string]interface{}{
+		"operation": "create_lightweight",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+		"tag":       "v2.0.0",
+		"object":    "abc123",
+	})
+	require.False(t, result.IsError, result.Content)
+	assert.JSONEq(t, `{"name": "v2.0.0", "sha": "abc123", "type": "commit"}`, result.Content[0].Text)
+}
+
+func TestHandleTagOperation_CreateAnnotated(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/git/tags", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "v2.0.0", body["tag"])
+		assert.Equal(t, "Release 2.0.0", body["message"])
+		assert.Equal(t, "abc123", body["object"])
+		assert.Equal(t, "commit", body["type"])
+		assert.Equal(t, map[string]interface{}{"name": "Release Bot", "email": "bot@example.com"}, body["tagger"])
+
+		w.WriteHeader(http.StatusCreated)
+		assert.NoError(t, json.NewEncoder(w).Encode(&github.Tag{Tag: github.String("v2.0.0"), SHA: github.String("tagsha")}))
+	})
+	mux.HandleFunc("/repos/test-owner/test-repo/git/refs", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, map[string]string{"ref": "refs/tags/v2.0.0", "sha": "tagsha"}, body)
+
+		w.WriteHeader(http.StatusCreated)
+		assert.NoError(t, json.NewEncoder(w).Encode(&github.Reference{
+			Ref:    github.String("refs/tags/v2.0.0"),
+			Object: &github.GitObject{SHA: github.String("tagsha"), Type: github.String("tag")},
+		}))
+	})
+
+	result := callGitHubHandler(t, gh.handleTagOperation, GitHubTagsToolName, map[string]interface{}{
+		"operation":    "create_annotated",
+		"owner":        "test-owner",
+		"repo":         "test-repo",
+		"tag":          "v2.0.0",
+		"object":       "abc123",
+		"message":      "Release 2.0.0",
+		"tagger_name":  "Release Bot",
+		"tagger_email": "bot@example.com",
+	})
+	require.False(t, result.IsError, result.Content)
+	assert.JSONEq(t, `{"name": "v2.0.0", "sha": "tagsha", "type": "tag"}`, result.Content[0].Text)
+}
+
+func TestHandleTagOperation_CreateExistingTag(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/git/refs", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"message": "Reference already exists"}`))
+	})
+
+	result := callGitHubHandler(t, gh.handleTagOperation, GitHubTagsToolName, map[string]interface{}{
+		"operation": "create_lightweight",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+		"tag":       "v1.0.0",
+		"object":    "abc123",
+	})
+	assert.True(t, result.IsError)
+	assert.Equal(t, "Reference already exists", result.Content[0].Text)
+}
+
+func TestHandleTagOperation_Delete(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		expectError bool
+		expected    string
+	}{
+		{name: "deletes a tag", status: http.StatusNoContent, expected: `{"tag": "v1.0.0", "status": "deleted"}`},
+		{name: "missing tag", status: http.StatusUnprocessableEntity, expectError: true, expected: "tag v1.0.0 does not exist in test-owner/test-repo"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gh, server, cleanup := setupGitHubTest(t)
+			gh.logger = newPermissiveLogger()
+			defer cleanup()
+
+			mux := http.NewServeMux()
+			server.Config.Handler = mux
+
+			mux.HandleFunc("/repos/test-owner/test-repo/git/refs/tags/v1.0.0", func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "DELETE", r.Method)
+				w.WriteHeader(tt.status)
+				if tt.status == http.StatusUnprocessableEntity {
+					_, _ = w.Write([]byte(`{"message": "Reference does not exist"}`))
+				}
+			})
+
+			result := callGitHubHandler(t, gh.handleTagOperation, GitHubTagsToolName, map[string]interface{}{
+				"operation": "delete",
+				"owner":     "test-owner",
+				"repo":      "test-repo",
+				"tag":       "refs/tags/v1.0.0",
+			})
+			assert.Equal(t, tt.expectError, result.IsError)
+			if tt.expectError {
+				assert.Equal(t, tt.expected, result.Content[0].Text)
+			} else {
+				assert.JSONEq(t, tt.expected, result.Content[0].Text)
+			}
+		})
+	}
+}
+
+func TestHandleTagOperation_InvalidInput(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    map[string]interface{}
+		expected string
+	}{
+		{
+			name:     "missing tag",
+			input:    map[string]interface{}{"operation": "delete", "owner": "o", "repo": "r"},
+			expected: "tag is required for delete",
+		},
+		{
+			name:     "lightweight without object",
+			input:    map[string]interface{}{"operation": "create_lightweight", "owner": "o", "repo": "r", "tag": "v1"},
+			expected: "object is required for create_lightweight",
+		},
+		{
+			name:     "annotated without tagger",
+			input:    map[string]interface{}{"operation": "create_annotated", "owner": "o", "repo": "r", "tag": "v1", "object": "abc", "message": "m"},
+			expected: "create_annotated requires object, message, tagger_name and tagger_email",
+		},
+		{
+			name:     "unsupported operation",
+			input:    map[string]interface{}{"operation": "move", "owner": "o", "repo": "r", "tag": "v1"},
+			expected: "unsupported operation: move",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gh, _, cleanup := setupGitHubTest(t)
+			gh.logger = newPermissiveLogger()
+			defer cleanup()
+
+			result := callGitHubHandler(t, gh.handleTagOperation, GitHubTagsToolName, tt.input)
+			assert.True(t, result.IsError)
+			assert.Equal(t, tt.expected, result.Content[0].Text)
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package mcptools
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGitHub(t *testing.T) {
+	tests := []struct {
+		name              string
+		config            GitHubConfig
+		expectedBaseURL   string
+		expectedUploadURL string
+		expectedError     string
+	}{
+		{
+			name:              "github.com by default",
+			config:            GitHubConfig{Token: "token"},
+			expectedBaseURL:   "https://api.github.com/",
+			expectedUploadURL: "https://uploads.github.com/",
+		},
+		{
+			name:              "enterprise base url",
+			config:            GitHubConfig{BaseURL: "https://github.example.com"},
+			expectedBaseURL:   "https://github.example.com/api/v3/",
+			expectedUploadURL: "https://github.example.com/api/uploads/",
+		},
+		{
+			name:              "enterprise upload url",
+			config:            GitHubConfig{BaseURL: "https://github.example.com/api/v3/", UploadURL: "https://uploads.example.com/api/uploads/"},
+			expectedBaseURL:   "https://github.example.com/api/v3/",
+			expectedUploadURL: "https://uploads.example.com/api/uploads/",
+		},
+		{
+			name:          "relative base url",
+			config:        GitHubConfig{BaseURL: "github.example.com/api/v3"},
+			expectedError: `invalid GitHub BaseURL "github.example.com/api/v3": must be an absolute http or https URL`,
+		},
+		{
+			name:          "malformed upload url",
+			config:        GitHubConfig{BaseURL: "https://github.example.com", UploadURL: "https://exa mple.com"},
+			expectedError: `invalid GitHub UploadURL "https://exa mple.com": parse "https://exa mple.com": invalid character " " in host name`,
+		},
+		{
+			name:          "upload url without base url",
+			config:        GitHubConfig{UploadURL: "https://uploads.example.com"},
+			expectedError: "GitHub UploadURL is set without a BaseURL",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gh, err := NewGitHub(newPermissiveLogger(), tt.config)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				assert.Nil(t, gh)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedBaseURL, gh.client.BaseURL.String())
+			assert.Equal(t, tt.expectedUploadURL, gh.client.UploadURL.String())
+		})
+	}
+}
+
+func TestNewGitHubTool_PanicsOnInvalidConfig(t *testing.T) {
+	assert.Panics(t, func() {
+		NewGitHubTool(newPermissiveLogger(), GitHubConfig{BaseURL: "ftp://github.example.com"})
+	})
+}
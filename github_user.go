@@ -0,0 +1,139 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/shaharia-lab/goai"
+)
+
+// gitHubProfile is the profile information reported for a user or an organization
+type gitHubProfile struct {
+	Login       string `json:"login"`
+	Type        string `json:"type"`
+	Name        string `json:"name,omitempty"`
+	Bio         string `json:"bio,omitempty"`
+	Company     string `json:"company,omitempty"`
+	Location    string `json:"location,omitempty"`
+	Blog        string `json:"blog,omitempty"`
+	HTMLURL     string `json:"html_url,omitempty"`
+	PublicRepos int    `json:"public_repos"`
+	Followers   int    `json:"followers"`
+	Following   int    `json:"following"`
+	Plan        string `json:"plan,omitempty"`
+	MemberCount *int   `json:"member_count,omitempty"`
+}
+
+// GetUserTool returns a tool for reading GitHub user and organization profiles
+func (g *GitHub) GetUserTool() goai.Tool {
+	return goai.Tool{
+		Name:        GitHubUserToolName,
+		Description: "Reads the profile of a GitHub user or organization",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"login": {
+					"type": "string",
+					"description": "User or organization login"
+				}
+			},
+			"required": ["login"]
+		}`),
+		Handler: g.handleUserOperation,
+	}
+}
+
+func (g *GitHub) handleUserOperation(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
+	ctx, span := goai.StartSpan(ctx, fmt.Sprintf("%s.Handler", params.Name))
+	defer span.End()
+
+	g.logger.WithFields(map[string]interface{}{
+		"tool":          params.Name,
+		"tool_argument": string(params.Arguments),
+	}).Info("handling user operation")
+
+	var input struct {
+		Login string `json:"login"`
+	}
+
+	if err := json.Unmarshal(params.Arguments, &input); err != nil {
+		return goai.CallToolResult{}, fmt.Errorf("failed to unmarshal input: %w", err)
+	}
+
+	if input.Login == "" {
+		return returnErrorOutput(fmt.Errorf("login is required")), nil
+	}
+
+	profile, err := g.getProfile(ctx, input.Login)
+	if err != nil {
+		g.logger.WithFields(map[string]interface{}{
+			"tool":             params.Name,
+			goai.ErrorLogField: err,
+			"login":            input.Login,
+		}).Error("GitHub user operation failed")
+
+		if isNotFound(err) {
+			return returnErrorOutput(fmt.Errorf("github user or organization %q not found", input.Login)), nil
+		}
+		return returnErrorOutput(fmt.Errorf("github user error: %w", err)), nil
+	}
+
+	m, err := marshalResult(profile)
+	if err != nil {
+		return returnErrorOutput(err), nil
+	}
+	g.logger.WithFields(map[string]interface{}{
+		"tool":          params.Name,
+		"login":         input.Login,
+		"result_length": len(m),
+	}).Info("GitHub user operation completed successfully")
+
+	return goai.CallToolResult{
+		Content: []goai.ToolResultContent{{
+			Type: "json",
+			Text: m,
+		}},
+	}, nil
+}
+
+// getProfile fetches the profile of login, enriching it with organization details for orgs
+func (g *GitHub) getProfile(ctx context.Context, login string) (*gitHubProfile, error) {
+	user, _, err := g.client.Users.Get(ctx, login)
+	if err != nil {
+		return nil, err
+	}
+
+	profile := &gitHubProfile{
+		Login:       user.GetLogin(),
+		Type:        user.GetType(),
+		Name:        user.GetName(),
+		Bio:         user.GetBio(),
+		Company:     user.GetCompany(),
+		Location:    user.GetLocation(),
+		Blog:        user.GetBlog(),
+		HTMLURL:     user.GetHTMLURL(),
+		PublicRepos: user.GetPublicRepos(),
+		Followers:   user.GetFollowers(),
+		Following:   user.GetFollowing(),
+	}
+
+	if user.GetType() != "Organization" {
+		return profile, nil
+	}
+
+	org, _, err := g.client.Organizations.Get(ctx, login)
+	if err != nil {
+		return nil, err
+	}
+	if org.GetDescription() != "" {
+		profile.Bio = org.GetDescription()
+	}
+	// Plan details are only visible to members of the organization
+	if org.Plan != nil {
+		profile.Plan = org.Plan.GetName()
+		profile.MemberCount = org.Plan.FilledSeats
+	}
+
+	return profile, nil
+}
@@ -0,0 +1,115 @@
+package mcptools
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetUserTool(t *testing.T) {
+	gh := &GitHub{
+		client: github.NewClient(nil),
+		logger: &MockLogger{},
+	}
+
+	tool := gh.GetUserTool()
+
+	assert.Equal(t, GitHubUserToolName, tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.NotNil(t, tool.Handler)
+}
+
+func TestHandleUserOperation_User(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/users/octocat", func(w http.ResponseWriter, r *http.Request) {
+		err := json.NewEncoder(w).Encode(&github.User{
+			Login:       github.String("octocat"),
+			Type:        github.String("User"),
+			Name:        github.String("The Octocat"),
+			Bio:         github.String("Mascot"),
+			PublicRepos: github.Int(8),
+			Followers:   github.Int(100),
+		})
+		assert.NoError(t, err)
+	})
+
+	result := callGitHubHandler(t, gh.handleUserOperation, GitHubUserToolName, map[string]interface{}{"login": "octocat"})
+	require.False(t, result.IsError, result.Content)
+
+	var profile gitHubProfile
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &profile))
+	assert.Equal(t, "octocat", profile.Login)
+	assert.Equal(t, "User", profile.Type)
+	assert.Equal(t, "Mascot", profile.Bio)
+	assert.Equal(t, 8, profile.PublicRepos)
+	assert.Equal(t, 100, profile.Followers)
+	assert.Empty(t, profile.Plan)
+	assert.Nil(t, profile.MemberCount)
+}
+
+func TestHandleUserOperation_Organization(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/users/test-org", func(w http.ResponseWriter, r *http.Request) {
+		err := json.NewEncoder(w).Encode(&github.User{
+			Login:       github.String("test-org"),
+			Type:        github.String("Organization"),
+			PublicRepos: github.Int(12),
+			Followers:   github.Int(5),
+		})
+		assert.NoError(t, err)
+	})
+	mux.HandleFunc("/orgs/test-org", func(w http.ResponseWriter, r *http.Request) {
+		err := json.NewEncoder(w).Encode(&github.Organization{
+			Login:       github.String("test-org"),
+			Description: github.String("An organization"),
+			Plan:        &github.Plan{Name: github.String("team"), FilledSeats: github.Int(7)},
+		})
+		assert.NoError(t, err)
+	})
+
+	result := callGitHubHandler(t, gh.handleUserOperation, GitHubUserToolName, map[string]interface{}{"login": "test-org"})
+	require.False(t, result.IsError, result.Content)
+
+	var profile gitHubProfile
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &profile))
+	assert.Equal(t, "Organization", profile.Type)
+	assert.Equal(t, "An organization", profile.Bio)
+	assert.Equal(t, 12, profile.PublicRepos)
+	assert.Equal(t, "team", profile.Plan)
+	require.NotNil(t, profile.MemberCount)
+	assert.Equal(t, 7, *profile.MemberCount)
+}
+
+func TestHandleUserOperation_NotFound(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/users/ghost-login", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+	})
+
+	result := callGitHubHandler(t, gh.handleUserOperation, GitHubUserToolName, map[string]interface{}{"login": "ghost-login"})
+	assert.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].Text, `"ghost-login" not found`)
+}
@@ -0,0 +1,280 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/shaharia-lab/goai"
+)
+
+// webhookRequiredFields are the fields each webhook operation requires
+var webhookRequiredFields = requiredFields{
+	"list":   {"owner", "repo"},
+	"create": {"owner", "repo", "url"},
+	"update": {"owner", "repo"},
+	"delete": {"owner", "repo"},
+	"ping":   {"owner", "repo"},
+}
+
+// webhookSummary is the webhook information reported by the webhooks tool. The secret of a
+// webhook is never reported.
+type webhookSummary struct {
+	ID           int64            `json:"id"`
+	URL          string           `json:"url"`
+	ContentType  string           `json:"content_type,omitempty"`
+	Events       []string         `json:"events"`
+	Active       bool             `json:"active"`
+	LastDelivery *webhookDelivery `json:"last_delivery,omitempty"`
+}
+
+// webhookDelivery is the outcome of the last delivery of a webhook. GitHub reports a status
+// other than "active", such as "misconfigured", and a non-2xx code for broken webhooks.
+type webhookDelivery struct {
+	Code    int    `json:"code,omitempty"`
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+// GetWebhookTool returns a tool for managing the webhooks of GitHub repositories
+func (g *GitHub) GetWebhookTool() goai.Tool {
+	return goai.Tool{
+		Name:        GitHubWebhooksToolName,
+		Description: "Manages GitHub repository webhooks - list with last delivery status, create, update, delete, ping",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"operation": {
+					"type": "string",
+					"enum": ["list", "create", "update", "delete", "ping"],
+					"description": "Webhook operation to perform"
+				},
+				"owner": {
+					"type": "string",
+					"description": "Repository owner"
+				},
+				"repo": {
+					"type": "string",
+					"description": "Repository name"
+				},
+				"hook_id": {
+					"type": "integer",
+					"description": "ID of the webhook for update, delete and ping"
+				},
+				"url": {
+					"type": "string",
+					"description": "URL the payloads are delivered to"
+				},
+				"content_type": {
+					"type": "string",
+					"enum": ["json", "form"],
+					"description": "Media type of the payloads (default json)"
+				},
+				"events": {
+					"type": "array",
+					"items": {"type": "string"},
+					"description": "Events triggering the webhook, such as push or pull_request (default push)"
+				},
+				"secret": {
+					"type": "string",
+					"description": "Secret used to sign the payloads"
+				},
+				"active": {
+					"type": "boolean",
+					"description": "Whether payloads are delivered (default true)"
+				}
+			},
+			"required": ["operation", "owner", "repo"]
+		}`),
+		Handler: g.handleWebhookOperation,
+	}
+}
+
+func (g *GitHub) handleWebhookOperation(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
+	ctx, span := goai.StartSpan(ctx, fmt.Sprintf("%s.Handler", params.Name))
+	defer span.End()
+
+	// The arguments are logged without the secret, whatever redaction rules are configured
+	g.logger.WithFields(map[string]interface{}{
+		"tool":          params.Name,
+		"tool_argument": maskArguments(params.Arguments, "secret"),
+	}).Info("handling webhook operation")
+
+	var input struct {
+		Operation   string   `json:"operation"`
+		Owner       string   `json:"owner"`
+		Repo        string   `json:"repo"`
+		HookID      int64    `json:"hook_id"`
+		URL         string   `json:"url"`
+		ContentType string   `json:"content_type"`
+		Events      []string `json:"events"`
+		Secret      string   `json:"secret"`
+		Active      *bool    `json:"active"`
+	}
+
+	if err := json.Unmarshal(params.Arguments, &input); err != nil {
+		return goai.CallToolResult{}, fmt.Errorf("failed to unmarshal input: %w", err)
+	}
+
+	if err := webhookRequiredFields.validate(input.Operation, map[string]string{
+		"owner": input.Owner,
+		"repo":  input.Repo,
+		"url":   input.URL,
+	}); err != nil {
+		return returnErrorOutput(err), nil
+	}
+	if (input.Operation == "update" || input.Operation == "delete" || input.Operation == "ping") && input.HookID <= 0 {
+		return returnErrorOutput(fmt.Errorf("hook_id is required for operation '%s'", input.Operation)), nil
+	}
+	if input.ContentType != "" && input.ContentType != "json" && input.ContentType != "form" {
+		return returnErrorOutput(fmt.Errorf("unsupported content_type %q: must be json or form", input.ContentType)), nil
+	}
+
+	config := &github.HookConfig{}
+	if input.URL != "" {
+		config.URL = github.String(input.URL)
+	}
+	if input.ContentType != "" {
+		config.ContentType = github.String(input.ContentType)
+	}
+	if input.Secret != "" {
+		config.Secret = github.String(input.Secret)
+	}
+
+	var result interface{}
+	var err error
+
+	switch input.Operation {
+	case "list":
+		var hooks []*github.Hook
+		hooks, err = paginate(listPageSize(0), listResultCap(0), func(opts github.ListOptions) ([]*github.Hook, *github.Response, error) {
+			return g.client.Repositories.ListHooks(ctx, input.Owner, input.Repo, &opts)
+		})
+		summaries := make([]webhookSummary, 0, len(hooks))
+		for _, hook := range hooks {
+			summaries = append(summaries, newWebhookSummary(hook))
+		}
+		result = summaries
+	case "create":
+		if config.ContentType == nil {
+			config.ContentType = github.String("json")
+		}
+		events := input.Events
+		if len(events) == 0 {
+			events = []string{"push"}
+		}
+
+		var hook *github.Hook
+		hook, _, err = g.client.Repositories.CreateHook(ctx, input.Owner, input.Repo, &github.Hook{
+			Name:   github.String("web"),
+			Config: config,
+			Events: events,
+			Active: input.Active,
+		})
+		if err == nil {
+			result = newWebhookSummary(hook)
+		}
+	case "update":
+		result, err = g.updateWebhook(ctx, input.Owner, input.Repo, input.HookID, config, input.Events, input.Active)
+	case "delete":
+		_, err = g.client.Repositories.DeleteHook(ctx, input.Owner, input.Repo, input.HookID)
+		if err == nil {
+			result = map[string]interface{}{"hook_id": input.HookID, "status": "deleted"}
+		}
+	case "ping":
+		_, err = g.client.Repositories.PingHook(ctx, input.Owner, input.Repo, input.HookID)
+		if err == nil {
+			result = map[string]interface{}{"hook_id": input.HookID, "status": "pinged"}
+		}
+	default:
+		return returnErrorOutput(fmt.Errorf("unsupported operation: %s", input.Operation)), nil
+	}
+
+	if err != nil {
+		g.logger.WithFields(map[string]interface{}{
+			"tool":             params.Name,
+			goai.ErrorLogField: err,
+			"operation":        input.Operation,
+		}).Error("GitHub webhook operation failed")
+
+		if isNotFound(err) && input.HookID > 0 {
+			return returnErrorOutput(fmt.Errorf("webhook %d does not exist in %s/%s", input.HookID, input.Owner, input.Repo)), nil
+		}
+		if isUnprocessable(err) {
+			return returnErrorOutput(errors.New(describeGitHubError(err))), nil
+		}
+		return returnErrorOutput(fmt.Errorf("github webhook %s error: %w", input.Operation, err)), nil
+	}
+
+	m, err := marshalResult(result)
+	if err != nil {
+		return returnErrorOutput(err), nil
+	}
+	g.logger.WithFields(map[string]interface{}{
+		"tool":          params.Name,
+		"operation":     input.Operation,
+		"result_length": len(m),
+	}).Info("GitHub webhook operation completed successfully")
+
+	return goai.CallToolResult{
+		Content: []goai.ToolResultContent{{
+			Type: "json",
+			Text: m,
+		}},
+	}, nil
+}
+
+// updateWebhook changes the given settings of a webhook and returns it. The configuration is
+// edited through its own endpoint, since editing the hook replaces its whole configuration and
+// would drop the secret, which GitHub never returns, unless it is passed again.
+func (g *GitHub) updateWebhook(ctx context.Context, owner, repo string, id int64, config *github.HookConfig, events []string, active *bool) (*webhookSummary, error) {
+	if config.URL != nil || config.ContentType != nil || config.Secret != nil {
+		if _, _, err := g.client.Repositories.EditHookConfiguration(ctx, owner, repo, id, config); err != nil {
+			return nil, err
+		}
+	}
+
+	var hook *github.Hook
+	var err error
+	if len(events) > 0 || active != nil {
+		hook, _, err = g.client.Repositories.EditHook(ctx, owner, repo, id, &github.Hook{Events: events, Active: active})
+	} else {
+		hook, _, err = g.client.Repositories.GetHook(ctx, owner, repo, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	summary := newWebhookSummary(hook)
+	return &summary, nil
+}
+
+func newWebhookSummary(hook *github.Hook) webhookSummary {
+	summary := webhookSummary{
+		ID:     hook.GetID(),
+		Events: hook.Events,
+		Active: hook.GetActive(),
+	}
+	if summary.Events == nil {
+		summary.Events = []string{}
+	}
+	if hook.Config != nil {
+		summary.URL = hook.Config.GetURL()
+		summary.ContentType = hook.Config.GetContentType()
+	}
+
+	// last_response is {"code": 200, "status": "active", "message": "OK"}, with a null code
+	// and an "unused" status for webhooks that never delivered anything
+	if hook.LastResponse != nil {
+		delivery := &webhookDelivery{}
+		if code, ok := hook.LastResponse["code"].(float64); ok {
+			delivery.Code = int(code)
+		}
+		delivery.Status, _ = hook.LastResponse["status"].(string)
+		delivery.Message, _ = hook.LastResponse["message"].(string)
+		summary.LastDelivery = delivery
+	}
+	return summary
+}
@@ -0,0 +1,248 @@
+package mcptools
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testWebhookSecret = "s3cr3t-webhook-value"
+
+func TestGetWebhookTool(t *testing.T) {
+	gh := &GitHub{client: github.NewClient(nil), logger: &MockLogger{}}
+
+	tool := gh.GetWebhookTool()
+
+	assert.Equal(t, GitHubWebhooksToolName, tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.NotNil(t, tool.Handler)
+
+	var schema map[string]interface{}
+	require.NoError(t, json.Unmarshal(tool.InputSchema, &schema))
+	assert.Equal(t, []interface{}{"operation", "owner", "repo"}, schema["required"])
+}
+
+func TestHandleWebhookOperation_List(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/hooks", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		_, _ = w.Write([]byte(`[
+			{"id": 1, "name": "web", "active": true, "events": ["push"],
+			 "config": {"url": "https://ci.example.com/hook", "content_type": "json", "secret": "********"},
+			 "last_response": {"code": 200, "status": "active", "message": "OK"}},
+			{"id": 2, "name": "web", "active": true, "events": ["pull_request", "issues"],
+			 "config": {"url": "https://gone.example.com/hook", "content_type": "form"},
+			 "last_response": {"code": 502, "status": "misconfigured", "message": "Bad Gateway"}},
+			{"id": 3, "name": "web", "active": false, "events": ["push"],
+			 "config": {"url": "https://new.example.com/hook"},
+			 "last_response": {"code": null, "status": "unused", "message": null}}
+		]`))
+	})
+
+	result := callGitHubHandler(t, gh.handleWebhookOperation, GitHubWebhooksToolName, map[string]interface{}{
+		"operation": "list",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+	})
+	require.False(t, result.IsError, result.Content)
+	assert.NotContains(t, result.Content[0].Text, "secret")
+
+	var hooks []webhookSummary
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &hooks))
+	assert.Equal(t, []webhookSummary{
+		{ID: 1, URL: "https://ci.example.com/hook", ContentType: "json", Events: []string{"push"}, Active: true, LastDelivery: &webhookDelivery{Code: 200, Status: "active", Message: "OK"}},
+		{ID: 2, URL: "https://gone.example.com/hook", ContentType: "form", Events: []string{"pull_request", "issues"}, Active: true, LastDelivery: &webhookDelivery{Code: 502, Status: "misconfigured", Message: "Bad Gateway"}},
+		{ID: 3, URL: "https://new.example.com/hook", Events: []string{"push"}, LastDelivery: &webhookDelivery{Status: "unused"}},
+	}, hooks)
+}
+
+func TestHandleWebhookOperation_Create(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	logger := newPermissiveLogger()
+	gh.logger = logger
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/hooks", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+
+		var body github.Hook
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "web", body.GetName())
+		assert.Equal(t, []string{"push"}, body.Events)
+		assert.Nil(t, body.Active)
+		assert.Equal(t, &github.HookConfig{
+			URL:         github.String("https://ci.example.com/hook"),
+			ContentType: github.String("json"),
+			Secret:      github.String(testWebhookSecret),
+		}, body.Config)
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id": 42, "name": "web", "active": true, "events": ["push"],
+			"config": {"url": "https://ci.example.com/hook", "content_type": "json", "secret": "********"},
+			"last_response": {"code": null, "status": "unused", "message": null}}`))
+	})
+
+	result := callGitHubHandler(t, gh.handleWebhookOperation, GitHubWebhooksToolName, map[string]interface{}{
+		"operation": "create",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+		"url":       "https://ci.example.com/hook",
+		"secret":    testWebhookSecret,
+	})
+	require.False(t, result.IsError, result.Content)
+	assert.JSONEq(t, `{"id": 42, "url": "https://ci.example.com/hook", "content_type": "json", "events": ["push"], "active": true, "last_delivery": {"status": "unused"}}`, result.Content[0].Text)
+
+	require.NotEmpty(t, logger.Calls)
+	for _, call := range logger.Calls {
+		assert.NotContains(t, fmt.Sprint(call.Arguments...), testWebhookSecret)
+	}
+}
+
+func TestHandleWebhookOperation_Update(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	logger := newPermissiveLogger()
+	gh.logger = logger
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/hooks/42/config", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PATCH", r.Method)
+
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, map[string]string{"secret": testWebhookSecret}, body)
+
+		_, _ = w.Write([]byte(`{"url": "https://ci.example.com/hook", "content_type": "json", "secret": "********"}`))
+	})
+	mux.HandleFunc("/repos/test-owner/test-repo/hooks/42", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PATCH", r.Method)
+
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, map[string]interface{}{"events": []interface{}{"push", "release"}, "active": false}, body)
+
+		_, _ = w.Write([]byte(`{"id": 42, "active": false, "events": ["push", "release"],
+			"config": {"url": "https://ci.example.com/hook", "content_type": "json"}}`))
+	})
+
+	result := callGitHubHandler(t, gh.handleWebhookOperation, GitHubWebhooksToolName, map[string]interface{}{
+		"operation": "update",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+		"hook_id":   42,
+		"secret":    testWebhookSecret,
+		"events":    []string{"push", "release"},
+		"active":    false,
+	})
+	require.False(t, result.IsError, result.Content)
+	assert.JSONEq(t, `{"id": 42, "url": "https://ci.example.com/hook", "content_type": "json", "events": ["push", "release"], "active": false}`, result.Content[0].Text)
+
+	for _, call := range logger.Calls {
+		assert.NotContains(t, fmt.Sprint(call.Arguments...), testWebhookSecret)
+	}
+}
+
+func TestHandleWebhookOperation_DeleteAndPing(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/hooks/42", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "DELETE", r.Method)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/repos/test-owner/test-repo/hooks/42/pings", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/repos/test-owner/test-repo/hooks/7/pings", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	result := callGitHubHandler(t, gh.handleWebhookOperation, GitHubWebhooksToolName, map[string]interface{}{
+		"operation": "delete",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+		"hook_id":   42,
+	})
+	require.False(t, result.IsError, result.Content)
+	assert.JSONEq(t, `{"hook_id": 42, "status": "deleted"}`, result.Content[0].Text)
+
+	result = callGitHubHandler(t, gh.handleWebhookOperation, GitHubWebhooksToolName, map[string]interface{}{
+		"operation": "ping",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+		"hook_id":   42,
+	})
+	require.False(t, result.IsError, result.Content)
+	assert.JSONEq(t, `{"hook_id": 42, "status": "pinged"}`, result.Content[0].Text)
+
+	result = callGitHubHandler(t, gh.handleWebhookOperation, GitHubWebhooksToolName, map[string]interface{}{
+		"operation": "ping",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+		"hook_id":   7,
+	})
+	assert.True(t, result.IsError)
+	assert.Equal(t, "webhook 7 does not exist in test-owner/test-repo", result.Content[0].Text)
+}
+
+func TestHandleWebhookOperation_InvalidInput(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    map[string]interface{}
+		expected string
+	}{
+		{
+			name:     "create without url",
+			input:    map[string]interface{}{"operation": "create", "owner": "o", "repo": "r"},
+			expected: "url is required for operation 'create'",
+		},
+		{
+			name:     "ping without hook id",
+			input:    map[string]interface{}{"operation": "ping", "owner": "o", "repo": "r"},
+			expected: "hook_id is required for operation 'ping'",
+		},
+		{
+			name:     "unsupported content type",
+			input:    map[string]interface{}{"operation": "create", "owner": "o", "repo": "r", "url": "https://example.com", "content_type": "xml"},
+			expected: `unsupported content_type "xml": must be json or form`,
+		},
+		{
+			name:     "unsupported operation",
+			input:    map[string]interface{}{"operation": "test", "owner": "o", "repo": "r"},
+			expected: "unsupported operation: test",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gh, _, cleanup := setupGitHubTest(t)
+			gh.logger = newPermissiveLogger()
+			defer cleanup()
+
+			result := callGitHubHandler(t, gh.handleWebhookOperation, GitHubWebhooksToolName, tt.input)
+			assert.True(t, result.IsError)
+			assert.Equal(t, tt.expected, result.Content[0].Text)
+		})
+	}
+}
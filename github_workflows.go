@@ -0,0 +1,361 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/shaharia-lab/goai"
+)
+
+// failedJobConclusions are the job conclusions GitHub reruns when re-running failed jobs
+var failedJobConclusions = map[string]bool{
+	"failure":   true,
+	"timed_out": true,
+	"cancelled": true,
+}
+
+// GetWorkflowTool returns a tool for managing GitHub Actions workflows
+func (g *GitHub) GetWorkflowTool() goai.Tool {
+	return goai.Tool{
+		Name:        GitHubWorkflowsToolName,
+		Description: "Manages GitHub Actions workflows - list workflows and runs, dispatch, rerun, cancel, list failed jobs",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"operation": {
+					"type": "string",
+					"enum": ["list_workflows", "list_runs", "get_run", "dispatch", "rerun", "cancel", "list_failed_jobs", "rerun_failed_jobs"],
+					"description": "Workflow operation to perform"
+				},
+				"owner": {
+					"type": "string",
+					"description": "Repository owner"
+				},
+				"repo": {
+					"type": "string",
+					"description": "Repository name"
+				},
+				"run_id": {
+					"type": "integer",
+					"description": "Workflow run ID"
+				},
+				"workflow_id": {
+					"type": ["string", "integer"],
+					"description": "Workflow file name (e.g. ci.yml) or numeric ID for dispatch, and to filter list_runs"
+				},
+				"ref": {
+					"type": "string",
+					"description": "Branch or tag to run the workflow on for dispatch"
+				},
+				"inputs": {
+					"type": "object",
+					"description": "Workflow inputs for dispatch"
+				},
+				"branch": {
+					"type": "string",
+					"description": "Filter list_runs by branch"
+				},
+				"status": {
+					"type": "string",
+					"description": "Filter list_runs by status or conclusion (e.g. in_progress, completed, failure)"
+				},
+				"per_page": {
+					"type": "integer",
+					"description": "Number of workflows or runs to list (default 30, max 100)"
+				}
+			},
+			"required": ["operation", "owner", "repo"]
+		}`),
+		Handler: g.handleWorkflowOperation,
+	}
+}
+
+func (g *GitHub) handleWorkflowOperation(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
+	ctx, span := goai.StartSpan(ctx, fmt.Sprintf("%s.Handler", params.Name))
+	defer span.End()
+
+	g.logger.WithFields(map[string]interface{}{
+		"tool":      params.Name,
+		"operation": params.Arguments,
+	}).Info("handling workflow operation")
+
+	var input struct {
+		Operation  string                 `json:"operation"`
+		Owner      string                 `json:"owner"`
+		Repo       string                 `json:"repo"`
+		RunID      int64                  `json:"run_id"`
+		WorkflowID workflowRef            `json:"workflow_id"`
+		Ref        string                 `json:"ref"`
+		Inputs     map[string]interface{} `json:"inputs"`
+		Branch     string                 `json:"branch"`
+		Status     string                 `json:"status"`
+		PerPage    int                    `json:"per_page"`
+	}
+
+	if err := json.Unmarshal(params.Arguments, &input); err != nil {
+		return goai.CallToolResult{}, fmt.Errorf("failed to unmarshal input: %w", err)
+	}
+
+	var result interface{}
+	var err error
+
+	switch input.Operation {
+	case "list_workflows":
+		var workflows *github.Workflows
+		workflows, _, err = g.client.Actions.ListWorkflows(ctx, input.Owner, input.Repo, &github.ListOptions{PerPage: input.PerPage})
+		if err == nil {
+			summaries := make([]workflowSummary, 0, len(workflows.Workflows))
+			for _, workflow := range workflows.Workflows {
+				summaries = append(summaries, workflowSummary{
+					ID:      workflow.GetID(),
+					Name:    workflow.GetName(),
+					Path:    workflow.GetPath(),
+					State:   workflow.GetState(),
+					HTMLURL: workflow.GetHTMLURL(),
+				})
+			}
+			result = map[string]interface{}{"total_count": workflows.GetTotalCount(), "workflows": summaries}
+		}
+	case "list_runs":
+		result, err = g.listWorkflowRuns(ctx, input.Owner, input.Repo, input.WorkflowID, &github.ListWorkflowRunsOptions{
+			Branch:      input.Branch,
+			Status:      input.Status,
+			ListOptions: github.ListOptions{PerPage: input.PerPage},
+		})
+	case "get_run":
+		if input.RunID == 0 {
+			return returnErrorOutput(fmt.Errorf("run_id is required")), nil
+		}
+		var run *github.WorkflowRun
+		run, _, err = g.client.Actions.GetWorkflowRunByID(ctx, input.Owner, input.Repo, input.RunID)
+		if err == nil {
+			result = newWorkflowRunSummary(run)
+		}
+	case "dispatch":
+		if input.WorkflowID == "" || input.Ref == "" {
+			return returnErrorOutput(fmt.Errorf("workflow_id and ref are required for dispatch")), nil
+		}
+		event := github.CreateWorkflowDispatchEventRequest{Ref: input.Ref, Inputs: input.Inputs}
+		if id, convErr := strconv.ParseInt(string(input.WorkflowID), 10, 64); convErr == nil {
+			_, err = g.client.Actions.CreateWorkflowDispatchEventByID(ctx, input.Owner, input.Repo, id, event)
+		} else {
+			_, err = g.client.Actions.CreateWorkflowDispatchEventByFileName(ctx, input.Owner, input.Repo, string(input.WorkflowID), event)
+		}
+		if err == nil {
+			result = map[string]interface{}{"workflow_id": input.WorkflowID, "ref": input.Ref, "status": "dispatched"}
+		}
+	case "rerun":
+		if input.RunID == 0 {
+			return returnErrorOutput(fmt.Errorf("run_id is required")), nil
+		}
+		_, err = g.client.Actions.RerunWorkflowByID(ctx, input.Owner, input.Repo, input.RunID)
+		if err == nil {
+			result = map[string]interface{}{"run_id": input.RunID, "status": "rerun_requested"}
+		}
+	case "cancel":
+		if input.RunID == 0 {
+			return returnErrorOutput(fmt.Errorf("run_id is required")), nil
+		}
+		_, err = g.client.Actions.CancelWorkflowRunByID(ctx, input.Owner, input.Repo, input.RunID)
+		// GitHub accepts cancellation asynchronously with 202, which the client reports as an AcceptedError
+		var accepted *github.AcceptedError
+		if errors.As(err, &accepted) {
+			err = nil
+		}
+		if err == nil {
+			result = map[string]interface{}{"run_id": input.RunID, "status": "cancel_requested"}
+		}
+	case "list_failed_jobs":
+		var jobs []workflowJobSummary
+		jobs, err = g.listFailedJobs(ctx, input.Owner, input.Repo, input.RunID)
+		if err == nil {
+			result = map[string]interface{}{"run_id": input.RunID, "failed_jobs": jobs}
+		}
+	case "rerun_failed_jobs":
+		var jobs []workflowJobSummary
+		jobs, err = g.listFailedJobs(ctx, input.Owner, input.Repo, input.RunID)
+		if err != nil {
+			break
+		}
+		if len(jobs) == 0 {
+			result = map[string]interface{}{
+				"run_id":      input.RunID,
+				"status":      "no_failed_jobs",
+				"rerun_jobs":  jobs,
+				"description": "workflow run has no failed jobs to rerun",
+			}
+			break
+		}
+		_, err = g.client.Actions.RerunFailedJobsByID(ctx, input.Owner, input.Repo, input.RunID)
+		if err == nil {
+			result = map[string]interface{}{"run_id": input.RunID, "status": "rerun_requested", "rerun_jobs": jobs}
+		}
+	default:
+		return returnErrorOutput(fmt.Errorf("unsupported operation: %s", input.Operation)), nil
+	}
+
+	if err != nil {
+		g.logger.WithFields(map[string]interface{}{
+			"tool":             params.Name,
+			goai.ErrorLogField: err,
+			"operation":        input.Operation,
+		}).Error("GitHub workflow operation failed")
+
+		if isUnprocessable(err) {
+			// Surface validation errors, such as undeclared dispatch inputs, exactly as GitHub reports them
+			return returnErrorOutput(errors.New(describeGitHubError(err))), nil
+		}
+		return returnErrorOutput(fmt.Errorf("github workflow %s error: %w", input.Operation, err)), nil
+	}
+
+	m, err := marshalResult(result)
+	if err != nil {
+		return returnErrorOutput(err), nil
+	}
+	g.logger.WithFields(map[string]interface{}{
+		"tool":          params.Name,
+		"operation":     input.Operation,
+		"result_length": len(m),
+	}).Info("GitHub workflow operation completed successfully")
+
+	return goai.CallToolResult{
+		Content: []goai.ToolResultContent{{
+			Type: "json",
+			Text: m,
+		}},
+	}, nil
+}
+
+// workflowRef identifies a workflow by file name or numeric ID, accepting either a JSON string or number
+type workflowRef string
+
+func (w *workflowRef) UnmarshalJSON(data []byte) error {
+	var id json.Number
+	if err := json.Unmarshal(data, &id); err == nil {
+		*w = workflowRef(id.String())
+		return nil
+	}
+
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return fmt.Errorf("workflow_id must be a file name or a numeric ID")
+	}
+	*w = workflowRef(name)
+	return nil
+}
+
+// workflowSummary is the subset of a workflow reported to callers
+type workflowSummary struct {
+	ID      int64  `json:"id"`
+	Name    string `json:"name"`
+	Path    string `json:"path"`
+	State   string `json:"state"`
+	HTMLURL string `json:"html_url"`
+}
+
+// workflowRunSummary is the subset of a workflow run reported to callers. Completed turns true
+// once the run has finished, at which point Conclusion holds its outcome.
+type workflowRunSummary struct {
+	ID         int64     `json:"id"`
+	Name       string    `json:"name"`
+	RunNumber  int       `json:"run_number"`
+	Event      string    `json:"event"`
+	HeadBranch string    `json:"head_branch"`
+	HeadSHA    string    `json:"head_sha"`
+	Status     string    `json:"status"`
+	Conclusion string    `json:"conclusion,omitempty"`
+	Completed  bool      `json:"completed"`
+	HTMLURL    string    `json:"html_url"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// newWorkflowRunSummary extracts the reported fields of a workflow run
+func newWorkflowRunSummary(run *github.WorkflowRun) workflowRunSummary {
+	return workflowRunSummary{
+		ID:         run.GetID(),
+		Name:       run.GetName(),
+		RunNumber:  run.GetRunNumber(),
+		Event:      run.GetEvent(),
+		HeadBranch: run.GetHeadBranch(),
+		HeadSHA:    run.GetHeadSHA(),
+		Status:     run.GetStatus(),
+		Conclusion: run.GetConclusion(),
+		Completed:  run.GetStatus() == "completed",
+		HTMLURL:    run.GetHTMLURL(),
+		CreatedAt:  run.GetCreatedAt().Time,
+		UpdatedAt:  run.GetUpdatedAt().Time,
+	}
+}
+
+// listWorkflowRuns lists the runs of a repository, or of a single workflow when workflowID is set
+func (g *GitHub) listWorkflowRuns(ctx context.Context, owner, repo string, workflowID workflowRef, opts *github.ListWorkflowRunsOptions) (interface{}, error) {
+	var runs *github.WorkflowRuns
+	var err error
+
+	switch id, convErr := strconv.ParseInt(string(workflowID), 10, 64); {
+	case workflowID == "":
+		runs, _, err = g.client.Actions.ListRepositoryWorkflowRuns(ctx, owner, repo, opts)
+	case convErr == nil:
+		runs, _, err = g.client.Actions.ListWorkflowRunsByID(ctx, owner, repo, id, opts)
+	default:
+		runs, _, err = g.client.Actions.ListWorkflowRunsByFileName(ctx, owner, repo, string(workflowID), opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make([]workflowRunSummary, 0, len(runs.WorkflowRuns))
+	for _, run := range runs.WorkflowRuns {
+		summaries = append(summaries, newWorkflowRunSummary(run))
+	}
+	return map[string]interface{}{"total_count": runs.GetTotalCount(), "workflow_runs": summaries}, nil
+}
+
+// workflowJobSummary is the subset of a workflow job reported to callers
+type workflowJobSummary struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	Conclusion string `json:"conclusion"`
+	HTMLURL    string `json:"html_url"`
+}
+
+// listFailedJobs returns the jobs of the latest attempt of a run that did not succeed
+func (g *GitHub) listFailedJobs(ctx context.Context, owner, repo string, runID int64) ([]workflowJobSummary, error) {
+	if runID == 0 {
+		return nil, fmt.Errorf("run_id is required")
+	}
+
+	jobs, err := paginate(100, 0, func(opts github.ListOptions) ([]*github.WorkflowJob, *github.Response, error) {
+		page, resp, err := g.client.Actions.ListWorkflowJobs(ctx, owner, repo, runID, &github.ListWorkflowJobsOptions{
+			Filter:      "latest",
+			ListOptions: opts,
+		})
+		if err != nil {
+			return nil, resp, err
+		}
+		return page.Jobs, resp, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	failed := []workflowJobSummary{}
+	for _, job := range jobs {
+		if !failedJobConclusions[job.GetConclusion()] {
+			continue
+		}
+		failed = append(failed, workflowJobSummary{
+			ID:         job.GetID(),
+			Name:       job.GetName(),
+			Conclusion: job.GetConclusion(),
+			HTMLURL:    job.GetHTMLURL(),
+		})
+	}
+	return failed, nil
+}
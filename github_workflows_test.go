@@ -0,0 +1,352 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/shaharia-lab/goai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// callGitHubHandler marshals input and invokes handler as the named tool.
+func callGitHubHandler(t *testing.T, handler func(context.Context, goai.CallToolParams) (goai.CallToolResult, error), name string, input map[string]interface{}) goai.CallToolResult {
+	t.Helper()
+	inputBytes, err := json.Marshal(input)
+	require.NoError(t, err)
+
+	result, err := handler(context.Background(), goai.CallToolParams{Name: name, Arguments: inputBytes})
+	require.NoError(t, err)
+	return result
+}
+
+func TestGetWorkflowTool(t *testing.T) {
+	gh := &GitHub{
+		client: github.NewClient(nil),
+		logger: &MockLogger{},
+	}
+
+	tool := gh.GetWorkflowTool()
+
+	assert.Equal(t, GitHubWorkflowsToolName, tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.NotNil(t, tool.Handler)
+
+	var schema map[string]interface{}
+	require.NoError(t, json.Unmarshal(tool.InputSchema, &schema))
+	assert.Equal(t, "object", schema["type"])
+}
+
+func TestHandleWorkflowOperation_RerunFailedJobs(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/actions/runs/42/jobs", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "latest", r.URL.Query().Get("filter"))
+
+		err := json.NewEncoder(w).Encode(&github.Jobs{
+			TotalCount: github.Int(3),
+			Jobs: []*github.WorkflowJob{
+				{ID: github.Int64(1), Name: github.String("build"), Conclusion: github.String("success")},
+				{ID: github.Int64(2), Name: github.String("test"), Conclusion: github.String("failure")},
+				{ID: github.Int64(3), Name: github.String("lint"), Conclusion: github.String("timed_out")},
+			},
+		})
+		assert.NoError(t, err)
+	})
+
+	rerunCalled := false
+	mux.HandleFunc("/repos/test-owner/test-repo/actions/runs/42/rerun-failed-jobs", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		rerunCalled = true
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	result := callGitHubHandler(t, gh.handleWorkflowOperation, GitHubWorkflowsToolName, map[string]interface{}{
+		"operation": "rerun_failed_jobs",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+		"run_id":    42,
+	})
+
+	require.False(t, result.IsError, result.Content)
+	assert.True(t, rerunCalled)
+
+	var response struct {
+		Status    string               `json:"status"`
+		RerunJobs []workflowJobSummary `json:"rerun_jobs"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &response))
+	assert.Equal(t, "rerun_requested", response.Status)
+	require.Len(t, response.RerunJobs, 2)
+	assert.Equal(t, "test", response.RerunJobs[0].Name)
+	assert.Equal(t, "lint", response.RerunJobs[1].Name)
+}
+
+func TestHandleWorkflowOperation_RerunWithoutFailedJobs(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/actions/runs/42/jobs", func(w http.ResponseWriter, r *http.Request) {
+		err := json.NewEncoder(w).Encode(&github.Jobs{
+			TotalCount: github.Int(1),
+			Jobs: []*github.WorkflowJob{
+				{ID: github.Int64(1), Name: github.String("build"), Conclusion: github.String("success")},
+			},
+		})
+		assert.NoError(t, err)
+	})
+	mux.HandleFunc("/repos/test-owner/test-repo/actions/runs/42/rerun-failed-jobs", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("rerun-failed-jobs must not be called when no job failed")
+	})
+
+	result := callGitHubHandler(t, gh.handleWorkflowOperation, GitHubWorkflowsToolName, map[string]interface{}{
+		"operation": "rerun_failed_jobs",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+		"run_id":    42,
+	})
+
+	require.False(t, result.IsError, result.Content)
+	assert.Contains(t, result.Content[0].Text, "no_failed_jobs")
+}
+
+func TestHandleWorkflowOperation_MissingRunID(t *testing.T) {
+	gh, _, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	result := callGitHubHandler(t, gh.handleWorkflowOperation, GitHubWorkflowsToolName, map[string]interface{}{
+		"operation": "list_failed_jobs",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+	})
+
+	assert.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].Text, "run_id is required")
+}
+
+func TestHandleWorkflowOperation_ListWorkflows(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/actions/workflows", func(w http.ResponseWriter, r *http.Request) {
+		err := json.NewEncoder(w).Encode(&github.Workflows{
+			TotalCount: github.Int(1),
+			Workflows: []*github.Workflow{
+				{ID: github.Int64(10), Name: github.String("CI"), Path: github.String(".github/workflows/ci.yml"), State: github.String("active")},
+			},
+		})
+		assert.NoError(t, err)
+	})
+
+	result := callGitHubHandler(t, gh.handleWorkflowOperation, GitHubWorkflowsToolName, map[string]interface{}{
+		"operation": "list_workflows",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+	})
+	require.False(t, result.IsError, result.Content)
+
+	var response struct {
+		TotalCount int               `json:"total_count"`
+		Workflows  []workflowSummary `json:"workflows"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &response))
+	assert.Equal(t, 1, response.TotalCount)
+	assert.Equal(t, ".github/workflows/ci.yml", response.Workflows[0].Path)
+}
+
+func TestHandleWorkflowOperation_ListRunsByFileName(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/actions/workflows/ci.yml/runs", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "main", r.URL.Query().Get("branch"))
+
+		err := json.NewEncoder(w).Encode(&github.WorkflowRuns{
+			TotalCount: github.Int(1),
+			WorkflowRuns: []*github.WorkflowRun{
+				{ID: github.Int64(42), Status: github.String("in_progress")},
+			},
+		})
+		assert.NoError(t, err)
+	})
+
+	result := callGitHubHandler(t, gh.handleWorkflowOperation, GitHubWorkflowsToolName, map[string]interface{}{
+		"operation":   "list_runs",
+		"owner":       "test-owner",
+		"repo":        "test-repo",
+		"workflow_id": "ci.yml",
+		"branch":      "main",
+	})
+	require.False(t, result.IsError, result.Content)
+
+	var response struct {
+		WorkflowRuns []workflowRunSummary `json:"workflow_runs"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &response))
+	require.Len(t, response.WorkflowRuns, 1)
+	assert.Equal(t, int64(42), response.WorkflowRuns[0].ID)
+	assert.False(t, response.WorkflowRuns[0].Completed)
+}
+
+func TestHandleWorkflowOperation_GetRun(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/actions/runs/42", func(w http.ResponseWriter, r *http.Request) {
+		err := json.NewEncoder(w).Encode(&github.WorkflowRun{
+			ID:         github.Int64(42),
+			Status:     github.String("completed"),
+			Conclusion: github.String("success"),
+		})
+		assert.NoError(t, err)
+	})
+
+	result := callGitHubHandler(t, gh.handleWorkflowOperation, GitHubWorkflowsToolName, map[string]interface{}{
+		"operation": "get_run",
+		"owner":     "test-owner",
+		"repo":      "test-repo",
+		"run_id":    42,
+	})
+	require.False(t, result.IsError, result.Content)
+
+	var run workflowRunSummary
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &run))
+	assert.Equal(t, "completed", run.Status)
+	assert.Equal(t, "success", run.Conclusion)
+	assert.True(t, run.Completed)
+}
+
+func TestHandleWorkflowOperation_Dispatch(t *testing.T) {
+	tests := []struct {
+		name       string
+		workflowID interface{}
+		path       string
+	}{
+		{name: "by file name", workflowID: "deploy.yml", path: "/repos/test-owner/test-repo/actions/workflows/deploy.yml/dispatches"},
+		{name: "by numeric ID", workflowID: 1234, path: "/repos/test-owner/test-repo/actions/workflows/1234/dispatches"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gh, server, cleanup := setupGitHubTest(t)
+			gh.logger = newPermissiveLogger()
+			defer cleanup()
+
+			mux := http.NewServeMux()
+			server.Config.Handler = mux
+
+			called := false
+			mux.HandleFunc(tt.path, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "POST", r.Method)
+				called = true
+
+				var event github.CreateWorkflowDispatchEventRequest
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&event))
+				assert.Equal(t, "main", event.Ref)
+				assert.Equal(t, map[string]interface{}{"environment": "staging"}, event.Inputs)
+				w.WriteHeader(http.StatusNoContent)
+			})
+
+			result := callGitHubHandler(t, gh.handleWorkflowOperation, GitHubWorkflowsToolName, map[string]interface{}{
+				"operation":   "dispatch",
+				"owner":       "test-owner",
+				"repo":        "test-repo",
+				"workflow_id": tt.workflowID,
+				"ref":         "main",
+				"inputs":      map[string]interface{}{"environment": "staging"},
+			})
+			require.False(t, result.IsError, result.Content)
+			assert.True(t, called)
+			assert.Contains(t, result.Content[0].Text, "dispatched")
+		})
+	}
+}
+
+func TestHandleWorkflowOperation_DispatchUndeclaredInput(t *testing.T) {
+	gh, server, cleanup := setupGitHubTest(t)
+	gh.logger = newPermissiveLogger()
+	defer cleanup()
+
+	mux := http.NewServeMux()
+	server.Config.Handler = mux
+
+	mux.HandleFunc("/repos/test-owner/test-repo/actions/workflows/deploy.yml/dispatches", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"message": "Unexpected inputs provided: [\"colour\"]"}`))
+	})
+
+	result := callGitHubHandler(t, gh.handleWorkflowOperation, GitHubWorkflowsToolName, map[string]interface{}{
+		"operation":   "dispatch",
+		"owner":       "test-owner",
+		"repo":        "test-repo",
+		"workflow_id": "deploy.yml",
+		"ref":         "main",
+		"inputs":      map[string]interface{}{"colour": "blue"},
+	})
+
+	assert.True(t, result.IsError)
+	assert.Equal(t, `Unexpected inputs provided: ["colour"]`, result.Content[0].Text)
+}
+
+func TestHandleWorkflowOperation_RerunAndCancel(t *testing.T) {
+	tests := []struct {
+		operation string
+		path      string
+		status    int
+		expected  string
+	}{
+		{operation: "rerun", path: "/repos/test-owner/test-repo/actions/runs/42/rerun", status: http.StatusCreated, expected: "rerun_requested"},
+		{operation: "cancel", path: "/repos/test-owner/test-repo/actions/runs/42/cancel", status: http.StatusAccepted, expected: "cancel_requested"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.operation, func(t *testing.T) {
+			gh, server, cleanup := setupGitHubTest(t)
+			gh.logger = newPermissiveLogger()
+			defer cleanup()
+
+			mux := http.NewServeMux()
+			server.Config.Handler = mux
+
+			mux.HandleFunc(tt.path, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "POST", r.Method)
+				w.WriteHeader(tt.status)
+			})
+
+			result := callGitHubHandler(t, gh.handleWorkflowOperation, GitHubWorkflowsToolName, map[string]interface{}{
+				"operation": tt.operation,
+				"owner":     "test-owner",
+				"repo":      "test-repo",
+				"run_id":    42,
+			})
+			require.False(t, result.IsError, result.Content)
+			assert.Contains(t, result.Content[0].Text, tt.expected)
+		})
+	}
+}
@@ -0,0 +1,273 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/shaharia-lab/goai"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+// ToolMiddleware wraps a goai.Tool, typically decorating its Handler.
+type ToolMiddleware func(tool goai.Tool) goai.Tool
+
+// ApplyMiddleware wraps every tool with the given middlewares. The first middleware
+// is the outermost one.
+func ApplyMiddleware(tools []goai.Tool, middlewares ...ToolMiddleware) []goai.Tool {
+	wrapped := make([]goai.Tool, len(tools))
+	for i, tool := range tools {
+		for j := len(middlewares) - 1; j >= 0; j-- {
+			tool = middlewares[j](tool)
+		}
+		wrapped[i] = tool
+	}
+	return wrapped
+}
+
+// Summarizer receives content that exceeds a result budget and returns a shorter version of it.
+type Summarizer func(ctx context.Context, toolName string, content string) (string, error)
+
+// ResultBudget limits the size of the content a tool may return.
+type ResultBudget struct {
+	// MaxResultBytes is the largest content size passed through unchanged. Zero disables the budget.
+	MaxResultBytes int
+	// Summarizer is invoked for oversized content. When nil, or when it fails,
+	// the content is truncated with a marker instead.
+	Summarizer Summarizer
+}
+
+// WithResultBudget returns a middleware that keeps each content block of a tool result
+// within the budget, summarizing or truncating oversized blocks. Oversized json blocks become
+// text blocks, since their summary or truncation is no longer a valid JSON document.
+func WithResultBudget(budget ResultBudget) ToolMiddleware {
+	return func(tool goai.Tool) goai.Tool {
+		if budget.MaxResultBytes <= 0 {
+			return tool
+		}
+
+		handler := tool.Handler
+		tool.Handler = func(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
+			result, err := handler(ctx, params)
+			if err != nil {
+				return result, err
+			}
+
+			for i, content := range result.Content {
+				text := budget.apply(ctx, tool.Name, content.Text)
+				if text != content.Text && content.Type == "json" {
+					result.Content[i].Type = "text"
+				}
+				result.Content[i].Text = text
+			}
+			return result, nil
+		}
+		return tool
+	}
+}
+
+// apply returns text unchanged when it fits the budget, otherwise its summary or truncation.
+func (b ResultBudget) apply(ctx context.Context, toolName string, text string) string {
+	if len(text) <= b.MaxResultBytes {
+		return text
+	}
+
+	if b.Summarizer != nil {
+		summary, err := b.Summarizer(ctx, toolName, text)
+		if err == nil && len(summary) <= b.MaxResultBytes {
+			return summary
+		}
+	}
+
+	return truncateOutput(text, b.MaxResultBytes)
+}
+
+// DefaultToolTimeout is the handler timeout used by WithTimeout when no default is configured.
+const DefaultToolTimeout = 60 * time.Second
+
+// ToolTimeouts configures the handler timeouts applied by WithTimeout.
+type ToolTimeouts struct {
+	// Default applies to every tool without an override. Zero means DefaultToolTimeout.
+	Default time.Duration
+	// PerTool overrides the default by tool name. A negative duration disables the timeout.
+	PerTool map[string]time.Duration
+}
+
+// timeoutFor returns the timeout configured for the named tool.
+func (t ToolTimeouts) timeoutFor(name string) time.Duration {
+	if timeout, ok := t.PerTool[name]; ok {
+		return timeout
+	}
+	if t.Default == 0 {
+		return DefaultToolTimeout
+	}
+	return t.Default
+}
+
+// WithTimeout returns a middleware that bounds every handler call with a context timeout,
+// so a call cannot hang forever even if the tool applies no timeout of its own. A handler
+// still running when the timeout fires is abandoned and a timeout error result is returned.
+func WithTimeout(timeouts ToolTimeouts) ToolMiddleware {
+	return func(tool goai.Tool) goai.Tool {
+		timeout := timeouts.timeoutFor(tool.Name)
+		if timeout <= 0 {
+			return tool
+		}
+
+		handler := tool.Handler
+		tool.Handler = func(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			type response struct {
+				result goai.CallToolResult
+				err    error
+			}
+			done := make(chan response, 1)
+			go func() {
+				result, err := handler(ctx, params)
+				done <- response{result: result, err: err}
+			}()
+
+			select {
+			case resp := <-done:
+				return resp.result, resp.err
+			case <-ctx.Done():
+				if ctx.Err() == context.DeadlineExceeded {
+					return returnErrorOutput(fmt.Errorf("tool %s timed out after %s", tool.Name, timeout)), nil
+				}
+				return goai.CallToolResult{}, ctx.Err()
+			}
+		}
+		return tool
+	}
+}
+
+// Names of the instruments recorded by WithMetrics.
+const (
+	ToolInvocationsMetricName = "mcp.tool.invocations"
+	ToolFailuresMetricName    = "mcp.tool.failures"
+	ToolDurationMetricName    = "mcp.tool.duration"
+)
+
+// WithMetrics returns a middleware that records, labeled by tool name and, for tools taking an
+// operation or a git command, by operation, the number of calls, the number of failed calls and
+// the call duration in seconds with the instruments of meter. A call fails when the handler
+// returns an error or an error result. A nil meter disables the middleware. The tool
+// constructors do not apply it; tools record metrics only when wrapped, e.g. by AllTools with
+// RegistryConfig.Meter set.
+func WithMetrics(meter metric.Meter) ToolMiddleware {
+	if meter == nil {
+		return func(tool goai.Tool) goai.Tool { return tool }
+	}
+
+	invocations, err := meter.Int64Counter(ToolInvocationsMetricName,
+		metric.WithDescription("Number of tool calls"),
+		metric.WithUnit("{call}"))
+	if err != nil {
+		otel.Handle(err)
+		invocations = noop.Int64Counter{}
+	}
+	failures, err := meter.Int64Counter(ToolFailuresMetricName,
+		metric.WithDescription("Number of tool calls that returned an error"),
+		metric.WithUnit("{call}"))
+	if err != nil {
+		otel.Handle(err)
+		failures = noop.Int64Counter{}
+	}
+	duration, err := meter.Float64Histogram(ToolDurationMetricName,
+		metric.WithDescription("Duration of tool calls"),
+		metric.WithUnit("s"))
+	if err != nil {
+		otel.Handle(err)
+		duration = noop.Float64Histogram{}
+	}
+
+	return func(tool goai.Tool) goai.Tool {
+		handler := tool.Handler
+		operations := toolOperations(tool)
+		tool.Handler = func(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
+			attrs := []attribute.KeyValue{attribute.String("tool.name", tool.Name)}
+			if operation := toolOperation(tool.Name, operations, params.Arguments); operation != "" {
+				attrs = append(attrs, attribute.String("tool.operation", operation))
+			}
+			set := metric.WithAttributeSet(attribute.NewSet(attrs...))
+
+			start := time.Now()
+			result, err := handler(ctx, params)
+			duration.Record(ctx, time.Since(start).Seconds(), set)
+			invocations.Add(ctx, 1, set)
+			if err != nil || result.IsError {
+				failures.Add(ctx, 1, set)
+			}
+			return result, err
+		}
+		return tool
+	}
+}
+
+// otherOperation labels the calls whose operation is not one the tool declares.
+const otherOperation = "other"
+
+// gitOperations are the git tool commands used as metric labels. The command is free-form, so
+// any other command is labeled otherOperation.
+var gitOperations = map[string]bool{
+	"add": true, "bisect": true, "blame": true, "branch": true, "checkout": true,
+	"cherry-pick": true, "clean": true, "clone": true, "commit": true, "config": true,
+	"describe": true, "diff": true, "fetch": true, "format-patch": true, "grep": true,
+	"init": true, "log": true, "ls-files": true, "merge": true, "mv": true, "pull": true,
+	"push": true, "rebase": true, "reflog": true, "remote": true, "reset": true,
+	"restore": true, "rev-list": true, "rev-parse": true, "revert": true, "rm": true,
+	"shortlog": true, "show": true, "stash": true, "status": true, "submodule": true,
+	"switch": true, "tag": true, "worktree": true,
+}
+
+// toolOperations returns the operations tool declares in the enum of the operation property of
+// its input schema, or the known commands of the git tool.
+func toolOperations(tool goai.Tool) map[string]bool {
+	if tool.Name == GitToolName {
+		return gitOperations
+	}
+
+	var schema struct {
+		Properties struct {
+			Operation struct {
+				Enum []string `json:"enum"`
+			} `json:"operation"`
+		} `json:"properties"`
+	}
+	if err := json.Unmarshal(tool.InputSchema, &schema); err != nil {
+		return nil
+	}
+	operations := make(map[string]bool, len(schema.Properties.Operation.Enum))
+	for _, operation := range schema.Properties.Operation.Enum {
+		operations[operation] = true
+	}
+	return operations
+}
+
+// toolOperation returns the operation a call performs: its operation argument, or the command
+// of the git tool. Operations missing from operations are reported as otherOperation and
+// free-form commands, such as those of the bash tool, are not operations, which keeps the
+// cardinality of the metric labels bounded.
+func toolOperation(toolName string, operations map[string]bool, arguments json.RawMessage) string {
+	var input struct {
+		Operation string `json:"operation"`
+		Command   string `json:"command"`
+	}
+	if err := json.Unmarshal(arguments, &input); err != nil {
+		return ""
+	}
+	operation := input.Operation
+	if operation == "" && toolName == GitToolName {
+		operation = input.Command
+	}
+	if operation != "" && !operations[operation] {
+		return otherOperation
+	}
+	return operation
+}
@@ -0,0 +1,375 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shaharia-lab/goai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+// newTextTool returns a tool whose handler always responds with the given text.
+func newTextTool(name, text string) goai.Tool {
+	return goai.Tool{
+		Name: name,
+		Handler: func(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
+			return goai.CallToolResult{Content: []goai.ToolResultContent{{Type: "text", Text: text}}}, nil
+		},
+	}
+}
+
+func TestWithResultBudget(t *testing.T) {
+	oversized := strings.Repeat("a", 100)
+
+	tests := []struct {
+		name       string
+		text       string
+		summarizer Summarizer
+		expected   string
+		summarized bool
+	}{
+		{
+			name:     "content within budget is unchanged",
+			text:     "short",
+			expected: "short",
+		},
+		{
+			name:     "oversized content is truncated without a summarizer",
+			text:     oversized,
+			expected: strings.Repeat("a", 10) + "\n... output truncated (100 bytes total)",
+		},
+		{
+			name: "oversized content is summarized",
+			text: oversized,
+			summarizer: func(ctx context.Context, toolName string, content string) (string, error) {
+				return "summary", nil
+			},
+			expected:   "summary",
+			summarized: true,
+		},
+		{
+			name: "failing summarizer falls back to truncation",
+			text: oversized,
+			summarizer: func(ctx context.Context, toolName string, content string) (string, error) {
+				return "", errors.New("summarizer unavailable")
+			},
+			expected:   strings.Repeat("a", 10) + "\n... output truncated (100 bytes total)",
+			summarized: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			invoked := false
+			var summarizer Summarizer
+			if tt.summarizer != nil {
+				summarizer = func(ctx context.Context, toolName string, content string) (string, error) {
+					invoked = true
+					assert.Equal(t, "budgeted", toolName)
+					assert.Equal(t, tt.text, content)
+					return tt.summarizer(ctx, toolName, content)
+				}
+			}
+
+			tool := WithResultBudget(ResultBudget{MaxResultBytes: 10, Summarizer: summarizer})(newTextTool("budgeted", tt.text))
+
+			result, err := tool.Handler(context.Background(), goai.CallToolParams{Name: "budgeted"})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result.Content[0].Text)
+			assert.Equal(t, tt.summarized, invoked)
+		})
+	}
+}
+
+func TestWithResultBudget_JSONContent(t *testing.T) {
+	document := `{"items":["first","second","third"]}`
+	tool := goai.Tool{
+		Name: "budgeted",
+		Handler: func(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
+			return goai.CallToolResult{Content: []goai.ToolResultContent{
+				{Type: "json", Text: document},
+				{Type: "json", Text: `{}`},
+			}}, nil
+		},
+	}
+
+	result, err := WithResultBudget(ResultBudget{MaxResultBytes: 10})(tool).Handler(context.Background(), goai.CallToolParams{Name: "budgeted"})
+	require.NoError(t, err)
+	require.Len(t, result.Content, 2)
+
+	assert.Equal(t, "text", result.Content[0].Type, "a truncated json block is no longer valid JSON")
+	assert.Equal(t, document[:10]+"\n... output truncated (36 bytes total)", result.Content[0].Text)
+	assert.Equal(t, goai.ToolResultContent{Type: "json", Text: `{}`}, result.Content[1])
+}
+
+func TestApplyMiddleware(t *testing.T) {
+	var order []string
+	record := func(label string) ToolMiddleware {
+		return func(tool goai.Tool) goai.Tool {
+			handler := tool.Handler
+			tool.Handler = func(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
+				order = append(order, label)
+				return handler(ctx, params)
+			}
+			return tool
+		}
+	}
+
+	tools := ApplyMiddleware([]goai.Tool{newTextTool("a", "ok")}, record("outer"), record("inner"))
+	require.Len(t, tools, 1)
+
+	_, err := tools[0].Handler(context.Background(), goai.CallToolParams{Name: "a"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"outer", "inner"}, order)
+}
+
+// newSleepingTool returns a tool whose handler ignores its context and sleeps for d.
+func newSleepingTool(name string, d time.Duration) goai.Tool {
+	return goai.Tool{
+		Name: name,
+		Handler: func(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
+			time.Sleep(d)
+			return goai.CallToolResult{Content: []goai.ToolResultContent{{Type: "text", Text: "done"}}}, nil
+		},
+	}
+}
+
+func TestWithTimeout(t *testing.T) {
+	tests := []struct {
+		name        string
+		timeouts    ToolTimeouts
+		sleep       time.Duration
+		expectError bool
+		expected    string
+	}{
+		{
+			name:        "handler exceeding the default is cancelled",
+			timeouts:    ToolTimeouts{Default: 20 * time.Millisecond},
+			sleep:       time.Second,
+			expectError: true,
+			expected:    "tool slow timed out after 20ms",
+		},
+		{
+			name:     "handler within the default completes",
+			timeouts: ToolTimeouts{Default: time.Second},
+			sleep:    time.Millisecond,
+			expected: "done",
+		},
+		{
+			name:     "per-tool override takes precedence",
+			timeouts: ToolTimeouts{Default: 20 * time.Millisecond, PerTool: map[string]time.Duration{"slow": time.Second}},
+			sleep:    50 * time.Millisecond,
+			expected: "done",
+		},
+		{
+			name:     "negative override disables the timeout",
+			timeouts: ToolTimeouts{Default: 20 * time.Millisecond, PerTool: map[string]time.Duration{"slow": -1}},
+			sleep:    50 * time.Millisecond,
+			expected: "done",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tool := WithTimeout(tt.timeouts)(newSleepingTool("slow", tt.sleep))
+
+			result, err := tool.Handler(context.Background(), goai.CallToolParams{Name: "slow"})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectError, result.IsError)
+			assert.Equal(t, tt.expected, result.Content[0].Text)
+		})
+	}
+}
+
+func TestWithTimeout_HandlerSeesDeadline(t *testing.T) {
+	tool := WithTimeout(ToolTimeouts{})(goai.Tool{
+		Name: "deadline",
+		Handler: func(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
+			deadline, ok := ctx.Deadline()
+			assert.True(t, ok)
+			assert.WithinDuration(t, time.Now().Add(DefaultToolTimeout), deadline, time.Second)
+			return goai.CallToolResult{}, nil
+		},
+	})
+
+	_, err := tool.Handler(context.Background(), goai.CallToolParams{Name: "deadline"})
+	require.NoError(t, err)
+}
+
+// recordingMeter is a metric.Meter keeping the measurements of its counters and histograms,
+// keyed by instrument name
+type recordingMeter struct {
+	noop.Meter
+	mu           sync.Mutex
+	measurements map[string][]recordedMeasurement
+}
+
+// recordedMeasurement is a single measurement of a recordingMeter instrument
+type recordedMeasurement struct {
+	value float64
+	attrs attribute.Set
+}
+
+func newRecordingMeter() *recordingMeter {
+	return &recordingMeter{measurements: map[string][]recordedMeasurement{}}
+}
+
+func (m *recordingMeter) record(name string, value float64, attrs attribute.Set) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.measurements[name] = append(m.measurements[name], recordedMeasurement{value: value, attrs: attrs})
+}
+
+func (m *recordingMeter) Int64Counter(name string, _ ...metric.Int64CounterOption) (metric.Int64Counter, error) {
+	return recordingCounter{meter: m, name: name}, nil
+}
+
+func (m *recordingMeter) Float64Histogram(name string, _ ...metric.Float64HistogramOption) (metric.Float64Histogram, error) {
+	return recordingHistogram{meter: m, name: name}, nil
+}
+
+type recordingCounter struct {
+	noop.Int64Counter
+	meter *recordingMeter
+	name  string
+}
+
+func (c recordingCounter) Add(_ context.Context, incr int64, options ...metric.AddOption) {
+	c.meter.record(c.name, float64(incr), metric.NewAddConfig(options).Attributes())
+}
+
+type recordingHistogram struct {
+	noop.Float64Histogram
+	meter *recordingMeter
+	name  string
+}
+
+func (h recordingHistogram) Record(_ context.Context, value float64, options ...metric.RecordOption) {
+	h.meter.record(h.name, value, metric.NewRecordConfig(options).Attributes())
+}
+
+func TestWithMetrics(t *testing.T) {
+	failing := goai.Tool{
+		Name: "failing",
+		Handler: func(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
+			return returnErrorOutput(errors.New("boom")), nil
+		},
+	}
+	broken := goai.Tool{
+		Name: "broken",
+		Handler: func(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
+			return goai.CallToolResult{}, errors.New("bad input")
+		},
+	}
+	tags := newTextTool(GitHubTagsToolName, "ok")
+	tags.InputSchema = json.RawMessage(`{"type": "object", "properties": {"operation": {"type": "string", "enum": ["list", "get"]}}}`)
+
+	tests := []struct {
+		name          string
+		tool          goai.Tool
+		arguments     string
+		expectedAttrs attribute.Set
+		expectFailure bool
+	}{
+		{
+			name:          "successful call",
+			tool:          newTextTool("echo", "ok"),
+			arguments:     `{}`,
+			expectedAttrs: attribute.NewSet(attribute.String("tool.name", "echo")),
+		},
+		{
+			name:      "operation label",
+			tool:      tags,
+			arguments: `{"operation": "list", "owner": "o"}`,
+			expectedAttrs: attribute.NewSet(
+				attribute.String("tool.name", GitHubTagsToolName),
+				attribute.String("tool.operation", "list"),
+			),
+		},
+		{
+			name:      "undeclared operation label",
+			tool:      tags,
+			arguments: `{"operation": "list-0123456789", "owner": "o"}`,
+			expectedAttrs: attribute.NewSet(
+				attribute.String("tool.name", GitHubTagsToolName),
+				attribute.String("tool.operation", "other"),
+			),
+		},
+		{
+			name:      "git command label",
+			tool:      newTextTool(GitToolName, "ok"),
+			arguments: `{"command": "status", "repo_path": "/repo"}`,
+			expectedAttrs: attribute.NewSet(
+				attribute.String("tool.name", GitToolName),
+				attribute.String("tool.operation", "status"),
+			),
+		},
+		{
+			name:      "unknown git command label",
+			tool:      newTextTool(GitToolName, "ok"),
+			arguments: `{"command": "status; rm -rf /", "repo_path": "/repo"}`,
+			expectedAttrs: attribute.NewSet(
+				attribute.String("tool.name", GitToolName),
+				attribute.String("tool.operation", "other"),
+			),
+		},
+		{
+			name:          "bash commands are not labels",
+			tool:          newTextTool(BashToolName, "ok"),
+			arguments:     `{"command": "ls -la"}`,
+			expectedAttrs: attribute.NewSet(attribute.String("tool.name", BashToolName)),
+		},
+		{
+			name:          "error result",
+			tool:          failing,
+			arguments:     `{}`,
+			expectedAttrs: attribute.NewSet(attribute.String("tool.name", "failing")),
+			expectFailure: true,
+		},
+		{
+			name:          "handler error",
+			tool:          broken,
+			arguments:     `not json`,
+			expectedAttrs: attribute.NewSet(attribute.String("tool.name", "broken")),
+			expectFailure: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meter := newRecordingMeter()
+			tool := WithMetrics(meter)(tt.tool)
+
+			_, _ = tool.Handler(context.Background(), goai.CallToolParams{Name: tt.tool.Name, Arguments: json.RawMessage(tt.arguments)})
+
+			assert.Equal(t, []recordedMeasurement{{value: 1, attrs: tt.expectedAttrs}}, meter.measurements[ToolInvocationsMetricName])
+			if tt.expectFailure {
+				assert.Equal(t, []recordedMeasurement{{value: 1, attrs: tt.expectedAttrs}}, meter.measurements[ToolFailuresMetricName])
+			} else {
+				assert.Empty(t, meter.measurements[ToolFailuresMetricName])
+			}
+
+			require.Len(t, meter.measurements[ToolDurationMetricName], 1)
+			assert.Equal(t, tt.expectedAttrs, meter.measurements[ToolDurationMetricName][0].attrs)
+			assert.GreaterOrEqual(t, meter.measurements[ToolDurationMetricName][0].value, 0.0)
+		})
+	}
+}
+
+func TestWithMetrics_NilMeter(t *testing.T) {
+	tool := newTextTool("echo", "ok")
+	wrapped := WithMetrics(nil)(tool)
+
+	result, err := wrapped.Handler(context.Background(), goai.CallToolParams{Name: "echo"})
+	require.NoError(t, err)
+	assert.Equal(t, "ok", result.Content[0].Text)
+}
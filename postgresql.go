@@ -129,13 +129,13 @@ func (p *PostgreSQL) PostgreSQLAllInOneTool() goai.Tool {
 			switch input.Operation {
 			case "query":
 				if input.Query == "" {
-					return goai.CallToolResult{}, fmt.Errorf("query is required for operation 'query'")
+					return returnErrorOutput(fmt.Errorf("query is required for operation 'query'")), nil
 				}
 				return p.executeQuery(ctx, db, input.Query)
 
 			case "explain":
 				if input.Query == "" {
-					return goai.CallToolResult{}, fmt.Errorf("query is required for operation 'explain'")
+					return returnErrorOutput(fmt.Errorf("query is required for operation 'explain'")), nil
 				}
 				return p.executeExplain(ctx, db, input.Query)
 
@@ -149,7 +149,7 @@ func (p *PostgreSQL) PostgreSQLAllInOneTool() goai.Tool {
 				p.logger.WithFields(map[string]interface{}{
 					"operation": input.Operation,
 				}).Error("Invalid operation")
-				return goai.CallToolResult{}, fmt.Errorf("unknown operation: %s", input.Operation)
+				return returnErrorOutput(fmt.Errorf("unknown operation: %s", input.Operation)), nil
 			}
 		},
 	}
@@ -164,7 +164,7 @@ func (p *PostgreSQL) executeQuery(ctx context.Context, db *sql.DB, query string)
 
 	rows, err := db.QueryContext(ctx, query)
 	if err != nil {
-		return goai.CallToolResult{}, err
+		return returnErrorOutput(err), nil
 	}
 	defer rows.Close()
 
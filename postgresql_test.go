@@ -3,6 +3,7 @@ package mcptools
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"testing"
 
 	"github.com/shaharia-lab/goai"
@@ -170,3 +171,47 @@ func TestPostgreSQL_ListDatabases(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
 }
+
+func TestPostgreSQL_FailuresAreToolErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    map[string]interface{}
+		expected string
+	}{
+		{
+			name:     "query fails",
+			input:    map[string]interface{}{"operation": "query", "database": "test_db", "query": "SELECT * FROM missing"},
+			expected: "relation \"missing\" does not exist",
+		},
+		{
+			name:     "query is missing",
+			input:    map[string]interface{}{"operation": "explain", "database": "test_db"},
+			expected: "query is required for operation 'explain'",
+		},
+		{
+			name:     "unknown operation",
+			input:    map[string]interface{}{"operation": "drop", "database": "test_db"},
+			expected: "unknown operation: drop",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, sqlMock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			sqlMock.ExpectQuery("SELECT").WillReturnError(errors.New(`relation "missing" does not exist`))
+
+			pg := NewPostgreSQL(newPermissiveLogger(), PostgreSQLConfig{})
+			pg.connPool["test_db"] = db
+
+			inputJSON, err := json.Marshal(tt.input)
+			require.NoError(t, err)
+
+			result, err := pg.PostgreSQLAllInOneTool().Handler(context.Background(), goai.CallToolParams{Name: PostgreSQLToolName, Arguments: inputJSON})
+			require.NoError(t, err)
+			assert.True(t, result.IsError)
+			assert.Equal(t, tt.expected, result.Content[0].Text)
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/shaharia-lab/goai"
+)
+
+// redacted replaces secrets scrubbed from log output
+const redacted = "[REDACTED]"
+
+// RedactionRule scrubs secrets from log output by replacing every match of Pattern with
+// Replacement, which may refer to capture groups like regexp.Regexp.ReplaceAllString.
+type RedactionRule struct {
+	Name        string
+	Pattern     *regexp.Regexp
+	Replacement string
+}
+
+// secretKeyPattern matches names of fields and variables holding secrets, e.g. GITHUB_TOKEN,
+// AWS_SECRET_ACCESS_KEY or SSH_PRIVATE_KEY
+var secretKeyPattern = regexp.MustCompile(`(?i)(token|secret|password|passwd|authorization)|(api|_)key$`)
+
+// passwordArgPattern matches command line arguments followed by a password, e.g. --password
+var passwordArgPattern = regexp.MustCompile(`(?i)^(--password|-p)$`)
+
+// DefaultRedactionRules returns the rules applied by the bash, git and GitHub tools when their
+// configuration sets none. Operators can append their own rules to the result.
+func DefaultRedactionRules() []RedactionRule {
+	return []RedactionRule{
+		{
+			Name:        "github_token",
+			Pattern:     regexp.MustCompile(`\b(gh[pousr]_[A-Za-z0-9]{20,}|github_pat_[A-Za-z0-9_]{20,})\b`),
+			Replacement: redacted,
+		},
+		{
+			Name:        "authorization_header",
+			Pattern:     regexp.MustCompile(`(?i)(authorization["']?\s*[:=]\s*["']?)((?:bearer|basic|token)\s+)?[^\s"',}]+`),
+			Replacement: "${1}" + redacted,
+		},
+		{
+			Name:        "url_credentials",
+			Pattern:     regexp.MustCompile(`(\b[a-z][a-z0-9+.-]*://[^:/@\s"']+:)[^@\s"']+@`),
+			Replacement: "${1}" + redacted + "@",
+		},
+		// -p also takes its value attached, as in mysql -phunter2
+		{
+			Name:        "password_flag",
+			Pattern:     regexp.MustCompile(`((?i:--password)(?:\s*=\s*|\s+|["']\s*,\s*["'])|(?:^|[\s"'\[])-p(?:\s*=\s*|\s+|["']\s*,\s*["'])?)([^\s"',\]-][^\s"',\]]*)`),
+			Replacement: "${1}" + redacted,
+		},
+		{
+			Name:        "secret_assignment",
+			Pattern:     regexp.MustCompile(`(?i)\b([A-Z0-9_]*(?:TOKEN|SECRET|PASSWORD|PASSWD)[A-Z0-9_]*|[A-Z0-9_]*(?:_KEY|APIKEY))(["']?\s*[=:]\s*["']?)([^\s"',}\[][^\s"',}]*)`),
+			Replacement: "${1}${2}" + redacted,
+		},
+	}
+}
+
+// Redactor scrubs secrets from strings using a set of rules
+type Redactor struct {
+	rules []RedactionRule
+}
+
+// NewRedactor returns a Redactor applying rules, or DefaultRedactionRules when rules is nil
+func NewRedactor(rules []RedactionRule) *Redactor {
+	if rules == nil {
+		rules = DefaultRedactionRules()
+	}
+	return &Redactor{rules: rules}
+}
+
+// Redact returns s with every secret matched by the rules replaced
+func (r *Redactor) Redact(s string) string {
+	for _, rule := range r.rules {
+		s = rule.Pattern.ReplaceAllString(s, rule.Replacement)
+	}
+	return s
+}
+
+// redactValue scrubs a logged value. Values of fields named like secrets are replaced
+// entirely; strings, string slices, raw JSON such as tool arguments and errors are scrubbed
+// with the rules and other values are returned unchanged.
+func (r *Redactor) redactValue(key string, value interface{}) interface{} {
+	if key != "" && secretKeyPattern.MatchString(key) {
+		return redacted
+	}
+
+	switch v := value.(type) {
+	case string:
+		return r.Redact(v)
+	case []string:
+		if v == nil {
+			return v
+		}
+		scrubbed := make([]string, len(v))
+		for i, s := range v {
+			// Arguments lists split a flag from its value, e.g. ["--password", "hunter2"]
+			if i > 0 && passwordArgPattern.MatchString(v[i-1]) {
+				scrubbed[i] = redacted
+				continue
+			}
+			scrubbed[i] = r.Redact(s)
+		}
+		return scrubbed
+	case map[string]string:
+		if v == nil {
+			return v
+		}
+		scrubbed := make(map[string]string, len(v))
+		for k, s := range v {
+			scrubbed[k] = r.redactValue(k, s).(string)
+		}
+		return scrubbed
+	case json.RawMessage:
+		return r.Redact(string(v))
+	case []byte:
+		return r.Redact(string(v))
+	case error:
+		return r.redactError(v)
+	default:
+		return value
+	}
+}
+
+// redactError returns err unchanged unless its message contains a secret
+func (r *Redactor) redactError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if msg := r.Redact(err.Error()); msg != err.Error() {
+		return errors.New(msg)
+	}
+	return err
+}
+
+// redactingLogger is a goai.Logger that scrubs secrets from fields, errors and messages before
+// passing them to the wrapped logger
+type redactingLogger struct {
+	logger   goai.Logger
+	redactor *Redactor
+}
+
+// newRedactingLogger wraps logger so that everything logged is scrubbed with rules, or with
+// DefaultRedactionRules when rules is nil
+func newRedactingLogger(logger goai.Logger, rules []RedactionRule) goai.Logger {
+	if logger == nil {
+		return nil
+	}
+	return &redactingLogger{logger: logger, redactor: NewRedactor(rules)}
+}
+
+func (l *redactingLogger) args(args []interface{}) []interface{} {
+	scrubbed := make([]interface{}, len(args))
+	for i, arg := range args {
+		// Arguments alternate between keys and values, e.g. Info("msg", "token", value)
+		key := ""
+		if i > 0 {
+			if k, ok := args[i-1].(string); ok && i%2 == 0 {
+				key = strings.TrimSpace(k)
+			}
+		}
+		scrubbed[i] = l.redactor.redactValue(key, arg)
+	}
+	return scrubbed
+}
+
+func (l *redactingLogger) Debug(args ...interface{}) { l.logger.Debug(l.args(args)...) }
+func (l *redactingLogger) Info(args ...interface{})  { l.logger.Info(l.args(args)...) }
+func (l *redactingLogger) Warn(args ...interface{})  { l.logger.Warn(l.args(args)...) }
+func (l *redactingLogger) Error(args ...interface{}) { l.logger.Error(l.args(args)...) }
+
+func (l *redactingLogger) WithFields(fields map[string]interface{}) goai.Logger {
+	scrubbed := make(map[string]interface{}, len(fields))
+	for key, value := range fields {
+		scrubbed[key] = l.redactor.redactValue(key, value)
+	}
+	return &redactingLogger{logger: l.logger.WithFields(scrubbed), redactor: l.redactor}
+}
+
+func (l *redactingLogger) WithContext(ctx context.Context) goai.Logger {
+	return &redactingLogger{logger: l.logger.WithContext(ctx), redactor: l.redactor}
+}
+
+func (l *redactingLogger) WithErr(err error) goai.Logger {
+	return &redactingLogger{logger: l.logger.WithErr(l.redactor.redactError(err)), redactor: l.redactor}
+}
+
+// maskArguments returns the JSON arguments of a call with the values of the given fields
+// replaced, for logging. Arguments that are not a JSON object are masked entirely.
+func maskArguments(arguments json.RawMessage, fields ...string) string {
+	var values map[string]interface{}
+	if err := json.Unmarshal(arguments, &values); err != nil {
+		return redacted
+	}
+	for _, field := range fields {
+		if _, ok := values[field]; ok {
+			values[field] = redacted
+		}
+	}
+	masked, err := marshalResult(values)
+	if err != nil {
+		return redacted
+	}
+	return masked
+}
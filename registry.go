@@ -0,0 +1,167 @@
+package mcptools
+
+import (
+	"github.com/shaharia-lab/goai"
+	"go.opentelemetry.io/otel/metric"
+	"google.golang.org/api/gmail/v1"
+)
+
+// RegistryConfig holds the dependencies and configuration AllTools needs to construct the
+// tools of the package. Tools whose dependencies are missing are left out.
+type RegistryConfig struct {
+	Logger goai.Logger
+
+	Bash       BashConfig
+	Curl       CurlConfig
+	FileSystem FileSystemConfig
+	Git        GitConfig
+	PostgreSQL PostgreSQLConfig
+
+	// GitHub configures the GitHub tools. When nil they are left out.
+	GitHub *GitHubConfig
+	// GmailService is the authenticated Gmail API service. When nil the Gmail tool is left out.
+	GmailService *gmail.Service
+	Gmail        GmailConfig
+	// WeatherProvider backs the current weather tool. When nil a StaticWeatherProvider is used.
+	WeatherProvider WeatherProvider
+	// HourlyForecastProvider backs the hourly forecast tool. When nil the tool is left out.
+	HourlyForecastProvider HourlyForecastProvider
+
+	// RedactionRules, when set, are used by the bash, git and GitHub tools whose own
+	// configuration sets no rules.
+	RedactionRules []RedactionRule
+
+	// Meter, when set, records call counts, failures and durations of the tools through
+	// WithMetrics. When nil no metrics are recorded.
+	Meter metric.Meter
+
+	// Include, when not empty, limits the tools to the ones with these names.
+	Include []string
+	// Exclude leaves out the tools with these names.
+	Exclude []string
+}
+
+// AllTools constructs every tool of the package that config provides dependencies for, in a
+// stable order, filtered by config.Include and config.Exclude. The result is ready to be
+// registered with a goai server, e.g. through Serve. Invalid configuration, such as a
+// malformed GitHub Enterprise URL, is reported as an error.
+func AllTools(config RegistryConfig) ([]goai.Tool, error) {
+	if config.RedactionRules != nil {
+		if config.Bash.RedactionRules == nil {
+			config.Bash.RedactionRules = config.RedactionRules
+		}
+		if config.Git.RedactionRules == nil {
+			config.Git.RedactionRules = config.RedactionRules
+		}
+		if config.GitHub != nil && config.GitHub.RedactionRules == nil {
+			github := *config.GitHub
+			github.RedactionRules = config.RedactionRules
+			config.GitHub = &github
+		}
+	}
+
+	git := NewGit(config.Logger, config.Git)
+	weather := config.WeatherProvider
+	if weather == nil {
+		weather = StaticWeatherProvider{}
+	}
+
+	tools := []goai.Tool{
+		NewBashWithConfig(config.Logger, config.Bash).BashAllInOneTool(),
+		NewCat(config.Logger).CatAllInOneTool(),
+		NewCurl(config.Logger, config.Curl).CurlAllInOneTool(),
+		NewDocker(config.Logger).DockerAllInOneTool(),
+		NewFileSystem(config.Logger, config.FileSystem).FileSystemAllInOneTool(),
+		git.GitAllInOneTool(),
+		git.GitFormatPatchTool(),
+		git.GitPrepareWorkspaceTool(),
+		git.GitChurnTool(),
+		git.GitEOLTool(),
+		git.GitCheckAttrTool(),
+		git.GitStashTool(),
+		git.GitChangelogTool(),
+		git.GitPickaxeTool(),
+		git.GitResolveConflictsTool(),
+		git.GitStatusTool(),
+		git.GitLogTool(),
+		git.GitCloneTool(),
+		git.GitDiffTool(),
+		git.GitCommitTool(),
+		git.GitPushTool(),
+		git.GitPullTool(),
+	}
+
+	if config.GitHub != nil {
+		gh, err := NewGitHub(config.Logger, *config.GitHub)
+		if err != nil {
+			return nil, err
+		}
+		tools = append(tools,
+			gh.GetIssuesTool(),
+			gh.GetPullRequestsTool(),
+			gh.GetRepositoryTool(),
+			gh.GetSearchTool(),
+			gh.GetWorkflowTool(),
+			gh.GetUserTool(),
+			gh.GetDiscussionsTool(),
+			gh.GetContentsTool(),
+			gh.GetReleaseTool(),
+			gh.GetCollaboratorTool(),
+			gh.GetTagTool(),
+			gh.GetGistTool(),
+			gh.GetStatusTool(),
+			gh.GetDeployKeyTool(),
+			gh.GetRateLimitTool(),
+			gh.GetLabelTool(),
+			gh.GetMilestoneTool(),
+			gh.GetWebhookTool(),
+		)
+	}
+	if config.GmailService != nil {
+		tools = append(tools, NewGmail(config.Logger, config.GmailService, config.Gmail).GmailAllInOneTool())
+	}
+
+	tools = append(tools,
+		NewGrep(config.Logger).GrepAllInOneTool(),
+		NewPostgreSQL(config.Logger, config.PostgreSQL).PostgreSQLAllInOneTool(),
+		NewSed(config.Logger).SedAllInOneTool(),
+		GetWeatherTool(weather),
+	)
+	if config.HourlyForecastProvider != nil {
+		tools = append(tools, GetHourlyForecastTool(config.HourlyForecastProvider))
+	}
+
+	tools = filterTools(tools, config.Include, config.Exclude)
+	if config.Meter != nil {
+		tools = ApplyMiddleware(tools, WithMetrics(config.Meter))
+	}
+	return tools, nil
+}
+
+// filterTools keeps the tools named in include, or all of them when include is empty,
+// and drops the ones named in exclude
+func filterTools(tools []goai.Tool, include, exclude []string) []goai.Tool {
+	included := toSet(include)
+	excluded := toSet(exclude)
+
+	var filtered []goai.Tool
+	for _, tool := range tools {
+		if len(included) > 0 && !included[tool.Name] {
+			continue
+		}
+		if excluded[tool.Name] {
+			continue
+		}
+		filtered = append(filtered, tool)
+	}
+	return filtered
+}
+
+// toSet returns the values as a set
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
@@ -0,0 +1,143 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/shaharia-lab/goai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/gmail/v1"
+)
+
+// allToolNames lists the name constant of every tool in the package
+var allToolNames = []string{
+	BashToolName,
+	CatToolName,
+	CurlToolName,
+	DockerToolName,
+	FileSystemToolName,
+	GitToolName,
+	GitFormatPatchToolName,
+	GitWorkspaceToolName,
+	GitChurnToolName,
+	GitEOLToolName,
+	GitCheckAttrToolName,
+	GitStashToolName,
+	GitChangelogToolName,
+	GitPickaxeToolName,
+	GitConflictsToolName,
+	GitStatusToolName,
+	GitLogToolName,
+	GitCloneToolName,
+	GitDiffToolName,
+	GitCommitToolName,
+	GitPushToolName,
+	GitPullToolName,
+	GitHubIssuesToolName,
+	GitHubPullRequestsToolName,
+	GitHubRepositoryToolName,
+	GitHubSearchToolName,
+	GitHubWorkflowsToolName,
+	GitHubUserToolName,
+	GitHubDiscussionsToolName,
+	GitHubContentsToolName,
+	GitHubReleasesToolName,
+	GitHubCollaboratorsToolName,
+	GitHubTagsToolName,
+	GitHubGistsToolName,
+	GitHubStatusesToolName,
+	GitHubDeployKeysToolName,
+	GitHubRateLimitToolName,
+	GitHubLabelsToolName,
+	GitHubMilestonesToolName,
+	GitHubWebhooksToolName,
+	GmailToolName,
+	GrepToolName,
+	PostgreSQLToolName,
+	SedToolName,
+	WeatherToolName,
+	HourlyForecastToolName,
+}
+
+// toolNames returns the names of tools in order
+func toolNames(tools []goai.Tool) []string {
+	names := make([]string, len(tools))
+	for i, tool := range tools {
+		names[i] = tool.Name
+	}
+	return names
+}
+
+func TestAllTools(t *testing.T) {
+	tools, err := AllTools(RegistryConfig{
+		Logger:                 newPermissiveLogger(),
+		GitHub:                 &GitHubConfig{Token: "token"},
+		GmailService:           &gmail.Service{},
+		HourlyForecastProvider: new(MockHourlyForecastProvider),
+	})
+	require.NoError(t, err)
+
+	names := toolNames(tools)
+	assert.ElementsMatch(t, allToolNames, names)
+
+	seen := map[string]bool{}
+	for _, tool := range tools {
+		assert.False(t, seen[tool.Name], "duplicate tool %s", tool.Name)
+		seen[tool.Name] = true
+		assert.NotEmpty(t, tool.Description, tool.Name)
+		assert.NotNil(t, tool.Handler, tool.Name)
+	}
+}
+
+func TestAllTools_OptionalDependencies(t *testing.T) {
+	tools, err := AllTools(RegistryConfig{Logger: newPermissiveLogger()})
+	require.NoError(t, err)
+	names := toolNames(tools)
+
+	assert.Contains(t, names, GitToolName)
+	assert.Contains(t, names, WeatherToolName)
+	assert.NotContains(t, names, GitHubIssuesToolName)
+	assert.NotContains(t, names, GmailToolName)
+	assert.NotContains(t, names, HourlyForecastToolName)
+}
+
+func TestAllTools_IncludeExclude(t *testing.T) {
+	tools, err := AllTools(RegistryConfig{
+		Logger:  newPermissiveLogger(),
+		GitHub:  &GitHubConfig{},
+		Include: []string{GitToolName, GitStashToolName, BashToolName, GitHubIssuesToolName},
+		Exclude: []string{GitHubIssuesToolName},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{BashToolName, GitToolName, GitStashToolName}, toolNames(tools))
+}
+
+func TestAllTools_InvalidGitHubConfig(t *testing.T) {
+	tools, err := AllTools(RegistryConfig{
+		Logger: newPermissiveLogger(),
+		GitHub: &GitHubConfig{BaseURL: "github.example.com"},
+	})
+
+	assert.EqualError(t, err, `invalid GitHub BaseURL "github.example.com": must be an absolute http or https URL`)
+	assert.Nil(t, tools)
+}
+
+func TestAllTools_Meter(t *testing.T) {
+	meter := newRecordingMeter()
+	tools, err := AllTools(RegistryConfig{
+		Logger:  newPermissiveLogger(),
+		Meter:   meter,
+		Include: []string{WeatherToolName},
+	})
+	require.NoError(t, err)
+	require.Len(t, tools, 1)
+
+	_, err = tools[0].Handler(context.Background(), goai.CallToolParams{Name: WeatherToolName, Arguments: json.RawMessage(`{"location": "Berlin"}`)})
+	require.NoError(t, err)
+
+	assert.Len(t, meter.measurements[ToolInvocationsMetricName], 1)
+	assert.Len(t, meter.measurements[ToolDurationMetricName], 1)
+}
@@ -0,0 +1,53 @@
+package mcptools
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/shaharia-lab/goai"
+)
+
+// NewServer returns an MCP server that exposes tools over the JSON-RPC stdio transport,
+// reading requests from in and writing responses to out. The logger must not write to out,
+// otherwise log lines corrupt the protocol stream.
+func NewServer(logger goai.Logger, tools []goai.Tool, in io.Reader, out io.Writer) (*goai.StdIOServer, error) {
+	base, err := goai.NewBaseServer(goai.UseLogger(logger))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create MCP server: %w", err)
+	}
+
+	if err := base.AddTools(ApplyMiddleware(tools, withTextContent)...); err != nil {
+		return nil, fmt.Errorf("failed to register tools: %w", err)
+	}
+
+	return goai.NewStdIOServer(base, in, out), nil
+}
+
+// Serve exposes tools over the MCP stdio transport until in is exhausted or ctx is cancelled,
+// so the package can run as a standalone MCP server binary:
+//
+//	err := mcptools.Serve(ctx, logger, tools, os.Stdin, os.Stdout)
+func Serve(ctx context.Context, logger goai.Logger, tools []goai.Tool, in io.Reader, out io.Writer) error {
+	server, err := NewServer(logger, tools, in, out)
+	if err != nil {
+		return err
+	}
+	return server.Run(ctx)
+}
+
+// withTextContent reports "json" content blocks as "text", since MCP clients only accept
+// the content types defined by the protocol.
+func withTextContent(tool goai.Tool) goai.Tool {
+	handler := tool.Handler
+	tool.Handler = func(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
+		result, err := handler(ctx, params)
+		for i, content := range result.Content {
+			if content.Type == "json" {
+				result.Content[i].Type = "text"
+			}
+		}
+		return result, err
+	}
+	return tool
+}
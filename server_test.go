@@ -0,0 +1,136 @@
+package mcptools
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/shaharia-lab/goai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// rpcResponse is a JSON-RPC response written by the server
+type rpcResponse struct {
+	ID     int             `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// stdioSession drives a server through in-memory pipes
+type stdioSession struct {
+	t         *testing.T
+	requests  *io.PipeWriter
+	responses *bufio.Scanner
+}
+
+// send writes a JSON-RPC message to the server
+func (s *stdioSession) send(message string) {
+	s.t.Helper()
+	_, err := s.requests.Write([]byte(message + "\n"))
+	require.NoError(s.t, err)
+}
+
+// receive reads the next JSON-RPC response from the server
+func (s *stdioSession) receive() rpcResponse {
+	s.t.Helper()
+	require.True(s.t, s.responses.Scan(), "expected a response")
+
+	var resp rpcResponse
+	require.NoError(s.t, json.Unmarshal(s.responses.Bytes(), &resp))
+	return resp
+}
+
+// startStdioSession serves tools over in-memory pipes and completes the MCP handshake
+func startStdioSession(t *testing.T, tools ...goai.Tool) *stdioSession {
+	t.Helper()
+	logger := newPermissiveLogger()
+	logger.On("WithErr", mock.Anything).Return(logger).Maybe()
+
+	inReader, inWriter := io.Pipe()
+	outReader, outWriter := io.Pipe()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- Serve(ctx, logger, tools, inReader, outWriter)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		_ = inWriter.Close()
+		_ = outReader.Close()
+		<-done
+	})
+
+	session := &stdioSession{t: t, requests: inWriter, responses: bufio.NewScanner(outReader)}
+	session.send(`{"jsonrpc": "2.0", "id": 1, "method": "initialize", "params": {"protocolVersion": "2024-11-05", "capabilities": {}, "clientInfo": {"name": "test", "version": "1.0"}}}`)
+	require.Nil(t, session.receive().Error)
+	session.send(`{"jsonrpc": "2.0", "method": "notifications/initialized"}`)
+	return session
+}
+
+// newEchoTool returns a tool that responds with its arguments as json content
+func newEchoTool() goai.Tool {
+	return goai.Tool{
+		Name:        "echo",
+		Description: "Echoes its input as JSON",
+		InputSchema: json.RawMessage(`{"type": "object", "properties": {"message": {"type": "string"}}}`),
+		Handler: func(ctx context.Context, params goai.CallToolParams) (goai.CallToolResult, error) {
+			return goai.CallToolResult{Content: []goai.ToolResultContent{{Type: "json", Text: string(params.Arguments)}}}, nil
+		},
+	}
+}
+
+func TestServe_ListAndCallTools(t *testing.T) {
+	session := startStdioSession(t, newEchoTool())
+
+	session.send(`{"jsonrpc": "2.0", "id": 2, "method": "tools/list"}`)
+	resp := session.receive()
+	require.Nil(t, resp.Error)
+	assert.Equal(t, 2, resp.ID)
+
+	var listed struct {
+		Tools []struct {
+			Name        string          `json:"name"`
+			Description string          `json:"description"`
+			InputSchema json.RawMessage `json:"inputSchema"`
+		} `json:"tools"`
+	}
+	require.NoError(t, json.Unmarshal(resp.Result, &listed))
+	require.Len(t, listed.Tools, 1)
+	assert.Equal(t, "echo", listed.Tools[0].Name)
+	assert.Equal(t, "Echoes its input as JSON", listed.Tools[0].Description)
+	assert.JSONEq(t, `{"type": "object", "properties": {"message": {"type": "string"}}}`, string(listed.Tools[0].InputSchema))
+
+	session.send(`{"jsonrpc": "2.0", "id": 3, "method": "tools/call", "params": {"name": "echo", "arguments": {"message": "hi"}}}`)
+	resp = session.receive()
+	require.Nil(t, resp.Error)
+	assert.Equal(t, 3, resp.ID)
+
+	var called goai.CallToolResult
+	require.NoError(t, json.Unmarshal(resp.Result, &called))
+	require.Len(t, called.Content, 1)
+	assert.Equal(t, "text", called.Content[0].Type)
+	assert.JSONEq(t, `{"message": "hi"}`, called.Content[0].Text)
+	assert.False(t, called.IsError)
+}
+
+func TestServe_UnknownTool(t *testing.T) {
+	session := startStdioSession(t, newEchoTool())
+
+	session.send(`{"jsonrpc": "2.0", "id": 2, "method": "tools/call", "params": {"name": "missing", "arguments": {}}}`)
+	resp := session.receive()
+	require.NotNil(t, resp.Error)
+	assert.Contains(t, resp.Error.Message, "missing")
+}
+
+func TestNewServer_RejectsInvalidTool(t *testing.T) {
+	_, err := NewServer(newPermissiveLogger(), []goai.Tool{newTextTool("plain", "ok")}, nil, io.Discard)
+	assert.ErrorContains(t, err, "failed to register tools")
+}
@@ -0,0 +1,77 @@
+package mcptools
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultWeatherCacheTTL is a sensible TTL for NewCachedWeatherProvider
+const DefaultWeatherCacheTTL = 10 * time.Minute
+
+// CachedWeatherProvider is a WeatherProvider that remembers the weather reported by another
+// provider for a TTL, so repeated lookups of the same location do not reach the upstream API.
+// It is safe for concurrent use.
+type CachedWeatherProvider struct {
+	provider WeatherProvider
+	ttl      time.Duration
+	now      func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]weatherCacheEntry
+}
+
+// weatherCacheEntry is a cached lookup and the time it expires
+type weatherCacheEntry struct {
+	weather Weather
+	expires time.Time
+}
+
+// NewCachedWeatherProvider returns provider wrapped in a cache keeping lookups for ttl.
+// A zero or negative ttl disables caching and returns provider unchanged.
+func NewCachedWeatherProvider(provider WeatherProvider, ttl time.Duration) WeatherProvider {
+	if ttl <= 0 {
+		return provider
+	}
+	return &CachedWeatherProvider{
+		provider: provider,
+		ttl:      ttl,
+		now:      time.Now,
+		entries:  map[string]weatherCacheEntry{},
+	}
+}
+
+// Current returns the cached weather of location in units, looking it up when it is missing or
+// expired. Failed lookups are not cached.
+func (c *CachedWeatherProvider) Current(ctx context.Context, location string, units Units) (Weather, error) {
+	key := weatherCacheKey(location, units)
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && c.now().Before(entry.expires) {
+		return entry.weather, nil
+	}
+
+	weather, err := c.provider.Current(ctx, location, units)
+	if err != nil {
+		return Weather{}, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	for k, e := range c.entries {
+		if !now.Before(e.expires) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = weatherCacheEntry{weather: weather, expires: now.Add(c.ttl)}
+	return weather, nil
+}
+
+// weatherCacheKey normalizes a location, ignoring case and extra whitespace, and combines it with units
+func weatherCacheKey(location string, units Units) string {
+	return strings.ToLower(strings.Join(strings.Fields(location), " ")) + "|" + string(units)
+}
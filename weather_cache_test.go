@@ -0,0 +1,80 @@
+package mcptools
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCachedWeatherProvider(t *testing.T) {
+	upstream := new(MockWeatherProvider)
+	upstream.On("Current", mock.Anything, "San Francisco, CA", UnitsMetric).Return(Weather{Location: "San Francisco", Temperature: 18, Condition: "Fog"}, nil).Once()
+	upstream.On("Current", mock.Anything, "san francisco,  ca", UnitsImperial).Return(Weather{Location: "San Francisco", Temperature: 64, Condition: "Fog"}, nil).Once()
+
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	provider := NewCachedWeatherProvider(upstream, time.Minute).(*CachedWeatherProvider)
+	provider.now = func() time.Time { return now }
+
+	for _, location := range []string{"San Francisco, CA", "  san francisco,  CA "} {
+		weather, err := provider.Current(context.Background(), location, UnitsMetric)
+		require.NoError(t, err)
+		assert.Equal(t, 18.0, weather.Temperature)
+	}
+
+	// Units are part of the key
+	weather, err := provider.Current(context.Background(), "san francisco,  ca", UnitsImperial)
+	require.NoError(t, err)
+	assert.Equal(t, 64.0, weather.Temperature)
+
+	// Expired entries are looked up again
+	now = now.Add(time.Minute)
+	upstream.On("Current", mock.Anything, "San Francisco, CA", UnitsMetric).Return(Weather{Location: "San Francisco", Temperature: 19, Condition: "Fog"}, nil).Once()
+	weather, err = provider.Current(context.Background(), "San Francisco, CA", UnitsMetric)
+	require.NoError(t, err)
+	assert.Equal(t, 19.0, weather.Temperature)
+
+	upstream.AssertExpectations(t)
+}
+
+func TestCachedWeatherProvider_ErrorsAreNotCached(t *testing.T) {
+	upstream := new(MockWeatherProvider)
+	upstream.On("Current", mock.Anything, "Berlin", UnitsMetric).Return(Weather{}, errors.New("unavailable")).Once()
+	upstream.On("Current", mock.Anything, "Berlin", UnitsMetric).Return(Weather{Location: "Berlin", Temperature: 12}, nil).Once()
+
+	provider := NewCachedWeatherProvider(upstream, time.Minute)
+
+	_, err := provider.Current(context.Background(), "Berlin", UnitsMetric)
+	assert.Error(t, err)
+
+	weather, err := provider.Current(context.Background(), "Berlin", UnitsMetric)
+	require.NoError(t, err)
+	assert.Equal(t, 12.0, weather.Temperature)
+	upstream.AssertExpectations(t)
+}
+
+func TestNewCachedWeatherProvider_ZeroTTLDisablesCaching(t *testing.T) {
+	upstream := StaticWeatherProvider{}
+	assert.Equal(t, WeatherProvider(upstream), NewCachedWeatherProvider(upstream, 0))
+}
+
+func TestCachedWeatherProvider_Concurrent(t *testing.T) {
+	provider := NewCachedWeatherProvider(StaticWeatherProvider{}, time.Minute)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			units := []Units{UnitsMetric, UnitsImperial}[i%2]
+			_, err := provider.Current(context.Background(), "Paris", units)
+			assert.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+}
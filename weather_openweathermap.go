@@ -0,0 +1,90 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// openWeatherMapBaseURL is the OpenWeatherMap API root
+const openWeatherMapBaseURL = "https://api.openweathermap.org"
+
+// OpenWeatherMapProvider is a WeatherProvider backed by the OpenWeatherMap current weather API
+type OpenWeatherMapProvider struct {
+	apiKey  string
+	baseURL string
+	client  *http.Client
+}
+
+// NewOpenWeatherMapProvider creates an OpenWeatherMap provider authenticated with apiKey
+func NewOpenWeatherMapProvider(apiKey string) *OpenWeatherMapProvider {
+	return &OpenWeatherMapProvider{
+		apiKey:  apiKey,
+		baseURL: openWeatherMapBaseURL,
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// openWeatherMapResponse is the subset of the current weather response the provider uses
+type openWeatherMapResponse struct {
+	Name    string `json:"name"`
+	Weather []struct {
+		Main        string `json:"main"`
+		Description string `json:"description"`
+	} `json:"weather"`
+	Main struct {
+		Temp float64 `json:"temp"`
+	} `json:"main"`
+	Message string `json:"message"`
+}
+
+// Current returns the current weather of location, e.g. "London,GB", in the given units
+func (p *OpenWeatherMapProvider) Current(ctx context.Context, location string, units Units) (Weather, error) {
+	query := url.Values{}
+	query.Set("q", location)
+	query.Set("appid", p.apiKey)
+	query.Set("units", string(units))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+"/data/2.5/weather?"+query.Encode(), nil)
+	if err != nil {
+		return Weather{}, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return Weather{}, fmt.Errorf("failed to query OpenWeatherMap: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var body openWeatherMapResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return Weather{}, fmt.Errorf("failed to decode OpenWeatherMap response (status %d): %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return Weather{}, fmt.Errorf("OpenWeatherMap returned status %d: %s", resp.StatusCode, body.Message)
+	}
+
+	weather := Weather{Location: body.Name, Temperature: body.Main.Temp}
+	if weather.Location == "" {
+		weather.Location = location
+	}
+	if len(body.Weather) > 0 {
+		weather.Condition = capitalize(body.Weather[0].Description)
+		if weather.Condition == "" {
+			weather.Condition = body.Weather[0].Main
+		}
+	}
+	return weather, nil
+}
+
+// capitalize upper-cases the first letter of s
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
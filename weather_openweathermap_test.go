@@ -0,0 +1,46 @@
+package mcptools
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestOpenWeatherMapProvider returns a provider that sends its requests to handler
+func newTestOpenWeatherMapProvider(t *testing.T, handler http.HandlerFunc) *OpenWeatherMapProvider {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	provider := NewOpenWeatherMapProvider("test-key")
+	provider.baseURL = server.URL
+	return provider
+}
+
+func TestOpenWeatherMapProvider_Current(t *testing.T) {
+	provider := newTestOpenWeatherMapProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/data/2.5/weather", r.URL.Path)
+		assert.Equal(t, "London,GB", r.URL.Query().Get("q"))
+		assert.Equal(t, "test-key", r.URL.Query().Get("appid"))
+		assert.Equal(t, "metric", r.URL.Query().Get("units"))
+		_, _ = w.Write([]byte(`{"name": "London", "weather": [{"main": "Clouds", "description": "broken clouds"}], "main": {"temp": 14.5}}`))
+	})
+
+	weather, err := provider.Current(context.Background(), "London,GB", UnitsMetric)
+	require.NoError(t, err)
+	assert.Equal(t, Weather{Location: "London", Temperature: 14.5, Condition: "Broken clouds"}, weather)
+}
+
+func TestOpenWeatherMapProvider_Current_Error(t *testing.T) {
+	provider := newTestOpenWeatherMapProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"cod": "404", "message": "city not found"}`))
+	})
+
+	_, err := provider.Current(context.Background(), "Atlantis", UnitsImperial)
+	assert.EqualError(t, err, "OpenWeatherMap returned status 404: city not found")
+}